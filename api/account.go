@@ -4,15 +4,59 @@ import (
     "database/sql"
     "errors"
     "net/http"
+    "time"
 
     "github.com/gin-gonic/gin"
+    "github.com/lib/pq"
     db "github.com/xiusl/bank/db/sqlc"
+    "github.com/xiusl/bank/money"
+    "github.com/xiusl/bank/policy"
     "github.com/xiusl/bank/token"
 )
 
+// accountResponse 账户信息，金额按账户货币的最小单位格式化；
+// available_balance 为余额减去未过期的预授权金额，只在查询单个账户时返回
+type accountResponse struct {
+    ID               int64        `json:"id"`
+    Owner            string       `json:"owner"`
+    Currency         string       `json:"currency"`
+    AccountType      string       `json:"account_type"`
+    Nickname         string       `json:"nickname"`
+    Balance          money.Money  `json:"balance"`
+    AvailableBalance *money.Money `json:"available_balance,omitempty"`
+    OverdraftLimit   money.Money  `json:"overdraft_limit"`
+    Status           string       `json:"status"`
+    CreatedAt        time.Time    `json:"created_at"`
+}
+
+func newAccountResponse(account db.Account) accountResponse {
+    return accountResponse{
+        ID:             account.ID,
+        Owner:          account.Owner,
+        Currency:       account.Currency,
+        AccountType:    account.AccountType,
+        Nickname:       account.Nickname,
+        Balance:        money.New(account.Balance, account.Currency),
+        OverdraftLimit: money.New(account.OverdraftLimit, account.Currency),
+        Status:         account.Status,
+        CreatedAt:      account.CreatedAt,
+    }
+}
+
+func newAccountsResponse(accounts []db.Account) []accountResponse {
+    rsp := make([]accountResponse, len(accounts))
+    for i, account := range accounts {
+        rsp[i] = newAccountResponse(account)
+    }
+    return rsp
+}
+
+// CreateAccountRequest 不传 account_type 时开活期账户
 type CreateAccountRequest struct {
-    Owner    string `json:"owner" binding:"required"`
-    Currency string `json:"currency" binding:"required,currency"`
+    Owner       string `json:"owner" binding:"required"`
+    Currency    string `json:"currency" binding:"required,currency"`
+    AccountType string `json:"account_type" binding:"omitempty,oneof=checking savings"`
+    Nickname    string `json:"nickname" binding:"max=64"`
 }
 
 func (server *Server) createAccount(ctx *gin.Context) {
@@ -22,20 +66,34 @@ func (server *Server) createAccount(ctx *gin.Context) {
         return
     }
 
+    accountType := req.AccountType
+    if accountType == "" {
+        accountType = db.AccountTypeChecking
+    }
+
     authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
     arg := db.CreateAccountParams{
-        Owner:    authPayload.Username,
-        Currency: req.Currency,
-        Balance:  0,
+        Owner:       authPayload.Username,
+        Currency:    req.Currency,
+        Balance:     0,
+        AccountType: accountType,
+        Nickname:    req.Nickname,
     }
 
-    account, err := server.store.CreateAccount(ctx, arg)
+    account, err := server.store.CreateAccountTx(ctx, arg)
     if err != nil {
+        if pqErr, ok := err.(*pq.Error); ok {
+            switch pqErr.Code.Name() {
+            case "unique_violation":
+                ctx.JSON(http.StatusForbidden, errorResponse(err))
+                return
+            }
+        }
         ctx.JSON(http.StatusInternalServerError, errorResponse(err))
         return
     }
 
-    ctx.JSON(http.StatusOK, account)
+    ctx.JSON(http.StatusOK, newAccountResponse(account))
 }
 
 type getAccountRequest struct {
@@ -48,29 +106,48 @@ func (server *Server) getAccount(ctx *gin.Context) {
         ctx.JSON(http.StatusBadRequest, errorResponse(err))
         return
     }
-    account, err := server.store.GetAccount(ctx, req.ID)
+    account, valid := server.authorizedAccount(ctx, req.ID, policy.ViewAccount)
+    if !valid {
+        return
+    }
+
+    held, err := server.store.GetHeldAmount(ctx, account.ID)
     if err != nil {
-        if err == sql.ErrNoRows {
-            ctx.JSON(http.StatusNotFound, errorResponse(err))
-            return
-        }
         ctx.JSON(http.StatusInternalServerError, errorResponse(err))
         return
     }
 
-    authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
-    if authPayload.Username != account.Owner {
-        err := errors.New("account does't belong to the authorizated user")
-        ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+    balance := money.New(account.Balance, account.Currency)
+    available, err := balance.Sub(money.New(held, account.Currency))
+    if err != nil {
+        ctx.JSON(http.StatusInternalServerError, errorResponse(err))
         return
     }
 
-    ctx.JSON(http.StatusOK, account)
+    rsp := newAccountResponse(account)
+    rsp.AvailableBalance = &available
+    ctx.JSON(http.StatusOK, rsp)
+}
+
+// authorizedAccount 获取账户并检查当前登录用户能否对其执行 action
+func (server *Server) authorizedAccount(ctx *gin.Context, accountID int64, action policy.Action) (db.Account, bool) {
+    account, err := server.store.GetAccount(ctx, accountID)
+    if err != nil {
+        if err == sql.ErrNoRows {
+            ctx.JSON(http.StatusNotFound, errorResponse(err))
+            return account, false
+        }
+        ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+        return account, false
+    }
+
+    return account, authorizeAccount(ctx, account, action)
 }
 
 type listAccountRequest struct {
-    PageID   int32 `form:"page_id" binding:"required,min=1"`
-    PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+    PageID      int32  `form:"page_id" binding:"required,min=1"`
+    PageSize    int32  `form:"page_size" binding:"required,min=5,max=10"`
+    AccountType string `form:"account_type" binding:"omitempty,oneof=checking savings"`
 }
 
 func (server *Server) listAccount(ctx *gin.Context) {
@@ -82,9 +159,10 @@ func (server *Server) listAccount(ctx *gin.Context) {
 
     authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
     arg := db.ListAccountsParams{
-        Owner:  authPayload.Username,
-        Limit:  req.PageSize,
-        Offset: (req.PageID - 1) * req.PageSize,
+        Owner:       authPayload.Username,
+        AccountType: req.AccountType,
+        Limit:       req.PageSize,
+        Offset:      (req.PageID - 1) * req.PageSize,
     }
     accounts, err := server.store.ListAccounts(ctx, arg)
 
@@ -92,5 +170,60 @@ func (server *Server) listAccount(ctx *gin.Context) {
         ctx.JSON(http.StatusInternalServerError, errorResponse(err))
         return
     }
-    ctx.JSON(http.StatusOK, accounts)
+    ctx.JSON(http.StatusOK, newAccountsResponse(accounts))
+}
+
+type updateAccountStatusRequest struct {
+    Status string `json:"status" binding:"required,oneof=active frozen closed"`
+}
+
+// updateAccountStatus 变更账户状态：冻结和解冻只有管理员可以操作，
+// 销户和重新开户账户本人也可以操作
+func (server *Server) updateAccountStatus(ctx *gin.Context) {
+    var uri getAccountRequest
+    if err := ctx.ShouldBindUri(&uri); err != nil {
+        ctx.JSON(http.StatusBadRequest, errorResponse(err))
+        return
+    }
+    var req updateAccountStatusRequest
+    if err := ctx.ShouldBindJSON(&req); err != nil {
+        ctx.JSON(http.StatusBadRequest, errorResponse(err))
+        return
+    }
+
+    account, err := server.store.GetAccount(ctx, uri.ID)
+    if err != nil {
+        if err == sql.ErrNoRows {
+            ctx.JSON(http.StatusNotFound, errorResponse(err))
+            return
+        }
+        ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+        return
+    }
+
+    action := policy.CloseAccount
+    if req.Status == db.AccountStatusFrozen || account.Status == db.AccountStatusFrozen {
+        action = policy.FreezeAccount
+    }
+    if !authorizeAccount(ctx, account, action) {
+        return
+    }
+
+    arg := db.UpdateAccountStatusTxParams{
+        AccountID: account.ID,
+        Status:    req.Status,
+    }
+    account, err = server.store.UpdateAccountStatusTx(ctx, arg)
+    if err != nil {
+        if errors.Is(err, db.ErrInvalidStatusTransition) ||
+            errors.Is(err, db.ErrNonZeroBalance) ||
+            errors.Is(err, db.ErrActiveHolds) {
+            ctx.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+            return
+        }
+        ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+        return
+    }
+
+    ctx.JSON(http.StatusOK, newAccountResponse(account))
 }
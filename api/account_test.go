@@ -13,10 +13,12 @@ import (
 
     "github.com/gin-gonic/gin"
     "github.com/golang/mock/gomock"
+    "github.com/lib/pq"
     "github.com/stretchr/testify/require"
 
     mockdb "github.com/xiusl/bank/db/mock"
     db "github.com/xiusl/bank/db/sqlc"
+    "github.com/xiusl/bank/money"
     "github.com/xiusl/bank/token"
     "github.com/xiusl/bank/util"
 )
@@ -35,24 +37,28 @@ func TestGetAccountAPI(t *testing.T) {
             name:      "OK",
             accountID: account.ID,
             setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
             },
             buildStuds: func(store *mockdb.MockStore) {
                 store.EXPECT().
                     GetAccount(gomock.Any(), gomock.Eq(account.ID)).
                     Return(account, nil).
                     Times(1)
+                store.EXPECT().
+                    GetHeldAmount(gomock.Any(), gomock.Eq(account.ID)).
+                    Times(1).
+                    Return(int64(10), nil)
             },
             checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
                 require.Equal(t, http.StatusOK, recorder.Code)
-                requireBodyMatchAccount(t, recorder.Body, account)
+                requireBodyMatchAvailableBalance(t, recorder.Body, account, account.Balance-10)
             },
         },
         {
             name:      "Not Found",
             accountID: account.ID,
             setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
             },
             buildStuds: func(store *mockdb.MockStore) {
                 store.EXPECT().
@@ -68,7 +74,7 @@ func TestGetAccountAPI(t *testing.T) {
             name:      "InternalError",
             accountID: account.ID,
             setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
             },
             buildStuds: func(store *mockdb.MockStore) {
                 store.EXPECT().
@@ -84,7 +90,7 @@ func TestGetAccountAPI(t *testing.T) {
             name:      "InvalidID",
             accountID: 0,
             setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
             },
             buildStuds: func(store *mockdb.MockStore) {
                 const id int64 = 0
@@ -114,7 +120,7 @@ func TestGetAccountAPI(t *testing.T) {
             name:      "InvalidUserName",
             accountID: account.ID,
             setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "invalid", time.Minute)
+                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "invalid", util.DepositorRole, time.Minute)
             },
             buildStuds: func(store *mockdb.MockStore) {
                 store.EXPECT().
@@ -126,6 +132,27 @@ func TestGetAccountAPI(t *testing.T) {
                 require.Equal(t, http.StatusUnauthorized, recorder.Code)
             },
         },
+        {
+            name:      "BankerCanView",
+            accountID: account.ID,
+            setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "banker", util.BankerRole, time.Minute)
+            },
+            buildStuds: func(store *mockdb.MockStore) {
+                store.EXPECT().
+                    GetAccount(gomock.Any(), gomock.Eq(account.ID)).
+                    Times(1).
+                    Return(account, nil)
+                store.EXPECT().
+                    GetHeldAmount(gomock.Any(), gomock.Eq(account.ID)).
+                    Times(1).
+                    Return(int64(0), nil)
+            },
+            checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+                requireBodyMatchAvailableBalance(t, recorder.Body, account, account.Balance)
+            },
+        },
     }
 
     for i := range testCases {
@@ -155,6 +182,10 @@ func TestGetAccountAPI(t *testing.T) {
 func TestCreateAccountAPI(t *testing.T) {
     user, _ := randomUser(t)
     account := randomAccount(user.Username)
+
+    savingsAccount := account
+    savingsAccount.AccountType = db.AccountTypeSavings
+    savingsAccount.Nickname = "rainy day"
     testCases := []struct {
         name          string
         body          gin.H
@@ -169,16 +200,17 @@ func TestCreateAccountAPI(t *testing.T) {
                 "currency": account.Currency,
             },
             setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
             },
             buildStuds: func(store *mockdb.MockStore) {
                 arg := db.CreateAccountParams{
-                    Owner:    account.Owner,
-                    Currency: account.Currency,
-                    Balance:  0,
+                    Owner:       account.Owner,
+                    Currency:    account.Currency,
+                    Balance:     0,
+                    AccountType: db.AccountTypeChecking,
                 }
                 store.EXPECT().
-                    CreateAccount(gomock.Any(), gomock.Eq(arg)).
+                    CreateAccountTx(gomock.Any(), gomock.Eq(arg)).
                     Times(1).
                     Return(account, nil)
             },
@@ -187,6 +219,73 @@ func TestCreateAccountAPI(t *testing.T) {
                 requireBodyMatchAccount(t, recoder.Body, account)
             },
         },
+        {
+            name: "Savings",
+            body: gin.H{
+                "owner":        savingsAccount.Owner,
+                "currency":     savingsAccount.Currency,
+                "account_type": db.AccountTypeSavings,
+                "nickname":     savingsAccount.Nickname,
+            },
+            setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+            },
+            buildStuds: func(store *mockdb.MockStore) {
+                arg := db.CreateAccountParams{
+                    Owner:       savingsAccount.Owner,
+                    Currency:    savingsAccount.Currency,
+                    Balance:     0,
+                    AccountType: db.AccountTypeSavings,
+                    Nickname:    savingsAccount.Nickname,
+                }
+                store.EXPECT().
+                    CreateAccountTx(gomock.Any(), gomock.Eq(arg)).
+                    Times(1).
+                    Return(savingsAccount, nil)
+            },
+            checkResponse: func(recoder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recoder.Code)
+                requireBodyMatchAccount(t, recoder.Body, savingsAccount)
+            },
+        },
+        {
+            name: "InvalidAccountType",
+            body: gin.H{
+                "owner":        account.Owner,
+                "currency":     account.Currency,
+                "account_type": "brokerage",
+            },
+            setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+            },
+            buildStuds: func(store *mockdb.MockStore) {
+                store.EXPECT().
+                    CreateAccountTx(gomock.Any(), gomock.Any()).
+                    Times(0)
+            },
+            checkResponse: func(recoder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusBadRequest, recoder.Code)
+            },
+        },
+        {
+            name: "DuplicateAccountType",
+            body: gin.H{
+                "owner":    account.Owner,
+                "currency": account.Currency,
+            },
+            setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+            },
+            buildStuds: func(store *mockdb.MockStore) {
+                store.EXPECT().
+                    CreateAccountTx(gomock.Any(), gomock.Any()).
+                    Times(1).
+                    Return(db.Account{}, &pq.Error{Code: "23505"})
+            },
+            checkResponse: func(recoder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusForbidden, recoder.Code)
+            },
+        },
         {
             name: "InvalidCurrency",
             body: gin.H{
@@ -194,11 +293,11 @@ func TestCreateAccountAPI(t *testing.T) {
                 "currency": "invalid",
             },
             setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
             },
             buildStuds: func(store *mockdb.MockStore) {
                 store.EXPECT().
-                    CreateAccount(gomock.Any(), gomock.Any()).
+                    CreateAccountTx(gomock.Any(), gomock.Any()).
                     Times(0)
             },
             checkResponse: func(recoder *httptest.ResponseRecorder) {
@@ -212,11 +311,11 @@ func TestCreateAccountAPI(t *testing.T) {
                 "currency": account.Currency,
             },
             setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
             },
             buildStuds: func(store *mockdb.MockStore) {
                 store.EXPECT().
-                    CreateAccount(gomock.Any(), gomock.Any()).
+                    CreateAccountTx(gomock.Any(), gomock.Any()).
                     Times(0)
             },
             checkResponse: func(recoder *httptest.ResponseRecorder) {
@@ -230,11 +329,11 @@ func TestCreateAccountAPI(t *testing.T) {
                 "currency": account.Currency,
             },
             setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
             },
             buildStuds: func(store *mockdb.MockStore) {
                 store.EXPECT().
-                    CreateAccount(gomock.Any(), gomock.Any()).
+                    CreateAccountTx(gomock.Any(), gomock.Any()).
                     Times(1).
                     Return(db.Account{}, sql.ErrConnDone)
             },
@@ -252,7 +351,7 @@ func TestCreateAccountAPI(t *testing.T) {
             },
             buildStuds: func(store *mockdb.MockStore) {
                 store.EXPECT().
-                    CreateAccount(gomock.Any(), gomock.Any()).
+                    CreateAccountTx(gomock.Any(), gomock.Any()).
                     Times(0)
             },
             checkResponse: func(recoder *httptest.ResponseRecorder) {
@@ -299,8 +398,9 @@ func TestListAccountsAPI(t *testing.T) {
     }
 
     type Query struct {
-        pageID   int
-        pageSize int
+        pageID      int
+        pageSize    int
+        accountType string
     }
 
     testCases := []struct {
@@ -317,7 +417,7 @@ func TestListAccountsAPI(t *testing.T) {
                 pageSize: n,
             },
             setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
             },
             buildStubs: func(store *mockdb.MockStore) {
                 arg := db.ListAccountsParams{
@@ -336,6 +436,53 @@ func TestListAccountsAPI(t *testing.T) {
                 requireBodyMatchAccounts(t, recorder.Body, accounts)
             },
         },
+        {
+            name: "FilterByAccountType",
+            query: Query{
+                pageID:      1,
+                pageSize:    n,
+                accountType: db.AccountTypeSavings,
+            },
+            setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+            },
+            buildStubs: func(store *mockdb.MockStore) {
+                arg := db.ListAccountsParams{
+                    Owner:       user.Username,
+                    AccountType: db.AccountTypeSavings,
+                    Limit:       int32(n),
+                    Offset:      0,
+                }
+
+                store.EXPECT().
+                    ListAccounts(gomock.Any(), gomock.Eq(arg)).
+                    Times(1).
+                    Return(accounts, nil)
+            },
+            checkResponse: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+                requireBodyMatchAccounts(t, recorder.Body, accounts)
+            },
+        },
+        {
+            name: "InvalidAccountType",
+            query: Query{
+                pageID:      1,
+                pageSize:    n,
+                accountType: "brokerage",
+            },
+            setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+            },
+            buildStubs: func(store *mockdb.MockStore) {
+                store.EXPECT().
+                    ListAccounts(gomock.Any(), gomock.Any()).
+                    Times(0)
+            },
+            checkResponse: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusBadRequest, recorder.Code)
+            },
+        },
         {
             name: "InternalError",
             query: Query{
@@ -343,7 +490,7 @@ func TestListAccountsAPI(t *testing.T) {
                 pageSize: n,
             },
             setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
             },
             buildStubs: func(store *mockdb.MockStore) {
                 store.EXPECT().
@@ -362,7 +509,7 @@ func TestListAccountsAPI(t *testing.T) {
                 pageSize: n,
             },
             setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
             },
             buildStubs: func(store *mockdb.MockStore) {
                 store.EXPECT().
@@ -380,7 +527,7 @@ func TestListAccountsAPI(t *testing.T) {
                 pageSize: 1000,
             },
             setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
             },
             buildStubs: func(store *mockdb.MockStore) {
                 store.EXPECT().
@@ -414,6 +561,9 @@ func TestListAccountsAPI(t *testing.T) {
             q := request.URL.Query()
             q.Add("page_id", fmt.Sprintf("%d", tc.query.pageID))
             q.Add("page_size", fmt.Sprintf("%d", tc.query.pageSize))
+            if tc.query.accountType != "" {
+                q.Add("account_type", tc.query.accountType)
+            }
             request.URL.RawQuery = q.Encode()
 
             tc.setupAuth(t, request, server.tokenMaker)
@@ -424,12 +574,212 @@ func TestListAccountsAPI(t *testing.T) {
     }
 }
 
+// 测试变更账户状态 API
+func TestUpdateAccountStatusAPI(t *testing.T) {
+    user, _ := randomUser(t)
+    account := randomAccount(user.Username)
+    account.Status = db.AccountStatusActive
+
+    frozenAccount := account
+    frozenAccount.Status = db.AccountStatusFrozen
+
+    testCases := []struct {
+        name          string
+        body          gin.H
+        setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+        buildStubs    func(store *mockdb.MockStore)
+        checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+    }{
+        {
+            name: "AdminFreeze",
+            body: gin.H{"status": db.AccountStatusFrozen},
+            setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "admin", util.AdminRole, time.Minute)
+            },
+            buildStubs: func(store *mockdb.MockStore) {
+                store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+
+                arg := db.UpdateAccountStatusTxParams{
+                    AccountID: account.ID,
+                    Status:    db.AccountStatusFrozen,
+                }
+                store.EXPECT().
+                    UpdateAccountStatusTx(gomock.Any(), gomock.Eq(arg)).
+                    Times(1).
+                    Return(frozenAccount, nil)
+            },
+            checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+
+                var gotAccount accountResponse
+                err := json.Unmarshal(recorder.Body.Bytes(), &gotAccount)
+                require.NoError(t, err)
+                require.Equal(t, db.AccountStatusFrozen, gotAccount.Status)
+            },
+        },
+        {
+            name: "OwnerCannotFreeze",
+            body: gin.H{"status": db.AccountStatusFrozen},
+            setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+            },
+            buildStubs: func(store *mockdb.MockStore) {
+                store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+                store.EXPECT().UpdateAccountStatusTx(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusForbidden, recorder.Code)
+            },
+        },
+        {
+            name: "OwnerCannotUnfreeze",
+            body: gin.H{"status": db.AccountStatusActive},
+            setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+            },
+            buildStubs: func(store *mockdb.MockStore) {
+                store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(frozenAccount, nil)
+                store.EXPECT().UpdateAccountStatusTx(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusForbidden, recorder.Code)
+            },
+        },
+        {
+            name: "OwnerClose",
+            body: gin.H{"status": db.AccountStatusClosed},
+            setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+            },
+            buildStubs: func(store *mockdb.MockStore) {
+                store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+
+                closedAccount := account
+                closedAccount.Status = db.AccountStatusClosed
+                arg := db.UpdateAccountStatusTxParams{
+                    AccountID: account.ID,
+                    Status:    db.AccountStatusClosed,
+                }
+                store.EXPECT().
+                    UpdateAccountStatusTx(gomock.Any(), gomock.Eq(arg)).
+                    Times(1).
+                    Return(closedAccount, nil)
+            },
+            checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+            },
+        },
+        {
+            name: "OtherUserCannotClose",
+            body: gin.H{"status": db.AccountStatusClosed},
+            setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "other", util.DepositorRole, time.Minute)
+            },
+            buildStubs: func(store *mockdb.MockStore) {
+                store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+                store.EXPECT().UpdateAccountStatusTx(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusUnauthorized, recorder.Code)
+            },
+        },
+        {
+            name: "NonZeroBalance",
+            body: gin.H{"status": db.AccountStatusClosed},
+            setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+            },
+            buildStubs: func(store *mockdb.MockStore) {
+                store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+                store.EXPECT().
+                    UpdateAccountStatusTx(gomock.Any(), gomock.Any()).
+                    Times(1).
+                    Return(db.Account{}, db.ErrNonZeroBalance)
+            },
+            checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+            },
+        },
+        {
+            name: "ActiveHolds",
+            body: gin.H{"status": db.AccountStatusClosed},
+            setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+            },
+            buildStubs: func(store *mockdb.MockStore) {
+                store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+                store.EXPECT().
+                    UpdateAccountStatusTx(gomock.Any(), gomock.Any()).
+                    Times(1).
+                    Return(db.Account{}, db.ErrActiveHolds)
+            },
+            checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+            },
+        },
+        {
+            name: "InvalidStatus",
+            body: gin.H{"status": "deleted"},
+            setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "admin", util.AdminRole, time.Minute)
+            },
+            buildStubs: func(store *mockdb.MockStore) {
+                store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+                store.EXPECT().UpdateAccountStatusTx(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusBadRequest, recorder.Code)
+            },
+        },
+        {
+            name: "NotFound",
+            body: gin.H{"status": db.AccountStatusFrozen},
+            setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "admin", util.AdminRole, time.Minute)
+            },
+            buildStubs: func(store *mockdb.MockStore) {
+                store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(db.Account{}, sql.ErrNoRows)
+                store.EXPECT().UpdateAccountStatusTx(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusNotFound, recorder.Code)
+            },
+        },
+    }
+
+    for i := range testCases {
+        tc := testCases[i]
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            store := mockdb.NewMockStore(ctrl)
+            tc.buildStubs(store)
+
+            server := newTestServer(t, store)
+            recorder := httptest.NewRecorder()
+
+            data, err := json.Marshal(tc.body)
+            require.NoError(t, err)
+
+            url := fmt.Sprintf("/accounts/%d/status", account.ID)
+            request, err := http.NewRequest(http.MethodPatch, url, bytes.NewReader(data))
+            require.NoError(t, err)
+
+            tc.setupAuth(t, request, server.tokenMaker)
+            server.router.ServeHTTP(recorder, request)
+            tc.checkResponse(t, recorder)
+        })
+    }
+}
+
 func randomAccount(owner string) db.Account {
     return db.Account{
-        ID:       util.RandomInt(1, 1000),
-        Owner:    owner,
-        Balance:  util.RandomMoney(),
-        Currency: util.RandomCurrency(),
+        ID:          util.RandomInt(1, 1000),
+        Owner:       owner,
+        Balance:     util.RandomMoney(),
+        Currency:    util.RandomCurrency(),
+        AccountType: db.AccountTypeChecking,
     }
 }
 
@@ -437,18 +787,32 @@ func requireBodyMatchAccount(t *testing.T, body *bytes.Buffer, account db.Accoun
     data, err := ioutil.ReadAll(body)
     require.NoError(t, err)
 
-    var gotAccount db.Account
+    var gotAccount accountResponse
+    err = json.Unmarshal(data, &gotAccount)
+    require.NoError(t, err)
+    require.Equal(t, newAccountResponse(account), gotAccount)
+}
+
+func requireBodyMatchAvailableBalance(t *testing.T, body *bytes.Buffer, account db.Account, available int64) {
+    data, err := ioutil.ReadAll(body)
+    require.NoError(t, err)
+
+    var gotAccount accountResponse
     err = json.Unmarshal(data, &gotAccount)
     require.NoError(t, err)
-    require.Equal(t, account, gotAccount)
+
+    expected := newAccountResponse(account)
+    availableBalance := money.New(available, account.Currency)
+    expected.AvailableBalance = &availableBalance
+    require.Equal(t, expected, gotAccount)
 }
 
 func requireBodyMatchAccounts(t *testing.T, body *bytes.Buffer, accouns []db.Account) {
     data, err := ioutil.ReadAll(body)
     require.NoError(t, err)
 
-    var gotAccounts []db.Account
+    var gotAccounts []accountResponse
     err = json.Unmarshal(data, &gotAccounts)
     require.NoError(t, err)
-    require.Equal(t, gotAccounts, accouns)
+    require.Equal(t, newAccountsResponse(accouns), gotAccounts)
 }
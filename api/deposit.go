@@ -0,0 +1,93 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/xiusl/bank/db/sqlc"
+	"github.com/xiusl/bank/money"
+	"github.com/xiusl/bank/policy"
+)
+
+type cashRequest struct {
+	Amount money.Money `json:"amount" binding:"required,gt=0"`
+}
+
+type cashResponse struct {
+	Account accountResponse `json:"account"`
+	Entry   entryResponse   `json:"entry"`
+}
+
+func (server *Server) createDeposit(ctx *gin.Context) {
+	account, req, valid := server.bindCashRequest(ctx)
+	if !valid {
+		return
+	}
+
+	arg := db.DepositTxParams{
+		AccountID: account.ID,
+		Amount:    req.Amount.Amount,
+	}
+	result, err := server.store.DepositTx(ctx, arg)
+	if err != nil {
+		if errors.Is(err, db.ErrAccountNotActive) {
+			ctx.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, cashResponse{
+		Account: newAccountResponse(result.Account),
+		Entry:   newEntryResponse(result.Entry, result.Account.Currency),
+	})
+}
+
+func (server *Server) createWithdrawal(ctx *gin.Context) {
+	account, req, valid := server.bindCashRequest(ctx)
+	if !valid {
+		return
+	}
+
+	arg := db.WithdrawTxParams{
+		AccountID: account.ID,
+		Amount:    req.Amount.Amount,
+	}
+	result, err := server.store.WithdrawTx(ctx, arg)
+	if err != nil {
+		if errors.Is(err, db.ErrInsufficientFunds) || errors.Is(err, db.ErrAccountNotActive) {
+			ctx.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, cashResponse{
+		Account: newAccountResponse(result.Account),
+		Entry:   newEntryResponse(result.Entry, result.Account.Currency),
+	})
+}
+
+// bindCashRequest 解析存取款请求，检查账户归属和币种
+func (server *Server) bindCashRequest(ctx *gin.Context) (db.Account, cashRequest, bool) {
+	var uri getAccountRequest
+	var req cashRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return db.Account{}, req, false
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return db.Account{}, req, false
+	}
+
+	account, valid := server.authorizedAccount(ctx, uri.ID, policy.OperateAccount)
+	if !valid {
+		return account, req, false
+	}
+
+	return account, req, requireCurrency(ctx, account, req.Amount)
+}
@@ -0,0 +1,162 @@
+package api
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/xiusl/bank/db/sqlc"
+	"github.com/xiusl/bank/money"
+	"github.com/xiusl/bank/policy"
+)
+
+// 未指定结束时间时的查询上限
+var maxHistoryTime = time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+
+// listHistoryRequest 按 id 倒序的游标分页：首页不传 cursor，
+// 之后传上一页返回的 next_cursor
+type listHistoryRequest struct {
+	PageSize  int32     `form:"page_size" binding:"required,min=5,max=50"`
+	Cursor    int64     `form:"cursor" binding:"omitempty,min=1"`
+	From      time.Time `form:"from"`
+	To        time.Time `form:"to"`
+	Direction string    `form:"direction" binding:"omitempty,oneof=in out"`
+}
+
+// bindHistoryRequest 解析查询参数并检查账户归属
+func (server *Server) bindHistoryRequest(ctx *gin.Context) (db.Account, listHistoryRequest, bool) {
+	var uri getAccountRequest
+	var req listHistoryRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return db.Account{}, req, false
+	}
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return db.Account{}, req, false
+	}
+
+	if req.Cursor == 0 {
+		req.Cursor = math.MaxInt64
+	}
+	if req.To.IsZero() {
+		req.To = maxHistoryTime
+	}
+	if !req.From.Before(req.To) {
+		err := errors.New("from must be earlier than to")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return db.Account{}, req, false
+	}
+
+	account, valid := server.authorizedAccount(ctx, uri.ID, policy.ViewAccount)
+	return account, req, valid
+}
+
+// entryResponse 账户分录，金额按账户货币格式化，正数为入账；
+// 关联的凭证和转账为空时不返回
+type entryResponse struct {
+	ID         int64       `json:"id"`
+	AccountID  int64       `json:"account_id"`
+	Amount     money.Money `json:"amount"`
+	JournalID  int64       `json:"journal_id,omitempty"`
+	TransferID int64       `json:"transfer_id,omitempty"`
+	CreatedAt  time.Time   `json:"created_at"`
+}
+
+func newEntryResponse(entry db.Entry, currency string) entryResponse {
+	return entryResponse{
+		ID:         entry.ID,
+		AccountID:  entry.AccountID,
+		Amount:     money.New(entry.Amount, currency),
+		JournalID:  entry.JournalID.Int64,
+		TransferID: entry.TransferID.Int64,
+		CreatedAt:  entry.CreatedAt,
+	}
+}
+
+type listEntriesResponse struct {
+	Entries    []entryResponse `json:"entries"`
+	NextCursor int64           `json:"next_cursor,omitempty"`
+}
+
+func (server *Server) listEntries(ctx *gin.Context) {
+	account, req, valid := server.bindHistoryRequest(ctx)
+	if !valid {
+		return
+	}
+
+	// 多取一条用于判断是否还有下一页
+	arg := db.ListAccountEntriesParams{
+		AccountID: account.ID,
+		BeforeID:  req.Cursor,
+		FromTime:  req.From,
+		ToTime:    req.To,
+		Direction: req.Direction,
+		PageSize:  req.PageSize + 1,
+	}
+	entries, err := server.store.ListAccountEntries(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	var rsp listEntriesResponse
+	if len(entries) > int(req.PageSize) {
+		entries = entries[:req.PageSize]
+		rsp.NextCursor = entries[req.PageSize-1].ID
+	}
+	rsp.Entries = make([]entryResponse, len(entries))
+	for i, entry := range entries {
+		rsp.Entries[i] = newEntryResponse(entry, account.Currency)
+	}
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+type listTransfersResponse struct {
+	Transfers  []transferResponse `json:"transfers"`
+	NextCursor int64              `json:"next_cursor,omitempty"`
+}
+
+func (server *Server) listTransfers(ctx *gin.Context) {
+	account, req, valid := server.bindHistoryRequest(ctx)
+	if !valid {
+		return
+	}
+
+	arg := db.ListAccountTransfersParams{
+		AccountID: account.ID,
+		Direction: req.Direction,
+		BeforeID:  req.Cursor,
+		FromTime:  req.From,
+		ToTime:    req.To,
+		PageSize:  req.PageSize + 1,
+	}
+	transfers, err := server.store.ListAccountTransfers(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	var rsp listTransfersResponse
+	if len(transfers) > int(req.PageSize) {
+		transfers = transfers[:req.PageSize]
+		rsp.NextCursor = transfers[req.PageSize-1].ID
+	}
+	rsp.Transfers = make([]transferResponse, len(transfers))
+	for i, row := range transfers {
+		transfer := db.Transfer{
+			ID:            row.ID,
+			FromAccountID: row.FromAccountID,
+			ToAccountID:   row.ToAccountID,
+			Amount:        row.Amount,
+			CreatedAt:     row.CreatedAt,
+			ToAmount:      row.ToAmount,
+			ExchangeRate:  row.ExchangeRate,
+			Fee:           row.Fee,
+		}
+		rsp.Transfers[i] = newTransferResponse(transfer, row.FromCurrency, row.ToCurrency)
+	}
+	ctx.JSON(http.StatusOK, rsp)
+}
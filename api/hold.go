@@ -0,0 +1,260 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/xiusl/bank/db/sqlc"
+	"github.com/xiusl/bank/money"
+	"github.com/xiusl/bank/policy"
+	"github.com/xiusl/bank/token"
+)
+
+// holdResponse 预授权，金额按付款账户的货币格式化，扣款后 transfer_id 为对应的转账
+type holdResponse struct {
+	ID          int64       `json:"id"`
+	AccountID   int64       `json:"account_id"`
+	ToAccountID int64       `json:"to_account_id"`
+	Amount      money.Money `json:"amount"`
+	Status      string      `json:"status"`
+	ExpiresAt   time.Time   `json:"expires_at"`
+	TransferID  int64       `json:"transfer_id,omitempty"`
+	CreatedAt   time.Time   `json:"created_at"`
+}
+
+func newHoldResponse(hold db.Hold, currency string) holdResponse {
+	return holdResponse{
+		ID:          hold.ID,
+		AccountID:   hold.AccountID,
+		ToAccountID: hold.ToAccountID,
+		Amount:      money.New(hold.Amount, currency),
+		Status:      hold.Status,
+		ExpiresAt:   hold.ExpiresAt,
+		TransferID:  hold.TransferID.Int64,
+		CreatedAt:   hold.CreatedAt,
+	}
+}
+
+type placeHoldRequest struct {
+	ToAccountID int64       `json:"to_account_id" binding:"required,min=1"`
+	Amount      money.Money `json:"amount" binding:"required,gt=0"`
+	// 不填时使用配置的默认有效期
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type placeHoldResponse struct {
+	Hold             holdResponse `json:"hold"`
+	AvailableBalance money.Money  `json:"available_balance"`
+}
+
+// placeHold 冻结账户的一部分可用余额，之后由收款方扣款或释放
+func (server *Server) placeHold(ctx *gin.Context) {
+	var uri getAccountRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req placeHoldRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	now := time.Now()
+	expiresAt := req.ExpiresAt
+	if expiresAt.IsZero() {
+		expiresAt = now.Add(server.config.HoldDuration)
+	}
+	if !expiresAt.After(now) {
+		err := errors.New("expires_at must be in the future")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, valid := server.validAccount(ctx, uri.ID, req.Amount.Currency)
+	if !valid {
+		return
+	}
+
+	if !authorizeAccount(ctx, account, policy.OperateAccount) {
+		return
+	}
+
+	_, valid = server.validAccount(ctx, req.ToAccountID, req.Amount.Currency)
+	if !valid {
+		return
+	}
+
+	arg := db.PlaceHoldTxParams{
+		AccountID:   account.ID,
+		ToAccountID: req.ToAccountID,
+		Amount:      req.Amount.Amount,
+		ExpiresAt:   expiresAt,
+	}
+
+	result, err := server.store.PlaceHoldTx(ctx, arg)
+	if err != nil {
+		if errors.Is(err, db.ErrInsufficientFunds) || errors.Is(err, db.ErrAccountNotActive) {
+			ctx.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, placeHoldResponse{
+		Hold:             newHoldResponse(result.Hold, account.Currency),
+		AvailableBalance: money.New(result.AvailableBalance, account.Currency),
+	})
+}
+
+type getHoldRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// getHold 付款方和收款方都可以查看预授权
+func (server *Server) getHold(ctx *gin.Context) {
+	var uri getHoldRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	hold, valid := server.findHold(ctx, uri.ID)
+	if !valid {
+		return
+	}
+
+	account, err := server.store.GetAccount(ctx, hold.AccountID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if !policy.Allows(authPayload, account, policy.ViewAccount) {
+		_, valid = server.authorizedAccount(ctx, hold.ToAccountID, policy.ViewAccount)
+		if !valid {
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusOK, newHoldResponse(hold, account.Currency))
+}
+
+type captureHoldRequest struct {
+	// 不填时按预授权金额全额扣款
+	Amount money.Money `json:"amount" binding:"omitempty,gt=0"`
+}
+
+type captureHoldResponse struct {
+	Hold     holdResponse       `json:"hold"`
+	Transfer transferTxResponse `json:"transfer"`
+}
+
+// captureHold 收款方扣款，把预授权转为一笔真实的转账
+func (server *Server) captureHold(ctx *gin.Context) {
+	var uri getHoldRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req captureHoldRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil && err != io.EOF {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	hold, account, valid := server.receivedHold(ctx, uri.ID)
+	if !valid {
+		return
+	}
+	if req.Amount.IsPositive() && !requireCurrency(ctx, account, req.Amount) {
+		return
+	}
+
+	arg := db.CaptureHoldTxParams{
+		HoldID: hold.ID,
+		Amount: req.Amount.Amount,
+	}
+
+	result, err := server.store.CaptureHoldTx(ctx, arg)
+	if err != nil {
+		holdError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, captureHoldResponse{
+		Hold:     newHoldResponse(result.Hold, account.Currency),
+		Transfer: newTransferTxResponse(result.Transfer),
+	})
+}
+
+// releaseHold 收款方放弃扣款，冻结的金额回到付款方的可用余额
+func (server *Server) releaseHold(ctx *gin.Context) {
+	var uri getHoldRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	hold, account, valid := server.receivedHold(ctx, uri.ID)
+	if !valid {
+		return
+	}
+
+	hold, err := server.store.ReleaseHoldTx(ctx, hold.ID)
+	if err != nil {
+		holdError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newHoldResponse(hold, account.Currency))
+}
+
+// findHold 获取预授权，不存在时返回 404
+func (server *Server) findHold(ctx *gin.Context, holdID int64) (db.Hold, bool) {
+	hold, err := server.store.GetHold(ctx, holdID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return hold, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return hold, false
+	}
+	return hold, true
+}
+
+// receivedHold 获取预授权及收款账户，并检查当前登录用户是收款账户的持有人
+func (server *Server) receivedHold(ctx *gin.Context, holdID int64) (db.Hold, db.Account, bool) {
+	hold, valid := server.findHold(ctx, holdID)
+	if !valid {
+		return hold, db.Account{}, false
+	}
+
+	account, valid := server.authorizedAccount(ctx, hold.ToAccountID, policy.OperateAccount)
+	return hold, account, valid
+}
+
+// holdError 把扣款、释放预授权的错误转换为对应的状态码
+func holdError(ctx *gin.Context, err error) {
+	if err == sql.ErrNoRows {
+		ctx.JSON(http.StatusNotFound, errorResponse(err))
+		return
+	}
+	if errors.Is(err, db.ErrHoldNotActive) ||
+		errors.Is(err, db.ErrInvalidCaptureAmount) ||
+		errors.Is(err, db.ErrInsufficientFunds) ||
+		errors.Is(err, db.ErrAccountNotActive) {
+		ctx.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+}
@@ -0,0 +1,180 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/xiusl/bank/db/sqlc"
+	"github.com/xiusl/bank/token"
+)
+
+const (
+	idempotencyKeyHeader    = "Idempotency-Key"
+	maxIdempotencyKeyLength = 255
+	// maxIdempotentBodySize 携带幂等 key 的请求体需要整个读入内存计算指纹，超过时拒绝
+	maxIdempotentBodySize = 1 << 20
+	// idempotencyCleanupInterval 清理过期幂等 key 的间隔
+	idempotencyCleanupInterval = time.Hour
+)
+
+// idempotencyResponseWriter 在写出响应的同时保留一份响应体，用于请求重放
+type idempotencyResponseWriter struct {
+	gin.ResponseWriter
+	body *bytes.Buffer
+}
+
+func (w *idempotencyResponseWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *idempotencyResponseWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// requestFingerprint 计算请求的指纹，同一个 key 只能用于指纹相同的请求
+func requestFingerprint(method, path string, body []byte) string {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s %s\n", method, path)
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// idempotencyMiddleware 处理携带 Idempotency-Key 请求头的请求：
+// 首次请求正常执行并保存响应，重放时直接返回保存的响应，
+// key 被用于不同的请求时拒绝执行。需要放在 authMiddleware 之后。
+// key 保存 ttl 后过期，过期后再次使用视为新的请求；ttl 为 0 时不过期
+func idempotencyMiddleware(store db.Store, ttl time.Duration) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		key := ctx.GetHeader(idempotencyKeyHeader)
+		if len(key) == 0 {
+			ctx.Next()
+			return
+		}
+
+		if len(key) > maxIdempotencyKeyLength {
+			err := fmt.Errorf("idempotency key must be at most %d characters", maxIdempotencyKeyLength)
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+
+		body, err := ioutil.ReadAll(http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxIdempotentBodySize))
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, errorResponse(err))
+			return
+		}
+		ctx.Request.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+		authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+		method := ctx.Request.Method
+		path := ctx.Request.URL.Path
+		hash := requestFingerprint(method, path, body)
+
+		var expiredBefore time.Time
+		if ttl > 0 {
+			expiredBefore = time.Now().Add(-ttl)
+		}
+		_, err = store.CreateIdempotencyKey(ctx, db.CreateIdempotencyKeyParams{
+			Username:       authPayload.Username,
+			IdempotencyKey: key,
+			RequestMethod:  method,
+			RequestPath:    path,
+			RequestHash:    hash,
+			ExpiredBefore:  expiredBefore,
+		})
+		if err == sql.ErrNoRows {
+			// key 已存在且未过期，按照保存的记录处理
+			replayIdempotentRequest(ctx, store, authPayload.Username, key, hash)
+			return
+		}
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		writer := &idempotencyResponseWriter{
+			ResponseWriter: ctx.Writer,
+			body:           &bytes.Buffer{},
+		}
+		ctx.Writer = writer
+		ctx.Next()
+
+		// 服务端错误不保存结果，释放 key 以便客户端重试
+		status := writer.Status()
+		if status >= http.StatusInternalServerError {
+			store.DeleteIdempotencyKey(ctx, db.DeleteIdempotencyKeyParams{
+				Username:       authPayload.Username,
+				IdempotencyKey: key,
+			})
+			return
+		}
+
+		_, err = store.UpdateIdempotencyKeyResponse(ctx, db.UpdateIdempotencyKeyResponseParams{
+			ResponseCode:   int32(status),
+			ResponseBody:   writer.body.Bytes(),
+			Username:       authPayload.Username,
+			IdempotencyKey: key,
+		})
+		if err != nil {
+			store.DeleteIdempotencyKey(ctx, db.DeleteIdempotencyKeyParams{
+				Username:       authPayload.Username,
+				IdempotencyKey: key,
+			})
+		}
+	}
+}
+
+func replayIdempotentRequest(ctx *gin.Context, store db.Store, username, key, hash string) {
+	record, err := store.GetIdempotencyKey(ctx, db.GetIdempotencyKeyParams{
+		Username:       username,
+		IdempotencyKey: key,
+	})
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if record.RequestHash != hash {
+		err := errors.New("idempotency key has already been used for a different request")
+		ctx.AbortWithStatusJSON(http.StatusUnprocessableEntity, errorResponse(err))
+		return
+	}
+
+	if record.ResponseCode == 0 {
+		err := errors.New("a request with the same idempotency key is in progress")
+		ctx.AbortWithStatusJSON(http.StatusConflict, errorResponse(err))
+		return
+	}
+
+	ctx.Data(int(record.ResponseCode), gin.MIMEJSON+"; charset=utf-8", record.ResponseBody)
+	ctx.Abort()
+}
+
+// cleanIdempotencyKeys 每隔 idempotencyCleanupInterval 删除保存超过 ttl 的幂等 key，直到 ctx 结束
+func cleanIdempotencyKeys(ctx context.Context, store db.Store, ttl time.Duration) {
+	ticker := time.NewTicker(idempotencyCleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := store.DeleteExpiredIdempotencyKeys(ctx, time.Now().Add(-ttl)); err != nil {
+			log.Println("clean idempotency keys failed:", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,304 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/xiusl/bank/db/mock"
+	db "github.com/xiusl/bank/db/sqlc"
+	"github.com/xiusl/bank/util"
+)
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	user, _ := randomUser(t)
+	key := util.RandomString(16)
+	idempotentPath := "/idempotent"
+	body := []byte(`{"amount":10}`)
+	hash := requestFingerprint(http.MethodPost, idempotentPath, body)
+	savedBody := []byte(`{"id":1}`)
+
+	testCases := []struct {
+		name          string
+		key           string
+		handlerStatus int
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder, calls int)
+	}{
+		{
+			name:          "NoKey",
+			key:           "",
+			handlerStatus: http.StatusOK,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateIdempotencyKey(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().UpdateIdempotencyKeyResponse(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, calls int) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, 1, calls)
+			},
+		},
+		{
+			name:          "FirstRequest",
+			key:           key,
+			handlerStatus: http.StatusOK,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateIdempotencyKeyParams{
+					Username:       user.Username,
+					IdempotencyKey: key,
+					RequestMethod:  http.MethodPost,
+					RequestPath:    idempotentPath,
+					RequestHash:    hash,
+				}
+				store.EXPECT().
+					CreateIdempotencyKey(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.IdempotencyKey{}, nil)
+
+				update := db.UpdateIdempotencyKeyResponseParams{
+					ResponseCode:   http.StatusOK,
+					ResponseBody:   savedBody,
+					Username:       user.Username,
+					IdempotencyKey: key,
+				}
+				store.EXPECT().
+					UpdateIdempotencyKeyResponse(gomock.Any(), gomock.Eq(update)).
+					Times(1).
+					Return(db.IdempotencyKey{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, calls int) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, 1, calls)
+			},
+		},
+		{
+			name:          "Replay",
+			key:           key,
+			handlerStatus: http.StatusOK,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateIdempotencyKey(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.IdempotencyKey{}, sql.ErrNoRows)
+				store.EXPECT().
+					GetIdempotencyKey(gomock.Any(), gomock.Eq(db.GetIdempotencyKeyParams{
+						Username:       user.Username,
+						IdempotencyKey: key,
+					})).
+					Times(1).
+					Return(db.IdempotencyKey{
+						Username:       user.Username,
+						IdempotencyKey: key,
+						RequestHash:    hash,
+						ResponseCode:   http.StatusOK,
+						ResponseBody:   savedBody,
+					}, nil)
+				store.EXPECT().UpdateIdempotencyKeyResponse(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, calls int) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, savedBody, recorder.Body.Bytes())
+				require.Equal(t, 0, calls)
+			},
+		},
+		{
+			name:          "KeyReusedWithDifferentRequest",
+			key:           key,
+			handlerStatus: http.StatusOK,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateIdempotencyKey(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.IdempotencyKey{}, sql.ErrNoRows)
+				store.EXPECT().
+					GetIdempotencyKey(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.IdempotencyKey{
+						RequestHash:  "other",
+						ResponseCode: http.StatusOK,
+						ResponseBody: savedBody,
+					}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, calls int) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+				require.Equal(t, 0, calls)
+			},
+		},
+		{
+			name:          "InProgress",
+			key:           key,
+			handlerStatus: http.StatusOK,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateIdempotencyKey(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.IdempotencyKey{}, sql.ErrNoRows)
+				store.EXPECT().
+					GetIdempotencyKey(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.IdempotencyKey{RequestHash: hash}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, calls int) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+				require.Equal(t, 0, calls)
+			},
+		},
+		{
+			name:          "HandlerInternalError",
+			key:           key,
+			handlerStatus: http.StatusInternalServerError,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateIdempotencyKey(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.IdempotencyKey{}, nil)
+				store.EXPECT().UpdateIdempotencyKeyResponse(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().
+					DeleteIdempotencyKey(gomock.Any(), gomock.Eq(db.DeleteIdempotencyKeyParams{
+						Username:       user.Username,
+						IdempotencyKey: key,
+					})).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, calls int) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+				require.Equal(t, 1, calls)
+			},
+		},
+		{
+			name:          "CreateKeyError",
+			key:           key,
+			handlerStatus: http.StatusOK,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateIdempotencyKey(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.IdempotencyKey{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, calls int) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+				require.Equal(t, 0, calls)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+
+			calls := 0
+			server.router.POST(
+				idempotentPath,
+				authMiddleware(server.tokenMaker, server.revocations),
+				idempotencyMiddleware(server.store, server.config.IdempotencyKeyTTL),
+				func(ctx *gin.Context) {
+					calls++
+					var req map[string]interface{}
+					err := json.NewDecoder(ctx.Request.Body).Decode(&req)
+					require.NoError(t, err)
+					ctx.Data(tc.handlerStatus, gin.MIMEJSON, savedBody)
+				},
+			)
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodPost, idempotentPath, bytes.NewReader(body))
+			require.NoError(t, err)
+			if len(tc.key) > 0 {
+				request.Header.Set(idempotencyKeyHeader, tc.key)
+			}
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder, calls)
+		})
+	}
+}
+
+func TestIdempotencyMiddlewareExpiry(t *testing.T) {
+	user, _ := randomUser(t)
+	ttl := 24 * time.Hour
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// 早于 now-ttl 创建的同名 key 已过期，由数据库覆盖为新的请求
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		CreateIdempotencyKey(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(ctx context.Context, arg db.CreateIdempotencyKeyParams) (db.IdempotencyKey, error) {
+			require.WithinDuration(t, time.Now().Add(-ttl), arg.ExpiredBefore, time.Second)
+			return db.IdempotencyKey{}, nil
+		})
+	store.EXPECT().
+		UpdateIdempotencyKeyResponse(gomock.Any(), gomock.Any()).
+		Times(1)
+
+	server := newTestServer(t, store)
+	server.router.POST(
+		"/idempotent",
+		authMiddleware(server.tokenMaker, server.revocations),
+		idempotencyMiddleware(server.store, ttl),
+		func(ctx *gin.Context) {
+			ctx.JSON(http.StatusOK, gin.H{})
+		},
+	)
+
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodPost, "/idempotent", bytes.NewReader([]byte(`{}`)))
+	require.NoError(t, err)
+	request.Header.Set(idempotencyKeyHeader, util.RandomString(16))
+
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func TestIdempotencyMiddlewareBodyTooLarge(t *testing.T) {
+	user, _ := randomUser(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().CreateIdempotencyKey(gomock.Any(), gomock.Any()).Times(0)
+
+	server := newTestServer(t, store)
+	calls := 0
+	server.router.POST(
+		"/idempotent",
+		authMiddleware(server.tokenMaker, server.revocations),
+		idempotencyMiddleware(server.store, 0),
+		func(ctx *gin.Context) {
+			calls++
+		},
+	)
+
+	recorder := httptest.NewRecorder()
+	body := bytes.Repeat([]byte("a"), maxIdempotentBodySize+1)
+	request, err := http.NewRequest(http.MethodPost, "/idempotent", bytes.NewReader(body))
+	require.NoError(t, err)
+	request.Header.Set(idempotencyKeyHeader, util.RandomString(16))
+
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code)
+	require.Zero(t, calls)
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xiusl/bank/logger"
+	"github.com/xiusl/bank/token"
+)
+
+// 记录到日志的错误响应体的最大长度
+const maxLoggedErrorBody = 4096
+
+// errorLogResponseWriter 状态码为 5xx 时保留一份响应体，用于在日志中记录错误信息
+type errorLogResponseWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *errorLogResponseWriter) Write(data []byte) (int, error) {
+	w.capture(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *errorLogResponseWriter) WriteString(s string) (int, error) {
+	w.capture([]byte(s))
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *errorLogResponseWriter) capture(data []byte) {
+	if w.Status() >= http.StatusInternalServerError && w.body.Len() < maxLoggedErrorBody {
+		w.body.Write(data)
+	}
+}
+
+// errorMessage 取出 errorResponse 格式的响应体中的错误信息
+func (w *errorLogResponseWriter) errorMessage() string {
+	var rsp struct {
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(w.body.Bytes(), &rsp); err != nil {
+		return ""
+	}
+	return rsp.Error
+}
+
+// requestLogger 为每个请求分配请求 ID：沿用合法的 X-Request-ID 请求头，否则生成新的。
+// 请求 ID 写入响应头和请求的 context，随 ctx 传给 Store；
+// 请求结束后输出一行 JSON 日志，5xx 响应以 error 级别记录并附上错误信息
+func requestLogger() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+
+		requestID := ctx.GetHeader(logger.RequestIDHeader)
+		if !logger.ValidRequestID(requestID) {
+			requestID = logger.NewRequestID()
+		}
+		ctx.Set(logger.RequestIDKey, requestID)
+		ctx.Request = ctx.Request.WithContext(logger.WithRequestID(ctx.Request.Context(), requestID))
+		ctx.Header(logger.RequestIDHeader, requestID)
+
+		writer := &errorLogResponseWriter{ResponseWriter: ctx.Writer}
+		ctx.Writer = writer
+
+		ctx.Next()
+
+		status := writer.Status()
+		fields := logger.Fields{
+			"method":     ctx.Request.Method,
+			"path":       ctx.Request.URL.Path,
+			"status":     status,
+			"latency_ms": float64(time.Since(start).Microseconds()) / 1000,
+			"client_ip":  ctx.ClientIP(),
+		}
+		if payload, ok := ctx.Get(authorizationPayloadKey); ok {
+			fields["username"] = payload.(*token.Payload).Username
+		}
+
+		if status >= http.StatusInternalServerError {
+			if message := writer.errorMessage(); len(message) > 0 {
+				fields["error"] = message
+			}
+			logger.Error(ctx, "request failed", fields)
+			return
+		}
+		logger.Info(ctx, "request", fields)
+	}
+}
@@ -0,0 +1,149 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/xiusl/bank/logger"
+	"github.com/xiusl/bank/token"
+	"github.com/xiusl/bank/util"
+)
+
+func TestRequestLogger(t *testing.T) {
+	username := util.RandomOwner()
+
+	testCases := []struct {
+		name          string
+		requestID     string
+		handler       gin.HandlerFunc
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder, handlerRequestID string, entry map[string]interface{})
+	}{
+		{
+			name:      "OK",
+			requestID: "client-request-1",
+			handler: func(ctx *gin.Context) {
+				payload, err := token.NewPayload(username, util.DepositorRole, token.AccessToken, time.Minute)
+				require.NoError(t, err)
+				ctx.Set(authorizationPayloadKey, payload)
+				ctx.JSON(http.StatusOK, gin.H{})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, handlerRequestID string, entry map[string]interface{}) {
+				require.Equal(t, "client-request-1", recorder.Header().Get(logger.RequestIDHeader))
+				require.Equal(t, "client-request-1", handlerRequestID)
+
+				require.Equal(t, logger.LevelInfo, entry["level"])
+				require.Equal(t, "client-request-1", entry["request_id"])
+				require.Equal(t, http.MethodGet, entry["method"])
+				require.Equal(t, "/test", entry["path"])
+				require.Equal(t, float64(http.StatusOK), entry["status"])
+				require.Equal(t, username, entry["username"])
+				require.Contains(t, entry, "latency_ms")
+				require.NotContains(t, entry, "error")
+			},
+		},
+		{
+			name: "GenerateRequestID",
+			handler: func(ctx *gin.Context) {
+				ctx.JSON(http.StatusOK, gin.H{})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, handlerRequestID string, entry map[string]interface{}) {
+				requestID := recorder.Header().Get(logger.RequestIDHeader)
+				require.True(t, logger.ValidRequestID(requestID))
+				require.Equal(t, requestID, handlerRequestID)
+				require.Equal(t, requestID, entry["request_id"])
+				require.NotContains(t, entry, "username")
+			},
+		},
+		{
+			name:      "InvalidRequestID",
+			requestID: "*/ select 1; /*",
+			handler: func(ctx *gin.Context) {
+				ctx.JSON(http.StatusOK, gin.H{})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, handlerRequestID string, entry map[string]interface{}) {
+				requestID := recorder.Header().Get(logger.RequestIDHeader)
+				require.NotEqual(t, "*/ select 1; /*", requestID)
+				require.True(t, logger.ValidRequestID(requestID))
+				require.Equal(t, requestID, handlerRequestID)
+			},
+		},
+		{
+			name:      "InternalError",
+			requestID: "client-request-2",
+			handler: func(ctx *gin.Context) {
+				ctx.JSON(http.StatusInternalServerError, errorResponse(errors.New("connection refused")))
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, handlerRequestID string, entry map[string]interface{}) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+				require.Equal(t, logger.LevelError, entry["level"])
+				require.Equal(t, "client-request-2", entry["request_id"])
+				require.Equal(t, "connection refused", entry["error"])
+			},
+		},
+		{
+			name: "ClientError",
+			handler: func(ctx *gin.Context) {
+				ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("invalid request")))
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, handlerRequestID string, entry map[string]interface{}) {
+				require.Equal(t, logger.LevelInfo, entry["level"])
+				require.Equal(t, float64(http.StatusBadRequest), entry["status"])
+				require.NotContains(t, entry, "error")
+			},
+		},
+		{
+			name: "Panic",
+			handler: func(ctx *gin.Context) {
+				panic("unexpected")
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, handlerRequestID string, entry map[string]interface{}) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+				require.Equal(t, logger.LevelError, entry["level"])
+				require.Equal(t, float64(http.StatusInternalServerError), entry["status"])
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger.SetOutput(&buf)
+			defer logger.SetOutput(ioutil.Discard)
+
+			var handlerRequestID string
+			router := gin.New()
+			router.Use(requestLogger(), gin.RecoveryWithWriter(ioutil.Discard))
+			router.GET("/test", func(ctx *gin.Context) {
+				// 处理函数把 ctx 传给 Store 时能取到请求 ID
+				handlerRequestID = logger.RequestID(ctx)
+				tc.handler(ctx)
+			})
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, "/test", nil)
+			require.NoError(t, err)
+			if len(tc.requestID) > 0 {
+				request.Header.Set(logger.RequestIDHeader, tc.requestID)
+			}
+			router.ServeHTTP(recorder, request)
+
+			lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+			require.Len(t, lines, 1)
+			var entry map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(lines[0]), &entry))
+
+			tc.checkResponse(t, recorder, handlerRequestID, entry)
+		})
+	}
+}
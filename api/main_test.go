@@ -1,28 +1,48 @@
 package api
 
 import (
+	"io/ioutil"
 	"os"
 	"testing"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/require"
+	"github.com/xiusl/bank/currency"
 	db "github.com/xiusl/bank/db/sqlc"
+	"github.com/xiusl/bank/fx"
+	"github.com/xiusl/bank/logger"
+	"github.com/xiusl/bank/token"
 	"github.com/xiusl/bank/util"
 )
 
+// disabledCurrency 测试用的已停用货币
+const disabledCurrency = "GBP"
+
 func newTestServer(t *testing.T, store db.Store) *Server {
 	config := util.Config{
-		TokenSymmertricKey:  util.RandomString(32),
-		AccessTokenDuration: time.Minute,
+		TokenSymmertricKey:   util.RandomString(32),
+		AccessTokenDuration:  time.Minute,
+		RefreshTokenDuration: time.Hour,
 	}
 
-	server, err := NewServer(config, store)
+	rates, err := fx.NewStaticRateProvider(nil)
+	require.NoError(t, err)
+
+	// 测试中不访问货币表，直接启用 USD 和 EUR，停用 GBP
+	currencies := currency.NewRegistry(store, 0)
+	currencies.Set(db.Currency{Code: util.USD, Exponent: 2, Enabled: true})
+	currencies.Set(db.Currency{Code: util.EUR, Exponent: 2, Enabled: true})
+	currencies.Set(db.Currency{Code: disabledCurrency, Exponent: 2, Enabled: false})
+
+	server, err := NewServer(config, store, token.NewMemoryRevocationStore(), rates, currencies)
 	require.NoError(t, err)
 	return server
 }
+
 func TestMain(m *testing.M) {
 	gin.SetMode(gin.TestMode)
+	logger.SetOutput(ioutil.Discard)
 
 	os.Exit(m.Run())
 }
@@ -3,10 +3,13 @@ package api
 import (
     "errors"
     "fmt"
+    "net"
     "net/http"
     "strings"
+    "time"
 
     "github.com/gin-gonic/gin"
+    "github.com/xiusl/bank/policy"
     "github.com/xiusl/bank/token"
 )
 
@@ -16,7 +19,7 @@ const (
     authorizationPayloadKey = "authorization_payload"
 )
 
-func authMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
+func authMiddleware(tokenMaker token.Maker, revocations token.RevocationStore) gin.HandlerFunc {
     return func(ctx *gin.Context) {
         authorizationHeader := ctx.GetHeader(authorizationHeaderKey)
         if len(authorizationHeader) == 0 {
@@ -40,14 +43,59 @@ func authMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
         }
 
         accessToken := fileds[1]
-        payload, err := tokenMaker.VerifyToken(accessToken)
+        payload, err := tokenMaker.VerifyToken(accessToken, token.AccessToken)
         if err != nil {
             err := errors.New("token error")
             ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
             return
         }
 
+        revoked, err := revocations.IsRevoked(ctx, payload)
+        if err != nil {
+            ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+            return
+        }
+        if revoked {
+            err := errors.New("token has been revoked")
+            ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+            return
+        }
+
         ctx.Set(authorizationPayloadKey, payload)
         ctx.Next()
     }
 }
+
+// roleMiddleware 只允许拥有指定角色的用户访问，需放在 authMiddleware 之后
+func roleMiddleware(roles ...string) gin.HandlerFunc {
+    return func(ctx *gin.Context) {
+        authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+        if !policy.HasRole(authPayload, roles...) {
+            err := fmt.Errorf("role %s is not permitted", authPayload.Role)
+            ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(err))
+            return
+        }
+        ctx.Next()
+    }
+}
+
+// connContextKey 请求 context 中保存底层连接的 key，由 http.Server.ConnContext 写入
+type connContextKey struct{}
+
+// streamWriteTimeout 为流式输出的路由单独设置写超时，替换 HTTP_WRITE_TIMEOUT 设置的连接写截止时间，
+// timeout 为 0 时不限制。请求结束后 http.Server 会在处理下一个请求前重新设置截止时间
+func streamWriteTimeout(timeout time.Duration) gin.HandlerFunc {
+    return func(ctx *gin.Context) {
+        if conn, ok := ctx.Request.Context().Value(connContextKey{}).(net.Conn); ok {
+            var deadline time.Time
+            if timeout > 0 {
+                deadline = time.Now().Add(timeout)
+            }
+            if err := conn.SetWriteDeadline(deadline); err != nil {
+                ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+                return
+            }
+        }
+        ctx.Next()
+    }
+}
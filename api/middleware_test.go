@@ -1,6 +1,7 @@
 package api
 
 import (
+    "context"
     "fmt"
     "net/http"
     "net/http/httptest"
@@ -10,6 +11,7 @@ import (
     "github.com/gin-gonic/gin"
     "github.com/stretchr/testify/require"
     "github.com/xiusl/bank/token"
+    "github.com/xiusl/bank/util"
 )
 
 func addAuthorization(
@@ -18,10 +20,12 @@ func addAuthorization(
     tokenMaker token.Maker,
     authorizationType string,
     username string,
+    role string,
     duration time.Duration,
 ) {
-    token, err := tokenMaker.CreateToken(username, duration)
+    token, payload, err := tokenMaker.CreateToken(username, role, token.AccessToken, duration)
     require.NoError(t, err)
+    require.NotEmpty(t, payload)
 
     authorizationHeader := fmt.Sprintf("%s %s", authorizationType, token)
     request.Header.Set(authorizationHeaderKey, authorizationHeader)
@@ -36,7 +40,7 @@ func TestAuthMiddleware(t *testing.T) {
         {
             name: "OK",
             setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "user", time.Minute)
+                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "user", util.DepositorRole, time.Minute)
             },
             checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
                 require.Equal(t, http.StatusOK, recorder.Code)
@@ -53,7 +57,7 @@ func TestAuthMiddleware(t *testing.T) {
         {
             name: "UnsupportedAuthorization",
             setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-                addAuthorization(t, request, tokenMaker, "unsupport", "user", time.Minute)
+                addAuthorization(t, request, tokenMaker, "unsupport", "user", util.DepositorRole, time.Minute)
             },
             checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
                 require.Equal(t, http.StatusUnauthorized, recorder.Code)
@@ -62,7 +66,7 @@ func TestAuthMiddleware(t *testing.T) {
         {
             name: "InvalidAuthorizationFormat",
             setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-                addAuthorization(t, request, tokenMaker, "", "user", time.Minute)
+                addAuthorization(t, request, tokenMaker, "", "user", util.DepositorRole, time.Minute)
             },
             checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
                 require.Equal(t, http.StatusUnauthorized, recorder.Code)
@@ -71,7 +75,18 @@ func TestAuthMiddleware(t *testing.T) {
         {
             name: "ExpiredToken",
             setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "user", -time.Minute)
+                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "user", util.DepositorRole, -time.Minute)
+            },
+            checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusUnauthorized, recorder.Code)
+            },
+        },
+        {
+            name: "RefreshToken",
+            setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+                refreshToken, _, err := tokenMaker.CreateToken("user", util.DepositorRole, token.RefreshToken, time.Hour)
+                require.NoError(t, err)
+                request.Header.Set(authorizationHeaderKey, fmt.Sprintf("%s %s", authorizationTypeBearer, refreshToken))
             },
             checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
                 require.Equal(t, http.StatusUnauthorized, recorder.Code)
@@ -88,7 +103,7 @@ func TestAuthMiddleware(t *testing.T) {
             authPath := "/auth"
             server.router.GET(
                 authPath,
-                authMiddleware(server.tokenMaker),
+                authMiddleware(server.tokenMaker, server.revocations),
                 func(c *gin.Context) {
                     c.JSON(http.StatusOK, gin.H{})
                 },
@@ -104,3 +119,79 @@ func TestAuthMiddleware(t *testing.T) {
         })
     }
 }
+
+func TestAuthMiddlewareRevokedToken(t *testing.T) {
+    server := newTestServer(t, nil)
+
+    authPath := "/auth"
+    server.router.GET(
+        authPath,
+        authMiddleware(server.tokenMaker, server.revocations),
+        func(c *gin.Context) {
+            c.JSON(http.StatusOK, gin.H{})
+        },
+    )
+
+    accessToken, payload, err := server.tokenMaker.CreateToken("user", util.DepositorRole, token.AccessToken, time.Minute)
+    require.NoError(t, err)
+
+    err = server.revocations.Revoke(context.Background(), payload.ID, payload.Username, payload.ExpiredAt)
+    require.NoError(t, err)
+
+    recorder := httptest.NewRecorder()
+    request, err := http.NewRequest(http.MethodGet, authPath, nil)
+    require.NoError(t, err)
+
+    request.Header.Set(authorizationHeaderKey, fmt.Sprintf("%s %s", authorizationTypeBearer, accessToken))
+    server.router.ServeHTTP(recorder, request)
+    require.Equal(t, http.StatusUnauthorized, recorder.Code)
+}
+
+func TestRoleMiddleware(t *testing.T) {
+    testCases := []struct {
+        name          string
+        role          string
+        checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+    }{
+        {
+            name: "OK",
+            role: util.AdminRole,
+            checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+            },
+        },
+        {
+            name: "Depositor",
+            role: util.DepositorRole,
+            checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusForbidden, recorder.Code)
+            },
+        },
+    }
+
+    for i := range testCases {
+        tc := testCases[i]
+
+        t.Run(tc.name, func(t *testing.T) {
+            server := newTestServer(t, nil)
+
+            adminPath := "/admin-only"
+            server.router.GET(
+                adminPath,
+                authMiddleware(server.tokenMaker, server.revocations),
+                roleMiddleware(util.AdminRole),
+                func(c *gin.Context) {
+                    c.JSON(http.StatusOK, gin.H{})
+                },
+            )
+
+            recorder := httptest.NewRecorder()
+            request, err := http.NewRequest(http.MethodGet, adminPath, nil)
+            require.NoError(t, err)
+
+            addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, "user", tc.role, time.Minute)
+            server.router.ServeHTTP(recorder, request)
+            tc.checkResponse(t, recorder)
+        })
+    }
+}
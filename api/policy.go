@@ -0,0 +1,28 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/xiusl/bank/db/sqlc"
+	"github.com/xiusl/bank/policy"
+	"github.com/xiusl/bank/token"
+)
+
+// authorizeAccount 检查当前登录用户能否对账户执行 action，无权限时中止请求
+func authorizeAccount(ctx *gin.Context, account db.Account, action policy.Action) bool {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if policy.Allows(authPayload, account, action) {
+		return true
+	}
+
+	if policy.OwnerAllowed(action) {
+		err := errors.New("account does't belong to the authorizated user")
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+		return false
+	}
+	err := errors.New("permission denied")
+	ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(err))
+	return false
+}
@@ -0,0 +1,32 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type getReconciliationRequest struct {
+	Refresh bool `form:"refresh"`
+}
+
+// getReconciliation 返回最近一次账本核对报告，refresh 为 true 或还没有核对过时立即核对
+func (server *Server) getReconciliation(ctx *gin.Context) {
+	var req getReconciliationRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	report, ok := server.reconciler.Last()
+	if req.Refresh || !ok {
+		var err error
+		report, err = server.reconciler.RunOnce(ctx)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
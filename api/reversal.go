@@ -0,0 +1,124 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/xiusl/bank/db/sqlc"
+	"github.com/xiusl/bank/money"
+	"github.com/xiusl/bank/token"
+)
+
+type reverseTransferUri struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type reverseTransferRequest struct {
+	// 以原转账的转出货币计，不填时冲正全部剩余金额
+	Amount money.Money `json:"amount" binding:"omitempty,gt=0"`
+	Reason string      `json:"reason" binding:"max=255"`
+}
+
+// reversalResponse 冲正结果：amount 以原转账的转出货币计，
+// transfer 为从原收款账户转回原付款账户的补偿转账
+type reversalResponse struct {
+	ID          int64            `json:"id"`
+	Amount      money.Money      `json:"amount"`
+	Reason      string           `json:"reason"`
+	InitiatedBy string           `json:"initiated_by"`
+	CreatedAt   time.Time        `json:"created_at"`
+	Original    transferResponse `json:"original"`
+	Transfer    transferResponse `json:"transfer"`
+	FromAccount accountResponse  `json:"from_account"`
+	ToAccount   accountResponse  `json:"to_account"`
+}
+
+func newReversalResponse(result db.ReverseTransferTxResult) reversalResponse {
+	// 补偿转账的方向与原转账相反
+	originalFrom, originalTo := result.ToAccount.Currency, result.FromAccount.Currency
+	return reversalResponse{
+		ID:          result.Reversal.ID,
+		Amount:      money.New(result.Reversal.Amount, originalFrom),
+		Reason:      result.Reversal.Reason,
+		InitiatedBy: result.Reversal.InitiatedBy,
+		CreatedAt:   result.Reversal.CreatedAt,
+		Original:    newTransferResponse(result.Original, originalFrom, originalTo),
+		Transfer:    newTransferResponse(result.Transfer, originalTo, originalFrom),
+		FromAccount: newAccountResponse(result.FromAccount),
+		ToAccount:   newAccountResponse(result.ToAccount),
+	}
+}
+
+// reverseTransfer 冲正一笔转账，由柜员或管理员发起，记录发起人
+func (server *Server) reverseTransfer(ctx *gin.Context) {
+	var uri reverseTransferUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req reverseTransferRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil && err != io.EOF {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if req.Amount.IsPositive() {
+		fromAccount, valid := server.transferSourceAccount(ctx, uri.ID)
+		if !valid || !requireCurrency(ctx, fromAccount, req.Amount) {
+			return
+		}
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.ReverseTransferTxParams{
+		TransferID:  uri.ID,
+		Amount:      req.Amount.Amount,
+		Reason:      req.Reason,
+		InitiatedBy: authPayload.Username,
+	}
+
+	result, err := server.store.ReverseTransferTx(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrTransferFullyReversed) ||
+			errors.Is(err, db.ErrInvalidReversalAmount) ||
+			errors.Is(err, db.ErrReverseReversal) ||
+			errors.Is(err, db.ErrInsufficientFunds) ||
+			errors.Is(err, db.ErrAccountNotActive) {
+			ctx.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newReversalResponse(result))
+}
+
+// transferSourceAccount 获取转账的转出账户，用来确定转账金额的货币
+func (server *Server) transferSourceAccount(ctx *gin.Context, transferID int64) (db.Account, bool) {
+	transfer, err := server.store.GetTransfer(ctx, transferID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return db.Account{}, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return db.Account{}, false
+	}
+
+	account, err := server.store.GetAccount(ctx, transfer.FromAccountID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return account, false
+	}
+	return account, true
+}
@@ -0,0 +1,331 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/xiusl/bank/db/sqlc"
+	"github.com/xiusl/bank/money"
+	"github.com/xiusl/bank/policy"
+	"github.com/xiusl/bank/token"
+)
+
+// scheduledTransferResponse 定时转账，金额按转出账户的货币格式化
+type scheduledTransferResponse struct {
+	ID            int64       `json:"id"`
+	Owner         string      `json:"owner"`
+	FromAccountID int64       `json:"from_account_id"`
+	ToAccountID   int64       `json:"to_account_id"`
+	Amount        money.Money `json:"amount"`
+	Frequency     string      `json:"frequency"`
+	Status        string      `json:"status"`
+	StartAt       time.Time   `json:"start_at"`
+	NextRunAt     time.Time   `json:"next_run_at"`
+	Attempts      int32       `json:"attempts"`
+	CreatedAt     time.Time   `json:"created_at"`
+}
+
+func newScheduledTransferResponse(scheduled db.ScheduledTransfer) scheduledTransferResponse {
+	return scheduledTransferResponse{
+		ID:            scheduled.ID,
+		Owner:         scheduled.Owner,
+		FromAccountID: scheduled.FromAccountID,
+		ToAccountID:   scheduled.ToAccountID,
+		Amount:        money.New(scheduled.Amount, scheduled.Currency),
+		Frequency:     scheduled.Frequency,
+		Status:        scheduled.Status,
+		StartAt:       scheduled.StartAt,
+		NextRunAt:     scheduled.NextRunAt,
+		Attempts:      scheduled.Attempts,
+		CreatedAt:     scheduled.CreatedAt,
+	}
+}
+
+// scheduledTransferRunResponse 定时转账的执行记录，金额按定时转账的货币格式化
+type scheduledTransferRunResponse struct {
+	ID                  int64       `json:"id"`
+	ScheduledTransferID int64       `json:"scheduled_transfer_id"`
+	TransferID          int64       `json:"transfer_id,omitempty"`
+	Amount              money.Money `json:"amount"`
+	Status              string      `json:"status"`
+	Error               string      `json:"error"`
+	CreatedAt           time.Time   `json:"created_at"`
+}
+
+func newScheduledTransferRunResponse(run db.ScheduledTransferRun, currency string) scheduledTransferRunResponse {
+	return scheduledTransferRunResponse{
+		ID:                  run.ID,
+		ScheduledTransferID: run.ScheduledTransferID,
+		TransferID:          run.TransferID.Int64,
+		Amount:              money.New(run.Amount, currency),
+		Status:              run.Status,
+		Error:               run.Error,
+		CreatedAt:           run.CreatedAt,
+	}
+}
+
+type createScheduledTransferRequest struct {
+	FromAccountID int64       `json:"from_account_id" binding:"required,min=1"`
+	ToAccountID   int64       `json:"to_account_id" binding:"required,min=1"`
+	Amount        money.Money `json:"amount" binding:"required,gt=0"`
+	Frequency     string      `json:"frequency" binding:"required,oneof=once daily weekly monthly"`
+	// 第一期的执行时间
+	StartAt time.Time `json:"start_at" binding:"required"`
+}
+
+// createScheduledTransfer 创建定时转账，只支持同币种转账
+func (server *Server) createScheduledTransfer(ctx *gin.Context) {
+	var req createScheduledTransferRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	fromAccount, valid := server.validAccount(ctx, req.FromAccountID, req.Amount.Currency)
+	if !valid {
+		return
+	}
+
+	if !authorizeAccount(ctx, fromAccount, policy.OperateAccount) {
+		return
+	}
+
+	_, valid = server.validAccount(ctx, req.ToAccountID, req.Amount.Currency)
+	if !valid {
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.CreateScheduledTransferParams{
+		Owner:         authPayload.Username,
+		FromAccountID: req.FromAccountID,
+		ToAccountID:   req.ToAccountID,
+		Amount:        req.Amount.Amount,
+		Frequency:     req.Frequency,
+		NextRunAt:     req.StartAt,
+		Currency:      req.Amount.Currency,
+	}
+
+	scheduled, err := server.store.CreateScheduledTransfer(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newScheduledTransferResponse(scheduled))
+}
+
+type getScheduledTransferRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getScheduledTransfer(ctx *gin.Context) {
+	var uri getScheduledTransferRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	scheduled, valid := server.ownedScheduledTransfer(ctx, uri.ID)
+	if !valid {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newScheduledTransferResponse(scheduled))
+}
+
+type listScheduledTransfersRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+func (server *Server) listScheduledTransfers(ctx *gin.Context) {
+	var req listScheduledTransfersRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.ListScheduledTransfersParams{
+		Owner:  authPayload.Username,
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	}
+
+	scheduled, err := server.store.ListScheduledTransfers(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := make([]scheduledTransferResponse, len(scheduled))
+	for i, transfer := range scheduled {
+		rsp[i] = newScheduledTransferResponse(transfer)
+	}
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+type updateScheduledTransferRequest struct {
+	Amount    money.Money `json:"amount" binding:"omitempty,gt=0"`
+	Frequency string      `json:"frequency" binding:"omitempty,oneof=once daily weekly monthly"`
+	Status    string      `json:"status" binding:"omitempty,oneof=active paused"`
+	NextRunAt *time.Time  `json:"next_run_at"`
+}
+
+// updateScheduledTransfer 修改定时转账，未提供的字段保持不变；已完成或已取消的不能修改
+func (server *Server) updateScheduledTransfer(ctx *gin.Context) {
+	var uri getScheduledTransferRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req updateScheduledTransferRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	scheduled, valid := server.ownedScheduledTransfer(ctx, uri.ID)
+	if !valid {
+		return
+	}
+
+	if scheduled.Status != db.ScheduledTransferActive && scheduled.Status != db.ScheduledTransferPaused {
+		err := fmt.Errorf("scheduled transfer is %s", scheduled.Status)
+		ctx.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+		return
+	}
+
+	// 只更新请求中提供的字段，避免覆盖调度器同时写入的租约和下一次执行时间
+	arg := db.UpdateScheduledTransferParams{
+		ID: scheduled.ID,
+	}
+	if req.Amount.IsPositive() {
+		fromAccount, valid := server.authorizedAccount(ctx, scheduled.FromAccountID, policy.OperateAccount)
+		if !valid || !requireCurrency(ctx, fromAccount, req.Amount) {
+			return
+		}
+		arg.Amount = sql.NullInt64{Int64: req.Amount.Amount, Valid: true}
+	}
+	if len(req.Frequency) > 0 {
+		arg.Frequency = sql.NullString{String: req.Frequency, Valid: true}
+	}
+	if len(req.Status) > 0 {
+		arg.Status = sql.NullString{String: req.Status, Valid: true}
+	}
+	if req.NextRunAt != nil {
+		arg.NextRunAt = sql.NullTime{Time: *req.NextRunAt, Valid: true}
+	}
+
+	scheduled, err := server.store.UpdateScheduledTransfer(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			// 读取之后被执行完成或被取消
+			err := errors.New("scheduled transfer is no longer active or paused")
+			ctx.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newScheduledTransferResponse(scheduled))
+}
+
+// deleteScheduledTransfer 取消定时转账，保留执行记录；已完成或已取消的不能再取消
+func (server *Server) deleteScheduledTransfer(ctx *gin.Context) {
+	var uri getScheduledTransferRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	scheduled, valid := server.ownedScheduledTransfer(ctx, uri.ID)
+	if !valid {
+		return
+	}
+
+	// 状态检查放在 UPDATE 的条件里，已完成或已取消的不会被改成取消
+	_, err := server.store.UpdateScheduledTransfer(ctx, db.UpdateScheduledTransferParams{
+		ID:     scheduled.ID,
+		Status: sql.NullString{String: db.ScheduledTransferCancelled, Valid: true},
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err := errors.New("scheduled transfer is no longer active or paused")
+			ctx.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+type listScheduledTransferRunsRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+// listScheduledTransferRuns 返回定时转账的执行记录，最近的在前
+func (server *Server) listScheduledTransferRuns(ctx *gin.Context) {
+	var uri getScheduledTransferRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req listScheduledTransferRunsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	scheduled, valid := server.ownedScheduledTransfer(ctx, uri.ID)
+	if !valid {
+		return
+	}
+
+	runs, err := server.store.ListScheduledTransferRuns(ctx, db.ListScheduledTransferRunsParams{
+		ScheduledTransferID: scheduled.ID,
+		Limit:               req.PageSize,
+		Offset:              (req.PageID - 1) * req.PageSize,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := make([]scheduledTransferRunResponse, len(runs))
+	for i, run := range runs {
+		rsp[i] = newScheduledTransferRunResponse(run, scheduled.Currency)
+	}
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+// ownedScheduledTransfer 查询定时转账并检查是否属于当前用户
+func (server *Server) ownedScheduledTransfer(ctx *gin.Context, id int64) (db.ScheduledTransfer, bool) {
+	scheduled, err := server.store.GetScheduledTransfer(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return scheduled, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return scheduled, false
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if scheduled.Owner != authPayload.Username {
+		err := errors.New("scheduled transfer doesn't belong to the authenticated user")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return scheduled, false
+	}
+
+	return scheduled, true
+}
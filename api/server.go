@@ -1,38 +1,68 @@
 package api
 
 import (
+    "context"
     "fmt"
+    "net"
+    "net/http"
+    "sync"
 
     "github.com/gin-gonic/gin"
     "github.com/gin-gonic/gin/binding"
     "github.com/go-playground/validator/v10"
+    "github.com/xiusl/bank/currency"
     db "github.com/xiusl/bank/db/sqlc"
+    "github.com/xiusl/bank/fee"
+    "github.com/xiusl/bank/fx"
+    "github.com/xiusl/bank/money"
+    "github.com/xiusl/bank/reconcile"
     "github.com/xiusl/bank/token"
     "github.com/xiusl/bank/util"
 )
 
 // Server http 服务
 type Server struct {
-    store      db.Store
-    router     *gin.Engine
-    config     util.Config
-    tokenMaker token.Maker
+    store       db.Store
+    router      *gin.Engine
+    config      util.Config
+    tokenMaker  token.Maker
+    revocations token.RevocationStore
+    rates       fx.RateProvider
+    fees        *fee.Schedule
+    reconciler  *reconcile.Monitor
+    currencies  *currency.Registry
 }
 
-// NewServer 创建一个新的服务，并设置路由
-func NewServer(config util.Config, store db.Store) (*Server, error) {
+// NewServer 创建一个新的服务，并设置路由；作废列表、汇率和货币表与 gRPC 服务共用，由调用方创建
+func NewServer(
+    config util.Config,
+    store db.Store,
+    revocations token.RevocationStore,
+    rates fx.RateProvider,
+    currencies *currency.Registry,
+) (*Server, error) {
     tokenMaker, err := token.NewPasetoMaker(config.TokenSymmertricKey)
     if err != nil {
         return nil, fmt.Errorf("cannot create token maker: %w", err)
     }
+    fees, err := fee.LoadSchedule(config.TransferFeesFile)
+    if err != nil {
+        return nil, fmt.Errorf("cannot create fee schedule: %w", err)
+    }
     server := &Server{
-        config:     config,
-        store:      store,
-        tokenMaker: tokenMaker,
+        config:      config,
+        store:       store,
+        tokenMaker:  tokenMaker,
+        revocations: revocations,
+        rates:       rates,
+        fees:        fees,
+        reconciler:  reconcile.NewMonitor(reconcile.NewReconciler(store), config.ReconcileInterval),
+        currencies:  currencies,
     }
 
     if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
-        v.RegisterValidation("currency", validCurrency)
+        v.RegisterValidation("currency", currencies.Validator())
+        v.RegisterCustomTypeFunc(moneyAmount, money.Money{})
     }
 
     server.setupRouter()
@@ -41,26 +71,123 @@ func NewServer(config util.Config, store db.Store) (*Server, error) {
 }
 
 func (server *Server) setupRouter() {
-    router := gin.Default()
+    router := gin.New()
+    router.Use(requestLogger(), gin.Recovery())
 
     // 设置路由
 
     router.POST("/users", server.createUser)
     router.POST("/users/login", server.loginUser)
+    router.POST("/tokens/renew_access", server.renewAccessToken)
+
+    authRoutes := router.Group("/").Use(authMiddleware(server.tokenMaker, server.revocations))
 
-    authRoutes := router.Group("/").Use(authMiddleware(server.tokenMaker))
+    authRoutes.POST("/users/logout", server.logoutUser)
 
-    authRoutes.POST("/accounts", server.createAccount)
+    authRoutes.POST("/accounts", idempotencyMiddleware(server.store, server.config.IdempotencyKeyTTL), server.createAccount)
     authRoutes.GET("/accounts/:id", server.getAccount)
+    authRoutes.PATCH("/accounts/:id/status", server.updateAccountStatus)
     authRoutes.GET("/accounts", server.listAccount)
-    authRoutes.POST("/transfers", server.createTransfer)
+    authRoutes.GET("/accounts/:id/entries", server.listEntries)
+    authRoutes.GET("/accounts/:id/transfers", server.listTransfers)
+    authRoutes.GET("/accounts/:id/statement", streamWriteTimeout(server.config.HTTPStreamWriteTimeout), server.getStatement)
+    authRoutes.POST("/accounts/:id/deposits", idempotencyMiddleware(server.store, server.config.IdempotencyKeyTTL), server.createDeposit)
+    authRoutes.POST("/accounts/:id/withdrawals", idempotencyMiddleware(server.store, server.config.IdempotencyKeyTTL), server.createWithdrawal)
+    authRoutes.POST("/accounts/:id/holds", idempotencyMiddleware(server.store, server.config.IdempotencyKeyTTL), server.placeHold)
+    authRoutes.GET("/holds/:id", server.getHold)
+    authRoutes.POST("/holds/:id/capture", idempotencyMiddleware(server.store, server.config.IdempotencyKeyTTL), server.captureHold)
+    authRoutes.POST("/holds/:id/release", server.releaseHold)
+    authRoutes.POST("/transfers", idempotencyMiddleware(server.store, server.config.IdempotencyKeyTTL), server.createTransfer)
+    authRoutes.POST("/transfers/scheduled", idempotencyMiddleware(server.store, server.config.IdempotencyKeyTTL), server.createScheduledTransfer)
+    authRoutes.GET("/transfers/scheduled", server.listScheduledTransfers)
+    authRoutes.GET("/transfers/scheduled/:id", server.getScheduledTransfer)
+    authRoutes.PATCH("/transfers/scheduled/:id", server.updateScheduledTransfer)
+    authRoutes.DELETE("/transfers/scheduled/:id", server.deleteScheduledTransfer)
+    authRoutes.GET("/transfers/scheduled/:id/runs", server.listScheduledTransferRuns)
+    authRoutes.POST(
+        "/transfers/:id/reverse",
+        roleMiddleware(util.BankerRole, util.AdminRole),
+        idempotencyMiddleware(server.store, server.config.IdempotencyKeyTTL),
+        server.reverseTransfer,
+    )
+    authRoutes.POST("/webhooks", server.createWebhook)
+    authRoutes.GET("/webhooks", server.listWebhooks)
+    authRoutes.DELETE("/webhooks/:id", server.deleteWebhook)
+    authRoutes.GET("/webhooks/:id/deliveries", server.listWebhookDeliveries)
+    authRoutes.POST("/webhooks/:id/deliveries/:delivery_id/replay", server.replayWebhookDelivery)
+
+    adminRoutes := router.Group("/admin").Use(
+        authMiddleware(server.tokenMaker, server.revocations),
+        roleMiddleware(util.AdminRole),
+    )
+
+    adminRoutes.POST("/users/:username/revoke_sessions", server.revokeUserSessions)
+    adminRoutes.GET("/reconciliation", server.getReconciliation)
+    adminRoutes.GET("/currencies", server.listCurrencies)
+    adminRoutes.PATCH("/currencies/:code", server.updateCurrency)
 
     server.router = router
 }
 
-// Start 开启服务器，address 监听的地址
-func (server *Server) Start(address string) error {
-    return server.router.Run(address)
+// Start 开启服务器，address 监听的地址；货币表由调用方加载和刷新，
+// 配置了核对间隔时在后台定期核对账本，配置了幂等 key 有效期时在后台定期清理过期的 key。
+// ctx 取消后不再接受新请求，等待处理中的请求完成（最多 ShutdownTimeout，为 0 时一直等待），
+// 并等后台任务退出后返回
+func (server *Server) Start(ctx context.Context, address string) error {
+    ctx, cancel := context.WithCancel(ctx)
+    var workers sync.WaitGroup
+    defer workers.Wait()
+    defer cancel()
+
+    if server.config.ReconcileInterval > 0 {
+        workers.Add(1)
+        go func() {
+            defer workers.Done()
+            server.reconciler.Start(ctx)
+        }()
+    }
+    if server.config.IdempotencyKeyTTL > 0 {
+        workers.Add(1)
+        go func() {
+            defer workers.Done()
+            cleanIdempotencyKeys(ctx, server.store, server.config.IdempotencyKeyTTL)
+        }()
+    }
+
+    httpServer := &http.Server{
+        Addr:         address,
+        Handler:      server.router,
+        ReadTimeout:  server.config.HTTPReadTimeout,
+        WriteTimeout: server.config.HTTPWriteTimeout,
+        IdleTimeout:  server.config.HTTPIdleTimeout,
+        // 流式输出的路由通过连接调整自己的写截止时间，见 streamWriteTimeout
+        ConnContext: func(ctx context.Context, conn net.Conn) context.Context {
+            return context.WithValue(ctx, connContextKey{}, conn)
+        },
+    }
+
+    errs := make(chan error, 1)
+    go func() {
+        errs <- httpServer.ListenAndServe()
+    }()
+
+    select {
+    case err := <-errs:
+        return err
+    case <-ctx.Done():
+    }
+
+    shutdownCtx := context.Background()
+    if server.config.ShutdownTimeout > 0 {
+        var cancelShutdown context.CancelFunc
+        shutdownCtx, cancelShutdown = context.WithTimeout(shutdownCtx, server.config.ShutdownTimeout)
+        defer cancelShutdown()
+    }
+
+    if err := httpServer.Shutdown(shutdownCtx); err != nil {
+        return fmt.Errorf("cannot shutdown server: %w", err)
+    }
+    return nil
 }
 
 // 格式化错误信息
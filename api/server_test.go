@@ -0,0 +1,179 @@
+package api
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/xiusl/bank/db/mock"
+)
+
+// freeAddress 返回一个当前未被占用的本地地址
+func freeAddress(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	return listener.Addr().String()
+}
+
+func TestServerGracefulShutdown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	server := newTestServer(t, store)
+	server.config.ShutdownTimeout = time.Second
+
+	started := make(chan struct{})
+	server.router.GET("/slow", func(ctx *gin.Context) {
+		close(started)
+		time.Sleep(200 * time.Millisecond)
+		ctx.String(http.StatusOK, "done")
+	})
+
+	address := freeAddress(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stopped := make(chan error, 1)
+	go func() {
+		stopped <- server.Start(ctx, address)
+	}()
+
+	require.Eventually(t, func() bool {
+		conn, err := net.Dial("tcp", address)
+		if err != nil {
+			return false
+		}
+		conn.Close()
+		return true
+	}, time.Second, 10*time.Millisecond)
+
+	type result struct {
+		rsp *http.Response
+		err error
+	}
+	responses := make(chan result, 1)
+	go func() {
+		rsp, err := http.Get("http://" + address + "/slow")
+		responses <- result{rsp, err}
+	}()
+
+	// 请求处理中收到停止信号，请求仍然正常完成
+	<-started
+	cancel()
+
+	res := <-responses
+	require.NoError(t, res.err)
+	defer res.rsp.Body.Close()
+	require.Equal(t, http.StatusOK, res.rsp.StatusCode)
+	body, err := ioutil.ReadAll(res.rsp.Body)
+	require.NoError(t, err)
+	require.Equal(t, "done", string(body))
+
+	require.NoError(t, <-stopped)
+
+	// 停止后不再接受新连接
+	_, err = net.Dial("tcp", address)
+	require.Error(t, err)
+}
+
+func TestServerShutdownTimeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	server := newTestServer(t, store)
+	server.config.ShutdownTimeout = 50 * time.Millisecond
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	server.router.GET("/stuck", func(ctx *gin.Context) {
+		close(started)
+		<-release
+	})
+
+	address := freeAddress(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stopped := make(chan error, 1)
+	go func() {
+		stopped <- server.Start(ctx, address)
+	}()
+
+	require.Eventually(t, func() bool {
+		conn, err := net.Dial("tcp", address)
+		if err != nil {
+			return false
+		}
+		conn.Close()
+		return true
+	}, time.Second, 10*time.Millisecond)
+
+	go http.Get("http://" + address + "/stuck")
+	<-started
+	cancel()
+
+	// 超过 ShutdownTimeout 仍有请求未完成时返回错误，不会一直阻塞
+	select {
+	case err := <-stopped:
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	case <-time.After(time.Second):
+		t.Fatal("server did not stop after shutdown timeout")
+	}
+}
+
+func TestServerStreamWriteTimeout(t *testing.T) {
+	server := newTestServer(t, nil)
+	server.config.HTTPWriteTimeout = 50 * time.Millisecond
+
+	handler := func(ctx *gin.Context) {
+		time.Sleep(150 * time.Millisecond)
+		ctx.String(http.StatusOK, "done")
+	}
+	server.router.GET("/slow", handler)
+	server.router.GET("/stream", streamWriteTimeout(time.Second), handler)
+
+	address := freeAddress(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stopped := make(chan error, 1)
+	go func() {
+		stopped <- server.Start(ctx, address)
+	}()
+	defer func() {
+		cancel()
+		require.NoError(t, <-stopped)
+	}()
+
+	require.Eventually(t, func() bool {
+		conn, err := net.Dial("tcp", address)
+		if err != nil {
+			return false
+		}
+		conn.Close()
+		return true
+	}, time.Second, 10*time.Millisecond)
+
+	// 普通路由超过 HTTP_WRITE_TIMEOUT 后响应被丢弃
+	_, err := http.Get("http://" + address + "/slow")
+	require.Error(t, err)
+
+	// 流式路由使用自己的写超时
+	rsp, err := http.Get("http://" + address + "/stream")
+	require.NoError(t, err)
+	defer rsp.Body.Close()
+	require.Equal(t, http.StatusOK, rsp.StatusCode)
+	body, err := ioutil.ReadAll(rsp.Body)
+	require.NoError(t, err)
+	require.Equal(t, "done", string(body))
+}
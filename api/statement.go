@@ -0,0 +1,81 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xiusl/bank/policy"
+	"github.com/xiusl/bank/statement"
+)
+
+type getStatementRequest struct {
+	From   time.Time `form:"from" binding:"required"`
+	To     time.Time `form:"to"`
+	Format string    `form:"format" binding:"omitempty,oneof=csv json ofx"`
+}
+
+// getStatement 导出账户在 [from, to) 期间的对账单，默认 JSON 格式；
+// to 不传或晚于当前时间时截至当前时间，以保证期初余额和分录一致
+func (server *Server) getStatement(ctx *gin.Context) {
+	var uri getAccountRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req getStatementRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	now := time.Now()
+	if req.To.IsZero() || req.To.After(now) {
+		req.To = now
+	}
+	if !req.From.Before(req.To) {
+		err := errors.New("from must be earlier than to")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if req.Format == "" {
+		req.Format = statement.FormatJSON
+	}
+
+	account, valid := server.authorizedAccount(ctx, uri.ID, policy.ViewAccount)
+	if !valid {
+		return
+	}
+
+	writer, err := statement.NewWriter(req.Format, ctx.Writer)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	header := statement.Header{
+		AccountID:   account.ID,
+		Owner:       account.Owner,
+		Currency:    account.Currency,
+		AccountType: account.AccountType,
+		From:        req.From,
+		To:          req.To,
+		GeneratedAt: now,
+	}
+	ctx.Header("Content-Type", statement.ContentType(req.Format))
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="statement-%d.%s"`, account.ID, req.Format))
+
+	_, err = statement.Generate(ctx, server.store, header, writer)
+	if err != nil {
+		// 已经开始输出时无法再改状态码，只能中断响应
+		if ctx.Writer.Written() {
+			ctx.Abort()
+			return
+		}
+		ctx.Writer.Header().Del("Content-Type")
+		ctx.Writer.Header().Del("Content-Disposition")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+	}
+}
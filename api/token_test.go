@@ -0,0 +1,194 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/xiusl/bank/db/mock"
+	db "github.com/xiusl/bank/db/sqlc"
+	"github.com/xiusl/bank/token"
+	"github.com/xiusl/bank/util"
+)
+
+func TestRenewAccessTokenAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		duration      time.Duration
+		tokenType     token.TokenType
+		buildSession  func(payload *token.Payload, refreshToken string) db.Session
+		sessionErr    error
+		buildUser     func(store *mockdb.MockStore)
+		role          string
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			duration: time.Hour,
+			buildSession: func(payload *token.Payload, refreshToken string) db.Session {
+				return newTestSession(payload, refreshToken)
+			},
+			buildUser: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp renewAccessTokenResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &rsp)
+				require.NoError(t, err)
+				require.NotEmpty(t, rsp.AccessToken)
+				require.True(t, rsp.AccessTokenExpiresAt.After(time.Now()))
+			},
+		},
+		{
+			name:     "RoleChanged",
+			duration: time.Hour,
+			buildSession: func(payload *token.Payload, refreshToken string) db.Session {
+				return newTestSession(payload, refreshToken)
+			},
+			buildUser: func(store *mockdb.MockStore) {
+				banker := user
+				banker.Role = util.BankerRole
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(banker, nil)
+			},
+			role: util.BankerRole,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "UserNotFound",
+			duration: time.Hour,
+			buildSession: func(payload *token.Payload, refreshToken string) db.Session {
+				return newTestSession(payload, refreshToken)
+			},
+			buildUser: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(db.User{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:     "BlockedSession",
+			duration: time.Hour,
+			buildSession: func(payload *token.Payload, refreshToken string) db.Session {
+				session := newTestSession(payload, refreshToken)
+				session.IsBlocked = true
+				return session
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:     "MismatchedToken",
+			duration: time.Hour,
+			buildSession: func(payload *token.Payload, refreshToken string) db.Session {
+				return newTestSession(payload, "other-token")
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:     "SessionNotFound",
+			duration: time.Hour,
+			buildSession: func(payload *token.Payload, refreshToken string) db.Session {
+				return db.Session{}
+			},
+			sessionErr: sql.ErrNoRows,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:     "ExpiredToken",
+			duration: -time.Minute,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:      "AccessToken",
+			duration:  time.Hour,
+			tokenType: token.AccessToken,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			server := newTestServer(t, store)
+
+			tokenType := token.RefreshToken
+			if len(tc.tokenType) > 0 {
+				tokenType = tc.tokenType
+			}
+			refreshToken, payload, err := server.tokenMaker.CreateToken(user.Username, util.DepositorRole, tokenType, tc.duration)
+			require.NoError(t, err)
+
+			if tc.buildSession != nil {
+				session := tc.buildSession(payload, refreshToken)
+				store.EXPECT().
+					GetSession(gomock.Any(), gomock.Eq(payload.ID)).
+					Times(1).
+					Return(session, tc.sessionErr)
+			} else {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Any()).Times(0)
+			}
+			if tc.buildUser != nil {
+				tc.buildUser(store)
+			} else {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+			}
+
+			recorder := httptest.NewRecorder()
+			data, err := json.Marshal(gin.H{"refresh_token": refreshToken})
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/tokens/renew_access", bytes.NewBuffer(data))
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+
+			if len(tc.role) > 0 {
+				var rsp renewAccessTokenResponse
+				err = json.Unmarshal(recorder.Body.Bytes(), &rsp)
+				require.NoError(t, err)
+
+				accessPayload, err := server.tokenMaker.VerifyToken(rsp.AccessToken, token.AccessToken)
+				require.NoError(t, err)
+				require.Equal(t, tc.role, accessPayload.Role)
+			}
+		})
+	}
+}
+
+func newTestSession(payload *token.Payload, refreshToken string) db.Session {
+	return db.Session{
+		ID:           payload.ID,
+		Username:     payload.Username,
+		RefreshToken: refreshToken,
+		ExpiresAt:    payload.ExpiredAt,
+	}
+}
@@ -5,17 +5,75 @@ import (
     "errors"
     "fmt"
     "net/http"
+    "time"
 
     "github.com/gin-gonic/gin"
     db "github.com/xiusl/bank/db/sqlc"
-    "github.com/xiusl/bank/token"
+    "github.com/xiusl/bank/fx"
+    "github.com/xiusl/bank/money"
+    "github.com/xiusl/bank/policy"
 )
 
 type transferRequest struct {
-    FromAccountID int64  `json:"from_account_id" binding:"required,min=1"`
-    ToAccountID   int64  `json:"to_account_id" binding:"required,min=1"`
-    Amount        int64  `json:"amount" binding:"required,gt=0"`
-    Currency      string `json:"currency" binding:"required,currency"`
+    FromAccountID int64 `json:"from_account_id" binding:"required,min=1"`
+    ToAccountID   int64 `json:"to_account_id" binding:"required,min=1"`
+    // 以转出货币计
+    Amount money.Money `json:"amount" binding:"required,gt=0"`
+    // 跨币种转账时指定到账货币，默认与转出货币相同
+    ToCurrency string `json:"to_currency" binding:"omitempty,currency"`
+}
+
+// transferResponse 转账记录，金额按转出货币、到账金额按转入货币格式化，手续费以转出货币计
+type transferResponse struct {
+    ID            int64       `json:"id"`
+    FromAccountID int64       `json:"from_account_id"`
+    ToAccountID   int64       `json:"to_account_id"`
+    Amount        money.Money `json:"amount"`
+    ToAmount      money.Money `json:"to_amount"`
+    ExchangeRate  float64     `json:"exchange_rate"`
+    Fee           money.Money `json:"fee"`
+    CreatedAt     time.Time   `json:"created_at"`
+}
+
+func newTransferResponse(transfer db.Transfer, fromCurrency string, toCurrency string) transferResponse {
+    return transferResponse{
+        ID:            transfer.ID,
+        FromAccountID: transfer.FromAccountID,
+        ToAccountID:   transfer.ToAccountID,
+        Amount:        money.New(transfer.Amount, fromCurrency),
+        ToAmount:      money.New(transfer.ToAmount, toCurrency),
+        ExchangeRate:  transfer.ExchangeRate,
+        Fee:           money.New(transfer.Fee, fromCurrency),
+        CreatedAt:     transfer.CreatedAt,
+    }
+}
+
+// transferTxResponse 转账结果，包含转账后的两端账户和分录，不收手续费时没有 fee_entry
+type transferTxResponse struct {
+    Transfer    transferResponse `json:"transfer"`
+    JournalID   int64            `json:"journal_id"`
+    FromAccount accountResponse  `json:"from_account"`
+    ToAccount   accountResponse  `json:"to_account"`
+    FromEntry   entryResponse    `json:"from_entry"`
+    ToEntry     entryResponse    `json:"to_entry"`
+    FeeEntry    *entryResponse   `json:"fee_entry,omitempty"`
+}
+
+func newTransferTxResponse(result db.TransferTxResult) transferTxResponse {
+    fromCurrency, toCurrency := result.FromAccount.Currency, result.ToAccount.Currency
+    rsp := transferTxResponse{
+        Transfer:    newTransferResponse(result.Transfer, fromCurrency, toCurrency),
+        JournalID:   result.Journal.ID,
+        FromAccount: newAccountResponse(result.FromAccount),
+        ToAccount:   newAccountResponse(result.ToAccount),
+        FromEntry:   newEntryResponse(result.FromEntry, fromCurrency),
+        ToEntry:     newEntryResponse(result.ToEntry, toCurrency),
+    }
+    if result.FeeEntry.ID != 0 {
+        feeEntry := newEntryResponse(result.FeeEntry, fromCurrency)
+        rsp.FeeEntry = &feeEntry
+    }
+    return rsp
 }
 
 func (server *Server) createTransfer(ctx *gin.Context) {
@@ -25,19 +83,22 @@ func (server *Server) createTransfer(ctx *gin.Context) {
         return
     }
 
-    fromAccount, valid := server.validAccount(ctx, req.FromAccountID, req.Currency)
+    currency := req.Amount.Currency
+    fromAccount, valid := server.validAccount(ctx, req.FromAccountID, currency)
     if !valid {
         return
     }
 
-    authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
-    if authPayload.Username != fromAccount.Owner {
-        err := errors.New("account does't belong to the authorizated user")
-        ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+    if !authorizeAccount(ctx, fromAccount, policy.OperateAccount) {
         return
     }
 
-    _, valid = server.validAccount(ctx, req.ToAccountID, req.Currency)
+    toCurrency := req.ToCurrency
+    if len(toCurrency) == 0 {
+        toCurrency = currency
+    }
+
+    _, valid = server.validAccount(ctx, req.ToAccountID, toCurrency)
     if !valid {
         return
     }
@@ -45,15 +106,46 @@ func (server *Server) createTransfer(ctx *gin.Context) {
     arg := db.TransferTxParams{
         FromAccountID: req.FromAccountID,
         ToAccountID:   req.ToAccountID,
-        Amount:        req.Amount,
+        Amount:        req.Amount.Amount,
+    }
+
+    if toCurrency != currency {
+        quote, err := fx.NewQuote(ctx, server.rates, currency, toCurrency, req.Amount.Amount, server.config.FXSpread)
+        if err != nil {
+            if errors.Is(err, fx.ErrRateNotFound) || errors.Is(err, fx.ErrAmountTooSmall) {
+                ctx.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+                return
+            }
+            ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+            return
+        }
+        arg.ToAmount = quote.ToAmount
+        arg.ExchangeRate = quote.Rate
+    }
+
+    // 手续费按转出货币收取
+    breakdown := server.fees.Calculate(currency, req.Amount.Amount)
+    arg.Fee = db.TransferFee{
+        Flat:       breakdown.Flat,
+        Percentage: breakdown.Percentage,
+        Amount:     breakdown.Amount,
     }
+
     result, err := server.store.TransferTx(ctx, arg)
     if err != nil {
+        if errors.Is(err, db.ErrInsufficientFunds) || errors.Is(err, db.ErrAccountNotActive) {
+            ctx.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+            return
+        }
+        if errors.Is(err, db.ErrCurrencyMismatch) {
+            ctx.JSON(http.StatusBadRequest, errorResponse(err))
+            return
+        }
         ctx.JSON(http.StatusInternalServerError, errorResponse(err))
         return
     }
 
-    ctx.JSON(http.StatusOK, result)
+    ctx.JSON(http.StatusOK, newTransferTxResponse(result))
 }
 
 func (server *Server) validAccount(ctx *gin.Context, accountID int64, currency string) (db.Account, bool) {
@@ -73,5 +165,12 @@ func (server *Server) validAccount(ctx *gin.Context, accountID int64, currency s
         return account, false
     }
 
+    // 货币停用后已开的账户保留，但不能再发起转账或预授权
+    if !server.currencies.IsEnabled(account.Currency) {
+        err := fmt.Errorf("account [%d] currency %s is disabled", accountID, account.Currency)
+        ctx.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+        return account, false
+    }
+
     return account, true
 }
@@ -14,6 +14,9 @@ import (
     "github.com/stretchr/testify/require"
     mockdb "github.com/xiusl/bank/db/mock"
     db "github.com/xiusl/bank/db/sqlc"
+    "github.com/xiusl/bank/fee"
+    "github.com/xiusl/bank/fx"
+    "github.com/xiusl/bank/money"
     "github.com/xiusl/bank/token"
     "github.com/xiusl/bank/util"
 )
@@ -28,10 +31,14 @@ func TestTransferAPI(t *testing.T) {
     account1 := randomAccount(user1.Username)
     account2 := randomAccount(user2.Username)
     account3 := randomAccount(user3.Username)
+    account4 := randomAccount(user2.Username)
 
     account1.Currency = util.USD
     account2.Currency = util.USD
     account3.Currency = util.EUR
+    account4.Currency = util.EUR
+    account5 := randomAccount(user1.Username)
+    account5.Currency = disabledCurrency
 
     testCases := []struct {
         name          string
@@ -45,11 +52,10 @@ func TestTransferAPI(t *testing.T) {
             body: gin.H{
                 "from_account_id": account1.ID,
                 "to_account_id":   account2.ID,
-                "amount":          amount,
-                "currency":        util.USD,
+                "amount":          money.New(amount, util.USD),
             },
             setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user1.Username, util.DepositorRole, time.Minute)
             },
             buildStubs: func(store *mockdb.MockStore) {
                 store.EXPECT().
@@ -71,16 +77,36 @@ func TestTransferAPI(t *testing.T) {
                 require.Equal(t, http.StatusOK, recorder.Code)
             },
         },
+        {
+            name: "CurrencyDisabled",
+            body: gin.H{
+                "from_account_id": account5.ID,
+                "to_account_id":   account2.ID,
+                "amount":          money.New(amount, disabledCurrency),
+            },
+            setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user1.Username, util.DepositorRole, time.Minute)
+            },
+            buildStubs: func(store *mockdb.MockStore) {
+                store.EXPECT().
+                    GetAccount(gomock.Any(), gomock.Eq(account5.ID)).
+                    Times(1).Return(account5, nil)
+                store.EXPECT().
+                    TransferTx(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResponse: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+            },
+        },
         {
             name: "FromAccountNotFound",
             body: gin.H{
                 "from_account_id": account1.ID,
                 "to_account_id":   account2.ID,
-                "amount":          amount,
-                "currency":        util.USD,
+                "amount":          money.New(amount, util.USD),
             },
             setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user1.Username, util.DepositorRole, time.Minute)
             },
             buildStubs: func(store *mockdb.MockStore) {
                 store.EXPECT().
@@ -101,11 +127,10 @@ func TestTransferAPI(t *testing.T) {
             body: gin.H{
                 "from_account_id": account1.ID,
                 "to_account_id":   account2.ID,
-                "amount":          amount,
-                "currency":        util.USD,
+                "amount":          money.New(amount, util.USD),
             },
             setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user1.Username, util.DepositorRole, time.Minute)
             },
             buildStubs: func(store *mockdb.MockStore) {
                 store.EXPECT().
@@ -126,11 +151,10 @@ func TestTransferAPI(t *testing.T) {
             body: gin.H{
                 "from_account_id": account3.ID,
                 "to_account_id":   account1.ID,
-                "amount":          amount,
-                "currency":        util.USD,
+                "amount":          money.New(amount, util.USD),
             },
             setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user3.Username, time.Minute)
+                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user3.Username, util.DepositorRole, time.Minute)
             },
             buildStubs: func(store *mockdb.MockStore) {
                 store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account3.ID)).Times(1).Return(account3, nil)
@@ -146,11 +170,10 @@ func TestTransferAPI(t *testing.T) {
             body: gin.H{
                 "from_account_id": account1.ID,
                 "to_account_id":   account3.ID,
-                "amount":          amount,
-                "currency":        util.USD,
+                "amount":          money.New(amount, util.USD),
             },
             setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user1.Username, util.DepositorRole, time.Minute)
             },
             buildStubs: func(store *mockdb.MockStore) {
                 store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
@@ -166,11 +189,10 @@ func TestTransferAPI(t *testing.T) {
             body: gin.H{
                 "from_account_id": account1.ID,
                 "to_account_id":   account2.ID,
-                "amount":          amount,
-                "currency":        "abc",
+                "amount":          money.New(amount, "abc"),
             },
             setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user1.Username, util.DepositorRole, time.Minute)
             },
             buildStubs: func(store *mockdb.MockStore) {
                 store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
@@ -185,11 +207,10 @@ func TestTransferAPI(t *testing.T) {
             body: gin.H{
                 "from_account_id": account1.ID,
                 "to_account_id":   account2.ID,
-                "amount":          -amount,
-                "currency":        util.USD,
+                "amount":          money.New(-amount, util.USD),
             },
             setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user1.Username, util.DepositorRole, time.Minute)
             },
             buildStubs: func(store *mockdb.MockStore) {
                 store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
@@ -204,11 +225,10 @@ func TestTransferAPI(t *testing.T) {
             body: gin.H{
                 "from_account_id": account1.ID,
                 "to_account_id":   account2.ID,
-                "amount":          amount,
-                "currency":        util.USD,
+                "amount":          money.New(amount, util.USD),
             },
             setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user1.Username, util.DepositorRole, time.Minute)
             },
             buildStubs: func(store *mockdb.MockStore) {
                 store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(1).Return(db.Account{}, sql.ErrConnDone)
@@ -223,11 +243,10 @@ func TestTransferAPI(t *testing.T) {
             body: gin.H{
                 "from_account_id": account1.ID,
                 "to_account_id":   account2.ID,
-                "amount":          amount,
-                "currency":        util.USD,
+                "amount":          money.New(amount, util.USD),
             },
             setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user1.Username, util.DepositorRole, time.Minute)
             },
             buildStubs: func(store *mockdb.MockStore) {
                 store.EXPECT().GetAccount(gomock.Any(), account1.ID).Times(1).Return(account1, nil)
@@ -238,13 +257,147 @@ func TestTransferAPI(t *testing.T) {
                 require.Equal(t, http.StatusInternalServerError, recorder.Code)
             },
         },
+        {
+            name: "InsufficientFunds",
+            body: gin.H{
+                "from_account_id": account1.ID,
+                "to_account_id":   account2.ID,
+                "amount":          money.New(amount, util.USD),
+            },
+            setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user1.Username, util.DepositorRole, time.Minute)
+            },
+            buildStubs: func(store *mockdb.MockStore) {
+                store.EXPECT().GetAccount(gomock.Any(), account1.ID).Times(1).Return(account1, nil)
+                store.EXPECT().GetAccount(gomock.Any(), account2.ID).Times(1).Return(account2, nil)
+                store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1).Return(db.TransferTxResult{}, db.ErrInsufficientFunds)
+            },
+            checkResponse: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+            },
+        },
+        {
+            name: "AccountNotActive",
+            body: gin.H{
+                "from_account_id": account1.ID,
+                "to_account_id":   account2.ID,
+                "amount":          money.New(amount, util.USD),
+            },
+            setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user1.Username, util.DepositorRole, time.Minute)
+            },
+            buildStubs: func(store *mockdb.MockStore) {
+                store.EXPECT().GetAccount(gomock.Any(), account1.ID).Times(1).Return(account1, nil)
+                store.EXPECT().GetAccount(gomock.Any(), account2.ID).Times(1).Return(account2, nil)
+                store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1).Return(db.TransferTxResult{}, db.ErrAccountNotActive)
+            },
+            checkResponse: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+            },
+        },
+        {
+            name: "CrossCurrency",
+            body: gin.H{
+                "from_account_id": account1.ID,
+                "to_account_id":   account3.ID,
+                "amount":          money.New(amount, util.USD),
+                "to_currency":     util.EUR,
+            },
+            setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user1.Username, util.DepositorRole, time.Minute)
+            },
+            buildStubs: func(store *mockdb.MockStore) {
+                store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+                store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account3.ID)).Times(1).Return(account3, nil)
+
+                arg := db.TransferTxParams{
+                    FromAccountID: account1.ID,
+                    ToAccountID:   account3.ID,
+                    Amount:        amount,
+                    ToAmount:      8,
+                    ExchangeRate:  0.8,
+                }
+                store.EXPECT().TransferTx(gomock.Any(), gomock.Eq(arg)).Times(1)
+            },
+            checkResponse: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+            },
+        },
+        {
+            name: "WithFee",
+            body: gin.H{
+                "from_account_id": account3.ID,
+                "to_account_id":   account4.ID,
+                "amount":          money.New(1000, util.EUR),
+            },
+            setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user3.Username, util.DepositorRole, time.Minute)
+            },
+            buildStubs: func(store *mockdb.MockStore) {
+                store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account3.ID)).Times(1).Return(account3, nil)
+                store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account4.ID)).Times(1).Return(account4, nil)
+
+                arg := db.TransferTxParams{
+                    FromAccountID: account3.ID,
+                    ToAccountID:   account4.ID,
+                    Amount:        1000,
+                    Fee: db.TransferFee{
+                        Flat:       5,
+                        Percentage: 10,
+                        Amount:     15,
+                    },
+                }
+                store.EXPECT().TransferTx(gomock.Any(), gomock.Eq(arg)).Times(1)
+            },
+            checkResponse: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+            },
+        },
+        {
+            name: "CrossCurrencyAmountTooSmall",
+            body: gin.H{
+                "from_account_id": account1.ID,
+                "to_account_id":   account3.ID,
+                "amount":          money.New(1, util.USD),
+                "to_currency":     util.EUR,
+            },
+            setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user1.Username, util.DepositorRole, time.Minute)
+            },
+            buildStubs: func(store *mockdb.MockStore) {
+                store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+                store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account3.ID)).Times(1).Return(account3, nil)
+                store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResponse: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+            },
+        },
+        {
+            name: "InvalidToCurrency",
+            body: gin.H{
+                "from_account_id": account1.ID,
+                "to_account_id":   account3.ID,
+                "amount":          money.New(amount, util.USD),
+                "to_currency":     "abc",
+            },
+            setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user1.Username, util.DepositorRole, time.Minute)
+            },
+            buildStubs: func(store *mockdb.MockStore) {
+                store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+                store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResponse: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusBadRequest, recorder.Code)
+            },
+        },
         {
             name: "UnAuthorization",
             body: gin.H{
                 "from_account_id": account1.ID,
                 "to_account_id":   account2.ID,
-                "amount":          amount,
-                "currency":        util.USD,
+                "amount":          money.New(amount, util.USD),
             },
             setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
             },
@@ -261,11 +414,10 @@ func TestTransferAPI(t *testing.T) {
             body: gin.H{
                 "from_account_id": account1.ID,
                 "to_account_id":   account2.ID,
-                "amount":          amount,
-                "currency":        util.USD,
+                "amount":          money.New(amount, util.USD),
             },
             setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "invaliduser", time.Minute)
+                addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "invaliduser", util.DepositorRole, time.Minute)
             },
             buildStubs: func(store *mockdb.MockStore) {
                 store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
@@ -288,6 +440,17 @@ func TestTransferAPI(t *testing.T) {
             tc.buildStubs(store)
 
             server := newTestServer(t, store)
+            rates, err := fx.NewStaticRateProvider(map[string]float64{
+                "USD/EUR": 0.8,
+            })
+            require.NoError(t, err)
+            server.rates = rates
+            // 只对 EUR 收手续费
+            fees, err := fee.NewSchedule(map[string]fee.Rule{
+                util.EUR: {Flat: 5, Percent: 0.01},
+            })
+            require.NoError(t, err)
+            server.fees = fees
             recorder := httptest.NewRecorder()
 
             data, err := json.Marshal(tc.body)
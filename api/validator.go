@@ -1,13 +1,30 @@
 package api
 
 import (
-	"github.com/go-playground/validator/v10"
-	"github.com/xiusl/bank/util"
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/xiusl/bank/db/sqlc"
+	"github.com/xiusl/bank/money"
 )
 
-var validCurrency validator.Func = func(fieldLevel validator.FieldLevel) bool {
-	if currency, ok := fieldLevel.Field().Interface().(string); ok {
-		return util.IsSupporedCurrency(currency)
+// moneyAmount 让 money.Money 字段按最小货币单位的整数金额校验，例如 binding:"required,gt=0"；
+// 货币和金额格式在解析 JSON 时已经校验
+func moneyAmount(field reflect.Value) interface{} {
+	if m, ok := field.Interface().(money.Money); ok {
+		return m.Amount
+	}
+	return nil
+}
+
+// requireCurrency 检查金额的货币与账户一致，不一致时返回 400
+func requireCurrency(ctx *gin.Context, account db.Account, amount money.Money) bool {
+	if account.Currency != amount.Currency {
+		err := fmt.Errorf("account [%d] currency mismatch:%s vs %s", account.ID, account.Currency, amount.Currency)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return false
 	}
-	return false
+	return true
 }
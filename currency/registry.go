@@ -0,0 +1,95 @@
+package currency
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	db "github.com/xiusl/bank/db/sqlc"
+	"github.com/xiusl/bank/money"
+)
+
+// Registry 缓存 currencies 表，启动时加载，之后定期刷新
+type Registry struct {
+	querier  db.Querier
+	interval time.Duration
+
+	mu         sync.RWMutex
+	currencies map[string]db.Currency
+}
+
+func NewRegistry(querier db.Querier, interval time.Duration) *Registry {
+	return &Registry{
+		querier:    querier,
+		interval:   interval,
+		currencies: make(map[string]db.Currency),
+	}
+}
+
+// Start 每隔 interval 重新加载一次，直到 ctx 结束；首次加载由调用方通过 Refresh 完成
+func (registry *Registry) Start(ctx context.Context) {
+	ticker := time.NewTicker(registry.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := registry.Refresh(ctx); err != nil {
+			log.Println("refresh currencies failed:", err)
+		}
+	}
+}
+
+// Refresh 从数据库重新加载全部货币，并向 money 包登记最小货币单位
+func (registry *Registry) Refresh(ctx context.Context) error {
+	currencies, err := registry.querier.ListCurrencies(ctx)
+	if err != nil {
+		return err
+	}
+
+	loaded := make(map[string]db.Currency, len(currencies))
+	for _, currency := range currencies {
+		loaded[currency.Code] = currency
+		register(currency)
+	}
+
+	registry.mu.Lock()
+	registry.currencies = loaded
+	registry.mu.Unlock()
+	return nil
+}
+
+// Set 更新缓存中的一种货币，管理员修改后本实例立即生效，其他实例在下次刷新时生效
+func (registry *Registry) Set(currency db.Currency) {
+	register(currency)
+
+	registry.mu.Lock()
+	registry.currencies[currency.Code] = currency
+	registry.mu.Unlock()
+}
+
+// Lookup 按货币代码查找，代码区分大小写
+func (registry *Registry) Lookup(code string) (db.Currency, bool) {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+	currency, ok := registry.currencies[code]
+	return currency, ok
+}
+
+// IsEnabled 货币存在且已启用
+func (registry *Registry) IsEnabled(code string) bool {
+	currency, ok := registry.Lookup(code)
+	return ok && currency.Enabled
+}
+
+func register(currency db.Currency) {
+	money.Register(money.Currency{
+		Code:     currency.Code,
+		Exponent: int(currency.Exponent),
+	})
+}
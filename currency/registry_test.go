@@ -0,0 +1,91 @@
+package currency
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/xiusl/bank/db/mock"
+	db "github.com/xiusl/bank/db/sqlc"
+	"github.com/xiusl/bank/money"
+	"github.com/xiusl/bank/util"
+)
+
+func TestRegistry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ListCurrencies(gomock.Any()).
+		Times(1).
+		Return([]db.Currency{
+			{Code: util.USD, Exponent: 2, Enabled: true},
+			{Code: util.EUR, Exponent: 2, Enabled: false},
+			{Code: "XTS", Exponent: 1, Enabled: true},
+		}, nil)
+
+	registry := NewRegistry(store, time.Minute)
+	require.False(t, registry.IsEnabled(util.USD))
+
+	err := registry.Refresh(context.Background())
+	require.NoError(t, err)
+
+	require.True(t, registry.IsEnabled(util.USD))
+	require.False(t, registry.IsEnabled(util.EUR))
+	require.False(t, registry.IsEnabled("usd"))
+	require.False(t, registry.IsEnabled("GBP"))
+
+	// 数据库中新增的货币也能按最小货币单位解析金额
+	m, err := money.Parse("1.5", "XTS")
+	require.NoError(t, err)
+	require.Equal(t, money.New(15, "XTS"), m)
+
+	registry.Set(db.Currency{Code: util.EUR, Exponent: 2, Enabled: true})
+	require.True(t, registry.IsEnabled(util.EUR))
+}
+
+func TestRegistryRefreshError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	gomock.InOrder(
+		store.EXPECT().
+			ListCurrencies(gomock.Any()).
+			Times(1).
+			Return([]db.Currency{{Code: util.USD, Exponent: 2, Enabled: true}}, nil),
+		store.EXPECT().
+			ListCurrencies(gomock.Any()).
+			Times(1).
+			Return(nil, sql.ErrConnDone),
+	)
+
+	registry := NewRegistry(store, time.Minute)
+	require.NoError(t, registry.Refresh(context.Background()))
+
+	// 刷新失败时保留上一次加载的结果
+	err := registry.Refresh(context.Background())
+	require.True(t, errors.Is(err, sql.ErrConnDone))
+	require.True(t, registry.IsEnabled(util.USD))
+}
+
+func TestValidator(t *testing.T) {
+	registry := NewRegistry(nil, time.Minute)
+	registry.Set(db.Currency{Code: util.USD, Exponent: 2, Enabled: true})
+	registry.Set(db.Currency{Code: util.EUR, Exponent: 2, Enabled: false})
+
+	validate := validator.New()
+	err := validate.RegisterValidation("currency", registry.Validator())
+	require.NoError(t, err)
+
+	require.NoError(t, validate.Var(util.USD, "currency"))
+	require.Error(t, validate.Var(util.EUR, "currency"))
+	require.Error(t, validate.Var("GBP", "currency"))
+	require.Error(t, validate.Var(1, "currency"))
+}
@@ -0,0 +1,13 @@
+package currency
+
+import "github.com/go-playground/validator/v10"
+
+// Validator 校验规则 currency 的实现，只接受货币表中已启用的货币，HTTP 和 gRPC 服务共用
+func (registry *Registry) Validator() validator.Func {
+	return func(fieldLevel validator.FieldLevel) bool {
+		if code, ok := fieldLevel.Field().Interface().(string); ok {
+			return registry.IsEnabled(code)
+		}
+		return false
+	}
+}
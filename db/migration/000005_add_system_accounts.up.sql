@@ -0,0 +1,3 @@
+-- 系统账户的所有者，用户名包含下划线，不会与通过 API 注册的用户冲突
+INSERT INTO "users" ("username", "hashed_password", "full_name", "email")
+VALUES ('system_cash', '', 'Bank Cash', 'cash@system.bank');
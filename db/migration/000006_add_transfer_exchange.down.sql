@@ -0,0 +1,11 @@
+DELETE FROM "entries" WHERE "account_id" IN (
+  SELECT "id" FROM "accounts" WHERE "owner" = 'system_fx'
+);
+
+DELETE FROM "accounts" WHERE "owner" = 'system_fx';
+
+DELETE FROM "users" WHERE "username" = 'system_fx';
+
+ALTER TABLE IF EXISTS "transfers" DROP COLUMN IF EXISTS "exchange_rate";
+
+ALTER TABLE IF EXISTS "transfers" DROP COLUMN IF EXISTS "to_amount";
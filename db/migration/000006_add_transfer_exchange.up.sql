@@ -0,0 +1,11 @@
+ALTER TABLE "transfers" ADD COLUMN "to_amount" bigint;
+
+UPDATE "transfers" SET "to_amount" = "amount";
+
+ALTER TABLE "transfers" ALTER COLUMN "to_amount" SET NOT NULL;
+
+ALTER TABLE "transfers" ADD COLUMN "exchange_rate" double precision NOT NULL DEFAULT 1;
+
+-- 换汇账户的所有者，跨币种转账的点差留在该账户
+INSERT INTO "users" ("username", "hashed_password", "full_name", "email")
+VALUES ('system_fx', '', 'Bank FX', 'fx@system.bank');
@@ -0,0 +1,5 @@
+ALTER TABLE "accounts" DROP CONSTRAINT IF EXISTS "status_check";
+
+ALTER TABLE "accounts" DROP COLUMN IF EXISTS "status";
+
+ALTER TABLE "users" DROP COLUMN IF EXISTS "role";
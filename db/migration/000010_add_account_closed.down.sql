@@ -0,0 +1,5 @@
+UPDATE "accounts" SET "status" = 'frozen' WHERE "status" = 'closed';
+
+ALTER TABLE "accounts" DROP CONSTRAINT IF EXISTS "status_check";
+
+ALTER TABLE "accounts" ADD CONSTRAINT "status_check" CHECK ("status" IN ('active', 'frozen'));
@@ -0,0 +1,3 @@
+ALTER TABLE "accounts" DROP CONSTRAINT IF EXISTS "status_check";
+
+ALTER TABLE "accounts" ADD CONSTRAINT "status_check" CHECK ("status" IN ('active', 'frozen', 'closed'));
@@ -0,0 +1,11 @@
+DELETE FROM "entries" WHERE "account_id" IN (
+  SELECT "id" FROM "accounts" WHERE "owner" = 'system_fee'
+);
+
+DELETE FROM "accounts" WHERE "owner" = 'system_fee';
+
+DELETE FROM "users" WHERE "username" = 'system_fee';
+
+ALTER TABLE IF EXISTS "transfers" DROP CONSTRAINT IF EXISTS "fee_check";
+
+ALTER TABLE IF EXISTS "transfers" DROP COLUMN IF EXISTS "fee";
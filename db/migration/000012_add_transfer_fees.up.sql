@@ -0,0 +1,7 @@
+ALTER TABLE "transfers" ADD COLUMN "fee" bigint NOT NULL DEFAULT 0;
+
+ALTER TABLE "transfers" ADD CONSTRAINT "fee_check" CHECK ("fee" >= 0);
+
+-- 手续费收入账户的所有者
+INSERT INTO "users" ("username", "hashed_password", "full_name", "email")
+VALUES ('system_fee', '', 'Bank Fee Revenue', 'fee@system.bank');
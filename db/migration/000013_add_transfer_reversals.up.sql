@@ -0,0 +1,20 @@
+CREATE TABLE "transfer_reversals" (
+  "id" bigserial PRIMARY KEY,
+  "transfer_id" bigint NOT NULL,
+  "reversal_transfer_id" bigint UNIQUE NOT NULL,
+  -- 冲正的金额，以原转账的转出货币计
+  "amount" bigint NOT NULL,
+  "reason" varchar NOT NULL DEFAULT '',
+  "initiated_by" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "transfer_reversals" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "transfer_reversals" ADD FOREIGN KEY ("reversal_transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "transfer_reversals" ADD FOREIGN KEY ("initiated_by") REFERENCES "users" ("username");
+
+ALTER TABLE "transfer_reversals" ADD CONSTRAINT "amount_check" CHECK ("amount" > 0);
+
+CREATE INDEX ON "transfer_reversals" ("transfer_id");
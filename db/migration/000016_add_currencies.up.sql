@@ -0,0 +1,18 @@
+CREATE TABLE "currencies" (
+  "code" varchar PRIMARY KEY,
+  -- 最小货币单位的小数位数，例如 USD 为 2，JPY 为 0
+  "exponent" int NOT NULL,
+  -- 停用的货币不能再开户，已有账户不受影响
+  "enabled" boolean NOT NULL DEFAULT true,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "currencies" ADD CONSTRAINT "exponent_check" CHECK ("exponent" BETWEEN 0 AND 4);
+
+INSERT INTO "currencies" ("code", "exponent", "enabled") VALUES
+  ('USD', 2, true),
+  ('EUR', 2, true),
+  ('GBP', 2, false),
+  ('CAD', 2, false),
+  ('JPY', 0, false),
+  ('KWD', 3, false);
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS "interest_accruals";
+
+DELETE FROM "entries" WHERE "account_id" IN (
+  SELECT "id" FROM "accounts" WHERE "owner" = 'system_interest'
+);
+
+DELETE FROM "accounts" WHERE "owner" = 'system_interest';
+
+DELETE FROM "users" WHERE "username" = 'system_interest';
@@ -0,0 +1,26 @@
+CREATE TABLE "interest_accruals" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "accrual_date" date NOT NULL,
+  -- 计息日日终余额
+  "balance" bigint NOT NULL,
+  -- 年利率，例如 0.02 表示 2%
+  "rate" double precision NOT NULL,
+  -- 当日利息，单位为百万分之一个最小货币单位
+  "amount_micros" bigint NOT NULL,
+  -- 入账的凭证，为空表示尚未入账
+  "journal_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "interest_accruals" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "interest_accruals" ADD FOREIGN KEY ("journal_id") REFERENCES "journals" ("id");
+
+ALTER TABLE "interest_accruals" ADD CONSTRAINT "account_date_key" UNIQUE ("account_id", "accrual_date");
+
+CREATE INDEX ON "interest_accruals" ("journal_id", "accrual_date");
+
+-- 利息支出账户的所有者
+INSERT INTO "users" ("username", "hashed_password", "full_name", "email")
+VALUES ('system_interest', '', 'Bank Interest Expense', 'interest@system.bank');
@@ -0,0 +1,19 @@
+-- 事务性发件箱：与账户、转账的变更在同一事务中写入，由 relay 异步发布
+CREATE TABLE "events" (
+  "id" bigserial PRIMARY KEY,
+  -- 同一账户的事件按 id 顺序发布
+  "account_id" bigint NOT NULL,
+  "type" varchar NOT NULL,
+  "payload" jsonb NOT NULL,
+  "attempts" int NOT NULL DEFAULT 0,
+  "last_error" varchar NOT NULL DEFAULT '',
+  -- 为空表示尚未发布
+  "published_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "events" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+CREATE INDEX ON "events" ("id") WHERE "published_at" IS NULL;
+
+CREATE INDEX ON "events" ("account_id");
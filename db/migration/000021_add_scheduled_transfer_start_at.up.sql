@@ -0,0 +1,6 @@
+-- 每月执行的定时转账按首期的日期计算执行日，已有数据以下一次执行时间为准
+ALTER TABLE "scheduled_transfers" ADD COLUMN "start_at" timestamptz;
+
+UPDATE "scheduled_transfers" SET "start_at" = "next_run_at";
+
+ALTER TABLE "scheduled_transfers" ALTER COLUMN "start_at" SET NOT NULL;
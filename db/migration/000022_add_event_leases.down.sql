@@ -0,0 +1 @@
+ALTER TABLE IF EXISTS "events" DROP COLUMN IF EXISTS "locked_until";
@@ -0,0 +1,2 @@
+-- relay 领取事件后在 locked_until 之前不会被再次领取，发布时不再持有事务和行锁
+ALTER TABLE "events" ADD COLUMN "locked_until" timestamptz NOT NULL DEFAULT (now());
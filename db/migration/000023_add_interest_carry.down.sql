@@ -0,0 +1,6 @@
+DELETE FROM "interest_accruals" WHERE "carried";
+
+DROP INDEX IF EXISTS "account_date_key";
+ALTER TABLE IF EXISTS "interest_accruals" ADD CONSTRAINT "account_date_key" UNIQUE ("account_id", "accrual_date");
+
+ALTER TABLE IF EXISTS "interest_accruals" DROP COLUMN IF EXISTS "carried";
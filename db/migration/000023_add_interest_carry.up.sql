@@ -0,0 +1,5 @@
+-- 入账时不足一个最小货币单位的利息记为一条结转计提，下次入账时合并，结转计提不受每日一条的限制
+ALTER TABLE "interest_accruals" ADD COLUMN "carried" boolean NOT NULL DEFAULT false;
+
+ALTER TABLE "interest_accruals" DROP CONSTRAINT IF EXISTS "account_date_key";
+CREATE UNIQUE INDEX "account_date_key" ON "interest_accruals" ("account_id", "accrual_date") WHERE NOT "carried";
@@ -0,0 +1 @@
+ALTER TABLE IF EXISTS "scheduled_transfers" DROP COLUMN IF EXISTS "currency";
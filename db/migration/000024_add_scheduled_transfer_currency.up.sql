@@ -0,0 +1,8 @@
+-- 定时转账金额的货币，与转出账户相同，已有数据从转出账户回填
+ALTER TABLE "scheduled_transfers" ADD COLUMN "currency" varchar;
+
+UPDATE "scheduled_transfers" s SET "currency" = a."currency"
+FROM "accounts" a
+WHERE a."id" = s."from_account_id";
+
+ALTER TABLE "scheduled_transfers" ALTER COLUMN "currency" SET NOT NULL;
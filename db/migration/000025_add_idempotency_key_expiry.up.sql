@@ -0,0 +1,2 @@
+-- 过期的幂等 key 按 created_at 定期清理
+CREATE INDEX "idempotency_keys_created_at_idx" ON "idempotency_keys" ("created_at");
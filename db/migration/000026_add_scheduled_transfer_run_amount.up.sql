@@ -0,0 +1,13 @@
+-- 执行记录保存当次的转账金额，定时转账的金额之后可能被修改；
+-- 已有数据优先从转账回填，没有转账的按定时转账当前金额回填
+ALTER TABLE "scheduled_transfer_runs" ADD COLUMN "amount" bigint;
+
+UPDATE "scheduled_transfer_runs" r SET "amount" = s."amount"
+FROM "scheduled_transfers" s
+WHERE s."id" = r."scheduled_transfer_id";
+
+UPDATE "scheduled_transfer_runs" r SET "amount" = t."amount"
+FROM "transfers" t
+WHERE t."id" = r."transfer_id";
+
+ALTER TABLE "scheduled_transfer_runs" ALTER COLUMN "amount" SET NOT NULL;
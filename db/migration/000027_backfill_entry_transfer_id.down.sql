@@ -0,0 +1 @@
+UPDATE "entries" SET "transfer_id" = NULL WHERE "journal_id" IS NULL;
@@ -0,0 +1,11 @@
+-- 为加入 journals 之前的转账分录回填 transfer_id：这些分录与转账在同一事务中写入，created_at 相同。
+-- 同一时刻有多笔转账时无法区分，保持为空，由对账报告出来人工处理
+UPDATE "entries" e SET "transfer_id" = t."id"
+FROM "transfers" t
+WHERE e."transfer_id" IS NULL
+  AND e."journal_id" IS NULL
+  AND e."created_at" = t."created_at"
+  AND NOT EXISTS (
+    SELECT 1 FROM "transfers" o
+    WHERE o."created_at" = t."created_at" AND o."id" <> t."id"
+  );
@@ -6,9 +6,12 @@ package mock_sqlc
 
 import (
     context "context"
+    sql "database/sql"
     reflect "reflect"
+    time "time"
 
     gomock "github.com/golang/mock/gomock"
+    uuid "github.com/google/uuid"
     db "github.com/xiusl/bank/db/sqlc"
 )
 
@@ -50,6 +53,95 @@ func (mr *MockStoreMockRecorder) AddAccountBalance(arg0, arg1 interface{}) *gomo
     return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAccountBalance", reflect.TypeOf((*MockStore)(nil).AddAccountBalance), arg0, arg1)
 }
 
+// BlockSession mocks base method.
+func (m *MockStore) BlockSession(arg0 context.Context, arg1 uuid.UUID) (db.Session, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "BlockSession", arg0, arg1)
+    ret0, _ := ret[0].(db.Session)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// BlockSession indicates an expected call of BlockSession.
+func (mr *MockStoreMockRecorder) BlockSession(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockSession", reflect.TypeOf((*MockStore)(nil).BlockSession), arg0, arg1)
+}
+
+// BlockUserSessions mocks base method.
+func (m *MockStore) BlockUserSessions(arg0 context.Context, arg1 string) error {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "BlockUserSessions", arg0, arg1)
+    ret0, _ := ret[0].(error)
+    return ret0
+}
+
+// BlockUserSessions indicates an expected call of BlockUserSessions.
+func (mr *MockStoreMockRecorder) BlockUserSessions(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockUserSessions", reflect.TypeOf((*MockStore)(nil).BlockUserSessions), arg0, arg1)
+}
+
+// CaptureHoldTx mocks base method.
+func (m *MockStore) CaptureHoldTx(arg0 context.Context, arg1 db.CaptureHoldTxParams) (db.CaptureHoldTxResult, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "CaptureHoldTx", arg0, arg1)
+    ret0, _ := ret[0].(db.CaptureHoldTxResult)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// CaptureHoldTx indicates an expected call of CaptureHoldTx.
+func (mr *MockStoreMockRecorder) CaptureHoldTx(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CaptureHoldTx", reflect.TypeOf((*MockStore)(nil).CaptureHoldTx), arg0, arg1)
+}
+
+// ClaimDueScheduledTransfers mocks base method.
+func (m *MockStore) ClaimDueScheduledTransfers(arg0 context.Context, arg1 db.ClaimDueScheduledTransfersParams) ([]db.ScheduledTransfer, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "ClaimDueScheduledTransfers", arg0, arg1)
+    ret0, _ := ret[0].([]db.ScheduledTransfer)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// ClaimDueScheduledTransfers indicates an expected call of ClaimDueScheduledTransfers.
+func (mr *MockStoreMockRecorder) ClaimDueScheduledTransfers(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDueScheduledTransfers", reflect.TypeOf((*MockStore)(nil).ClaimDueScheduledTransfers), arg0, arg1)
+}
+
+// ClaimDueWebhookDeliveries mocks base method.
+func (m *MockStore) ClaimDueWebhookDeliveries(arg0 context.Context, arg1 db.ClaimDueWebhookDeliveriesParams) ([]db.WebhookDelivery, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "ClaimDueWebhookDeliveries", arg0, arg1)
+    ret0, _ := ret[0].([]db.WebhookDelivery)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// ClaimDueWebhookDeliveries indicates an expected call of ClaimDueWebhookDeliveries.
+func (mr *MockStoreMockRecorder) ClaimDueWebhookDeliveries(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDueWebhookDeliveries", reflect.TypeOf((*MockStore)(nil).ClaimDueWebhookDeliveries), arg0, arg1)
+}
+
+// ClaimUnpublishedEvents mocks base method.
+func (m *MockStore) ClaimUnpublishedEvents(arg0 context.Context, arg1 db.ClaimUnpublishedEventsParams) ([]db.Event, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "ClaimUnpublishedEvents", arg0, arg1)
+    ret0, _ := ret[0].([]db.Event)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// ClaimUnpublishedEvents indicates an expected call of ClaimUnpublishedEvents.
+func (mr *MockStoreMockRecorder) ClaimUnpublishedEvents(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimUnpublishedEvents", reflect.TypeOf((*MockStore)(nil).ClaimUnpublishedEvents), arg0, arg1)
+}
+
 // CreateAccount mocks base method.
 func (m *MockStore) CreateAccount(arg0 context.Context, arg1 db.CreateAccountParams) (db.Account, error) {
     m.ctrl.T.Helper()
@@ -65,6 +157,21 @@ func (mr *MockStoreMockRecorder) CreateAccount(arg0, arg1 interface{}) *gomock.C
     return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccount", reflect.TypeOf((*MockStore)(nil).CreateAccount), arg0, arg1)
 }
 
+// CreateAccountTx mocks base method.
+func (m *MockStore) CreateAccountTx(arg0 context.Context, arg1 db.CreateAccountParams) (db.Account, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "CreateAccountTx", arg0, arg1)
+    ret0, _ := ret[0].(db.Account)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// CreateAccountTx indicates an expected call of CreateAccountTx.
+func (mr *MockStoreMockRecorder) CreateAccountTx(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccountTx", reflect.TypeOf((*MockStore)(nil).CreateAccountTx), arg0, arg1)
+}
+
 // CreateEntry mocks base method.
 func (m *MockStore) CreateEntry(arg0 context.Context, arg1 db.CreateEntryParams) (db.Entry, error) {
     m.ctrl.T.Helper()
@@ -80,6 +187,169 @@ func (mr *MockStoreMockRecorder) CreateEntry(arg0, arg1 interface{}) *gomock.Cal
     return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEntry", reflect.TypeOf((*MockStore)(nil).CreateEntry), arg0, arg1)
 }
 
+// CreateEvent mocks base method.
+func (m *MockStore) CreateEvent(arg0 context.Context, arg1 db.CreateEventParams) (db.Event, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "CreateEvent", arg0, arg1)
+    ret0, _ := ret[0].(db.Event)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// CreateEvent indicates an expected call of CreateEvent.
+func (mr *MockStoreMockRecorder) CreateEvent(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEvent", reflect.TypeOf((*MockStore)(nil).CreateEvent), arg0, arg1)
+}
+
+// CreateHold mocks base method.
+func (m *MockStore) CreateHold(arg0 context.Context, arg1 db.CreateHoldParams) (db.Hold, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "CreateHold", arg0, arg1)
+    ret0, _ := ret[0].(db.Hold)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// CreateHold indicates an expected call of CreateHold.
+func (mr *MockStoreMockRecorder) CreateHold(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHold", reflect.TypeOf((*MockStore)(nil).CreateHold), arg0, arg1)
+}
+
+// CreateIdempotencyKey mocks base method.
+func (m *MockStore) CreateIdempotencyKey(arg0 context.Context, arg1 db.CreateIdempotencyKeyParams) (db.IdempotencyKey, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "CreateIdempotencyKey", arg0, arg1)
+    ret0, _ := ret[0].(db.IdempotencyKey)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// CreateIdempotencyKey indicates an expected call of CreateIdempotencyKey.
+func (mr *MockStoreMockRecorder) CreateIdempotencyKey(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIdempotencyKey", reflect.TypeOf((*MockStore)(nil).CreateIdempotencyKey), arg0, arg1)
+}
+
+// CreateInterestAccrual mocks base method.
+func (m *MockStore) CreateInterestAccrual(arg0 context.Context, arg1 db.CreateInterestAccrualParams) (db.InterestAccrual, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "CreateInterestAccrual", arg0, arg1)
+    ret0, _ := ret[0].(db.InterestAccrual)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// CreateInterestAccrual indicates an expected call of CreateInterestAccrual.
+func (mr *MockStoreMockRecorder) CreateInterestAccrual(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInterestAccrual", reflect.TypeOf((*MockStore)(nil).CreateInterestAccrual), arg0, arg1)
+}
+
+// CreateInterestCarry mocks base method.
+func (m *MockStore) CreateInterestCarry(arg0 context.Context, arg1 db.CreateInterestCarryParams) (db.InterestAccrual, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "CreateInterestCarry", arg0, arg1)
+    ret0, _ := ret[0].(db.InterestAccrual)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// CreateInterestCarry indicates an expected call of CreateInterestCarry.
+func (mr *MockStoreMockRecorder) CreateInterestCarry(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInterestCarry", reflect.TypeOf((*MockStore)(nil).CreateInterestCarry), arg0, arg1)
+}
+
+// CreateJournal mocks base method.
+func (m *MockStore) CreateJournal(arg0 context.Context, arg1 string) (db.Journal, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "CreateJournal", arg0, arg1)
+    ret0, _ := ret[0].(db.Journal)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// CreateJournal indicates an expected call of CreateJournal.
+func (mr *MockStoreMockRecorder) CreateJournal(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateJournal", reflect.TypeOf((*MockStore)(nil).CreateJournal), arg0, arg1)
+}
+
+// CreateRevokedToken mocks base method.
+func (m *MockStore) CreateRevokedToken(arg0 context.Context, arg1 db.CreateRevokedTokenParams) error {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "CreateRevokedToken", arg0, arg1)
+    ret0, _ := ret[0].(error)
+    return ret0
+}
+
+// CreateRevokedToken indicates an expected call of CreateRevokedToken.
+func (mr *MockStoreMockRecorder) CreateRevokedToken(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRevokedToken", reflect.TypeOf((*MockStore)(nil).CreateRevokedToken), arg0, arg1)
+}
+
+// CreateScheduledTransfer mocks base method.
+func (m *MockStore) CreateScheduledTransfer(arg0 context.Context, arg1 db.CreateScheduledTransferParams) (db.ScheduledTransfer, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "CreateScheduledTransfer", arg0, arg1)
+    ret0, _ := ret[0].(db.ScheduledTransfer)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// CreateScheduledTransfer indicates an expected call of CreateScheduledTransfer.
+func (mr *MockStoreMockRecorder) CreateScheduledTransfer(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateScheduledTransfer", reflect.TypeOf((*MockStore)(nil).CreateScheduledTransfer), arg0, arg1)
+}
+
+// CreateScheduledTransferRun mocks base method.
+func (m *MockStore) CreateScheduledTransferRun(arg0 context.Context, arg1 db.CreateScheduledTransferRunParams) (db.ScheduledTransferRun, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "CreateScheduledTransferRun", arg0, arg1)
+    ret0, _ := ret[0].(db.ScheduledTransferRun)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// CreateScheduledTransferRun indicates an expected call of CreateScheduledTransferRun.
+func (mr *MockStoreMockRecorder) CreateScheduledTransferRun(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateScheduledTransferRun", reflect.TypeOf((*MockStore)(nil).CreateScheduledTransferRun), arg0, arg1)
+}
+
+// CreateSession mocks base method.
+func (m *MockStore) CreateSession(arg0 context.Context, arg1 db.CreateSessionParams) (db.Session, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "CreateSession", arg0, arg1)
+    ret0, _ := ret[0].(db.Session)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// CreateSession indicates an expected call of CreateSession.
+func (mr *MockStoreMockRecorder) CreateSession(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockStore)(nil).CreateSession), arg0, arg1)
+}
+
+// CreateSystemAccount mocks base method.
+func (m *MockStore) CreateSystemAccount(arg0 context.Context, arg1 db.CreateSystemAccountParams) error {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "CreateSystemAccount", arg0, arg1)
+    ret0, _ := ret[0].(error)
+    return ret0
+}
+
+// CreateSystemAccount indicates an expected call of CreateSystemAccount.
+func (mr *MockStoreMockRecorder) CreateSystemAccount(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSystemAccount", reflect.TypeOf((*MockStore)(nil).CreateSystemAccount), arg0, arg1)
+}
+
 // CreateTransfer mocks base method.
 func (m *MockStore) CreateTransfer(arg0 context.Context, arg1 db.CreateTransferParams) (db.Transfer, error) {
     m.ctrl.T.Helper()
@@ -95,6 +365,21 @@ func (mr *MockStoreMockRecorder) CreateTransfer(arg0, arg1 interface{}) *gomock.
     return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransfer", reflect.TypeOf((*MockStore)(nil).CreateTransfer), arg0, arg1)
 }
 
+// CreateTransferReversal mocks base method.
+func (m *MockStore) CreateTransferReversal(arg0 context.Context, arg1 db.CreateTransferReversalParams) (db.TransferReversal, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "CreateTransferReversal", arg0, arg1)
+    ret0, _ := ret[0].(db.TransferReversal)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// CreateTransferReversal indicates an expected call of CreateTransferReversal.
+func (mr *MockStoreMockRecorder) CreateTransferReversal(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransferReversal", reflect.TypeOf((*MockStore)(nil).CreateTransferReversal), arg0, arg1)
+}
+
 // CreateUser mocks base method.
 func (m *MockStore) CreateUser(arg0 context.Context, arg1 db.CreateUserParams) (db.User, error) {
     m.ctrl.T.Helper()
@@ -110,166 +395,1220 @@ func (mr *MockStoreMockRecorder) CreateUser(arg0, arg1 interface{}) *gomock.Call
     return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockStore)(nil).CreateUser), arg0, arg1)
 }
 
-// DeleteAccount mocks base method.
-func (m *MockStore) DeleteAccount(arg0 context.Context, arg1 int64) error {
+// CreateWebhookDeliveries mocks base method.
+func (m *MockStore) CreateWebhookDeliveries(arg0 context.Context, arg1 int64) error {
     m.ctrl.T.Helper()
-    ret := m.ctrl.Call(m, "DeleteAccount", arg0, arg1)
+    ret := m.ctrl.Call(m, "CreateWebhookDeliveries", arg0, arg1)
     ret0, _ := ret[0].(error)
     return ret0
 }
 
-// DeleteAccount indicates an expected call of DeleteAccount.
-func (mr *MockStoreMockRecorder) DeleteAccount(arg0, arg1 interface{}) *gomock.Call {
+// CreateWebhookDeliveries indicates an expected call of CreateWebhookDeliveries.
+func (mr *MockStoreMockRecorder) CreateWebhookDeliveries(arg0, arg1 interface{}) *gomock.Call {
     mr.mock.ctrl.T.Helper()
-    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockStore)(nil).DeleteAccount), arg0, arg1)
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhookDeliveries", reflect.TypeOf((*MockStore)(nil).CreateWebhookDeliveries), arg0, arg1)
 }
 
-// GetAccount mocks base method.
-func (m *MockStore) GetAccount(arg0 context.Context, arg1 int64) (db.Account, error) {
+// CreateWebhookEndpoint mocks base method.
+func (m *MockStore) CreateWebhookEndpoint(arg0 context.Context, arg1 db.CreateWebhookEndpointParams) (db.WebhookEndpoint, error) {
     m.ctrl.T.Helper()
-    ret := m.ctrl.Call(m, "GetAccount", arg0, arg1)
-    ret0, _ := ret[0].(db.Account)
+    ret := m.ctrl.Call(m, "CreateWebhookEndpoint", arg0, arg1)
+    ret0, _ := ret[0].(db.WebhookEndpoint)
     ret1, _ := ret[1].(error)
     return ret0, ret1
 }
 
-// GetAccount indicates an expected call of GetAccount.
-func (mr *MockStoreMockRecorder) GetAccount(arg0, arg1 interface{}) *gomock.Call {
+// CreateWebhookEndpoint indicates an expected call of CreateWebhookEndpoint.
+func (mr *MockStoreMockRecorder) CreateWebhookEndpoint(arg0, arg1 interface{}) *gomock.Call {
     mr.mock.ctrl.T.Helper()
-    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockStore)(nil).GetAccount), arg0, arg1)
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhookEndpoint", reflect.TypeOf((*MockStore)(nil).CreateWebhookEndpoint), arg0, arg1)
 }
 
-// GetAccountForUpdate mocks base method.
-func (m *MockStore) GetAccountForUpdate(arg0 context.Context, arg1 int64) (db.Account, error) {
+// DeactivateWebhookEndpoint mocks base method.
+func (m *MockStore) DeactivateWebhookEndpoint(arg0 context.Context, arg1 int64) (db.WebhookEndpoint, error) {
     m.ctrl.T.Helper()
-    ret := m.ctrl.Call(m, "GetAccountForUpdate", arg0, arg1)
-    ret0, _ := ret[0].(db.Account)
+    ret := m.ctrl.Call(m, "DeactivateWebhookEndpoint", arg0, arg1)
+    ret0, _ := ret[0].(db.WebhookEndpoint)
     ret1, _ := ret[1].(error)
     return ret0, ret1
 }
 
-// GetAccountForUpdate indicates an expected call of GetAccountForUpdate.
-func (mr *MockStoreMockRecorder) GetAccountForUpdate(arg0, arg1 interface{}) *gomock.Call {
+// DeactivateWebhookEndpoint indicates an expected call of DeactivateWebhookEndpoint.
+func (mr *MockStoreMockRecorder) DeactivateWebhookEndpoint(arg0, arg1 interface{}) *gomock.Call {
     mr.mock.ctrl.T.Helper()
-    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountForUpdate", reflect.TypeOf((*MockStore)(nil).GetAccountForUpdate), arg0, arg1)
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateWebhookEndpoint", reflect.TypeOf((*MockStore)(nil).DeactivateWebhookEndpoint), arg0, arg1)
 }
 
-// GetEntry mocks base method.
-func (m *MockStore) GetEntry(arg0 context.Context, arg1 int64) (db.Entry, error) {
+// DeleteAccount mocks base method.
+func (m *MockStore) DeleteAccount(arg0 context.Context, arg1 int64) error {
     m.ctrl.T.Helper()
-    ret := m.ctrl.Call(m, "GetEntry", arg0, arg1)
-    ret0, _ := ret[0].(db.Entry)
-    ret1, _ := ret[1].(error)
-    return ret0, ret1
+    ret := m.ctrl.Call(m, "DeleteAccount", arg0, arg1)
+    ret0, _ := ret[0].(error)
+    return ret0
 }
 
-// GetEntry indicates an expected call of GetEntry.
-func (mr *MockStoreMockRecorder) GetEntry(arg0, arg1 interface{}) *gomock.Call {
+// DeleteAccount indicates an expected call of DeleteAccount.
+func (mr *MockStoreMockRecorder) DeleteAccount(arg0, arg1 interface{}) *gomock.Call {
     mr.mock.ctrl.T.Helper()
-    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntry", reflect.TypeOf((*MockStore)(nil).GetEntry), arg0, arg1)
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockStore)(nil).DeleteAccount), arg0, arg1)
 }
 
-// GetTransfer mocks base method.
-func (m *MockStore) GetTransfer(arg0 context.Context, arg1 int64) (db.Transfer, error) {
+// DeleteExpiredIdempotencyKeys mocks base method.
+func (m *MockStore) DeleteExpiredIdempotencyKeys(arg0 context.Context, arg1 time.Time) error {
     m.ctrl.T.Helper()
-    ret := m.ctrl.Call(m, "GetTransfer", arg0, arg1)
-    ret0, _ := ret[0].(db.Transfer)
-    ret1, _ := ret[1].(error)
-    return ret0, ret1
+    ret := m.ctrl.Call(m, "DeleteExpiredIdempotencyKeys", arg0, arg1)
+    ret0, _ := ret[0].(error)
+    return ret0
 }
 
-// GetTransfer indicates an expected call of GetTransfer.
-func (mr *MockStoreMockRecorder) GetTransfer(arg0, arg1 interface{}) *gomock.Call {
+// DeleteExpiredIdempotencyKeys indicates an expected call of DeleteExpiredIdempotencyKeys.
+func (mr *MockStoreMockRecorder) DeleteExpiredIdempotencyKeys(arg0, arg1 interface{}) *gomock.Call {
     mr.mock.ctrl.T.Helper()
-    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransfer", reflect.TypeOf((*MockStore)(nil).GetTransfer), arg0, arg1)
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredIdempotencyKeys", reflect.TypeOf((*MockStore)(nil).DeleteExpiredIdempotencyKeys), arg0, arg1)
 }
 
-// GetUser mocks base method.
-func (m *MockStore) GetUser(arg0 context.Context, arg1 string) (db.User, error) {
+// DeleteExpiredRevokedTokens mocks base method.
+func (m *MockStore) DeleteExpiredRevokedTokens(arg0 context.Context) error {
     m.ctrl.T.Helper()
-    ret := m.ctrl.Call(m, "GetUser", arg0, arg1)
-    ret0, _ := ret[0].(db.User)
-    ret1, _ := ret[1].(error)
-    return ret0, ret1
+    ret := m.ctrl.Call(m, "DeleteExpiredRevokedTokens", arg0)
+    ret0, _ := ret[0].(error)
+    return ret0
 }
 
-// GetUser indicates an expected call of GetUser.
-func (mr *MockStoreMockRecorder) GetUser(arg0, arg1 interface{}) *gomock.Call {
+// DeleteExpiredRevokedTokens indicates an expected call of DeleteExpiredRevokedTokens.
+func (mr *MockStoreMockRecorder) DeleteExpiredRevokedTokens(arg0 interface{}) *gomock.Call {
     mr.mock.ctrl.T.Helper()
-    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockStore)(nil).GetUser), arg0, arg1)
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredRevokedTokens", reflect.TypeOf((*MockStore)(nil).DeleteExpiredRevokedTokens), arg0)
 }
 
-// ListAccounts mocks base method.
-func (m *MockStore) ListAccounts(arg0 context.Context, arg1 db.ListAccountsParams) ([]db.Account, error) {
+// DeleteIdempotencyKey mocks base method.
+func (m *MockStore) DeleteIdempotencyKey(arg0 context.Context, arg1 db.DeleteIdempotencyKeyParams) error {
     m.ctrl.T.Helper()
-    ret := m.ctrl.Call(m, "ListAccounts", arg0, arg1)
-    ret0, _ := ret[0].([]db.Account)
-    ret1, _ := ret[1].(error)
-    return ret0, ret1
+    ret := m.ctrl.Call(m, "DeleteIdempotencyKey", arg0, arg1)
+    ret0, _ := ret[0].(error)
+    return ret0
 }
 
-// ListAccounts indicates an expected call of ListAccounts.
-func (mr *MockStoreMockRecorder) ListAccounts(arg0, arg1 interface{}) *gomock.Call {
+// DeleteIdempotencyKey indicates an expected call of DeleteIdempotencyKey.
+func (mr *MockStoreMockRecorder) DeleteIdempotencyKey(arg0, arg1 interface{}) *gomock.Call {
     mr.mock.ctrl.T.Helper()
-    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccounts", reflect.TypeOf((*MockStore)(nil).ListAccounts), arg0, arg1)
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIdempotencyKey", reflect.TypeOf((*MockStore)(nil).DeleteIdempotencyKey), arg0, arg1)
 }
 
-// ListEntries mocks base method.
-func (m *MockStore) ListEntries(arg0 context.Context, arg1 db.ListEntriesParams) ([]db.Entry, error) {
+// DepositTx mocks base method.
+func (m *MockStore) DepositTx(arg0 context.Context, arg1 db.DepositTxParams) (db.DepositTxResult, error) {
     m.ctrl.T.Helper()
-    ret := m.ctrl.Call(m, "ListEntries", arg0, arg1)
-    ret0, _ := ret[0].([]db.Entry)
+    ret := m.ctrl.Call(m, "DepositTx", arg0, arg1)
+    ret0, _ := ret[0].(db.DepositTxResult)
     ret1, _ := ret[1].(error)
     return ret0, ret1
 }
 
-// ListEntries indicates an expected call of ListEntries.
-func (mr *MockStoreMockRecorder) ListEntries(arg0, arg1 interface{}) *gomock.Call {
+// DepositTx indicates an expected call of DepositTx.
+func (mr *MockStoreMockRecorder) DepositTx(arg0, arg1 interface{}) *gomock.Call {
     mr.mock.ctrl.T.Helper()
-    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEntries", reflect.TypeOf((*MockStore)(nil).ListEntries), arg0, arg1)
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DepositTx", reflect.TypeOf((*MockStore)(nil).DepositTx), arg0, arg1)
 }
 
-// ListTransfers mocks base method.
-func (m *MockStore) ListTransfers(arg0 context.Context, arg1 db.ListTransfersParams) ([]db.Transfer, error) {
+// ExecuteScheduledTransferTx mocks base method.
+func (m *MockStore) ExecuteScheduledTransferTx(arg0 context.Context, arg1 db.ExecuteScheduledTransferTxParams) (db.ExecuteScheduledTransferTxResult, error) {
     m.ctrl.T.Helper()
-    ret := m.ctrl.Call(m, "ListTransfers", arg0, arg1)
-    ret0, _ := ret[0].([]db.Transfer)
+    ret := m.ctrl.Call(m, "ExecuteScheduledTransferTx", arg0, arg1)
+    ret0, _ := ret[0].(db.ExecuteScheduledTransferTxResult)
     ret1, _ := ret[1].(error)
     return ret0, ret1
 }
 
-// ListTransfers indicates an expected call of ListTransfers.
-func (mr *MockStoreMockRecorder) ListTransfers(arg0, arg1 interface{}) *gomock.Call {
+// ExecuteScheduledTransferTx indicates an expected call of ExecuteScheduledTransferTx.
+func (mr *MockStoreMockRecorder) ExecuteScheduledTransferTx(arg0, arg1 interface{}) *gomock.Call {
     mr.mock.ctrl.T.Helper()
-    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransfers", reflect.TypeOf((*MockStore)(nil).ListTransfers), arg0, arg1)
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteScheduledTransferTx", reflect.TypeOf((*MockStore)(nil).ExecuteScheduledTransferTx), arg0, arg1)
 }
 
-// TransferTx mocks base method.
-func (m *MockStore) TransferTx(arg0 context.Context, arg1 db.TransferTxParams) (db.TransferTxResult, error) {
+// ExpireHolds mocks base method.
+func (m *MockStore) ExpireHolds(arg0 context.Context) error {
     m.ctrl.T.Helper()
-    ret := m.ctrl.Call(m, "TransferTx", arg0, arg1)
-    ret0, _ := ret[0].(db.TransferTxResult)
-    ret1, _ := ret[1].(error)
-    return ret0, ret1
+    ret := m.ctrl.Call(m, "ExpireHolds", arg0)
+    ret0, _ := ret[0].(error)
+    return ret0
 }
 
-// TransferTx indicates an expected call of TransferTx.
-func (mr *MockStoreMockRecorder) TransferTx(arg0, arg1 interface{}) *gomock.Call {
+// ExpireHolds indicates an expected call of ExpireHolds.
+func (mr *MockStoreMockRecorder) ExpireHolds(arg0 interface{}) *gomock.Call {
     mr.mock.ctrl.T.Helper()
-    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferTx", reflect.TypeOf((*MockStore)(nil).TransferTx), arg0, arg1)
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireHolds", reflect.TypeOf((*MockStore)(nil).ExpireHolds), arg0)
 }
 
-// UpdateAccount mocks base method.
-func (m *MockStore) UpdateAccount(arg0 context.Context, arg1 db.UpdateAccountParams) (db.Account, error) {
+// GetAccount mocks base method.
+func (m *MockStore) GetAccount(arg0 context.Context, arg1 int64) (db.Account, error) {
     m.ctrl.T.Helper()
-    ret := m.ctrl.Call(m, "UpdateAccount", arg0, arg1)
+    ret := m.ctrl.Call(m, "GetAccount", arg0, arg1)
     ret0, _ := ret[0].(db.Account)
     ret1, _ := ret[1].(error)
     return ret0, ret1
 }
 
-// UpdateAccount indicates an expected call of UpdateAccount.
-func (mr *MockStoreMockRecorder) UpdateAccount(arg0, arg1 interface{}) *gomock.Call {
+// GetAccount indicates an expected call of GetAccount.
+func (mr *MockStoreMockRecorder) GetAccount(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockStore)(nil).GetAccount), arg0, arg1)
+}
+
+// GetAccountBalanceAt mocks base method.
+func (m *MockStore) GetAccountBalanceAt(arg0 context.Context, arg1 db.GetAccountBalanceAtParams) (int64, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "GetAccountBalanceAt", arg0, arg1)
+    ret0, _ := ret[0].(int64)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// GetAccountBalanceAt indicates an expected call of GetAccountBalanceAt.
+func (mr *MockStoreMockRecorder) GetAccountBalanceAt(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountBalanceAt", reflect.TypeOf((*MockStore)(nil).GetAccountBalanceAt), arg0, arg1)
+}
+
+// GetAccountForUpdate mocks base method.
+func (m *MockStore) GetAccountForUpdate(arg0 context.Context, arg1 int64) (db.Account, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "GetAccountForUpdate", arg0, arg1)
+    ret0, _ := ret[0].(db.Account)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// GetAccountForUpdate indicates an expected call of GetAccountForUpdate.
+func (mr *MockStoreMockRecorder) GetAccountForUpdate(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountForUpdate", reflect.TypeOf((*MockStore)(nil).GetAccountForUpdate), arg0, arg1)
+}
+
+// GetCurrency mocks base method.
+func (m *MockStore) GetCurrency(arg0 context.Context, arg1 string) (db.Currency, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "GetCurrency", arg0, arg1)
+    ret0, _ := ret[0].(db.Currency)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// GetCurrency indicates an expected call of GetCurrency.
+func (mr *MockStoreMockRecorder) GetCurrency(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrency", reflect.TypeOf((*MockStore)(nil).GetCurrency), arg0, arg1)
+}
+
+// GetEntry mocks base method.
+func (m *MockStore) GetEntry(arg0 context.Context, arg1 int64) (db.Entry, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "GetEntry", arg0, arg1)
+    ret0, _ := ret[0].(db.Entry)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// GetEntry indicates an expected call of GetEntry.
+func (mr *MockStoreMockRecorder) GetEntry(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntry", reflect.TypeOf((*MockStore)(nil).GetEntry), arg0, arg1)
+}
+
+// GetEvent mocks base method.
+func (m *MockStore) GetEvent(arg0 context.Context, arg1 int64) (db.Event, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "GetEvent", arg0, arg1)
+    ret0, _ := ret[0].(db.Event)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// GetEvent indicates an expected call of GetEvent.
+func (mr *MockStoreMockRecorder) GetEvent(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEvent", reflect.TypeOf((*MockStore)(nil).GetEvent), arg0, arg1)
+}
+
+// GetHeldAmount mocks base method.
+func (m *MockStore) GetHeldAmount(arg0 context.Context, arg1 int64) (int64, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "GetHeldAmount", arg0, arg1)
+    ret0, _ := ret[0].(int64)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// GetHeldAmount indicates an expected call of GetHeldAmount.
+func (mr *MockStoreMockRecorder) GetHeldAmount(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHeldAmount", reflect.TypeOf((*MockStore)(nil).GetHeldAmount), arg0, arg1)
+}
+
+// GetHold mocks base method.
+func (m *MockStore) GetHold(arg0 context.Context, arg1 int64) (db.Hold, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "GetHold", arg0, arg1)
+    ret0, _ := ret[0].(db.Hold)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// GetHold indicates an expected call of GetHold.
+func (mr *MockStoreMockRecorder) GetHold(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHold", reflect.TypeOf((*MockStore)(nil).GetHold), arg0, arg1)
+}
+
+// GetHoldForUpdate mocks base method.
+func (m *MockStore) GetHoldForUpdate(arg0 context.Context, arg1 int64) (db.Hold, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "GetHoldForUpdate", arg0, arg1)
+    ret0, _ := ret[0].(db.Hold)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// GetHoldForUpdate indicates an expected call of GetHoldForUpdate.
+func (mr *MockStoreMockRecorder) GetHoldForUpdate(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHoldForUpdate", reflect.TypeOf((*MockStore)(nil).GetHoldForUpdate), arg0, arg1)
+}
+
+// GetIdempotencyKey mocks base method.
+func (m *MockStore) GetIdempotencyKey(arg0 context.Context, arg1 db.GetIdempotencyKeyParams) (db.IdempotencyKey, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "GetIdempotencyKey", arg0, arg1)
+    ret0, _ := ret[0].(db.IdempotencyKey)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// GetIdempotencyKey indicates an expected call of GetIdempotencyKey.
+func (mr *MockStoreMockRecorder) GetIdempotencyKey(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIdempotencyKey", reflect.TypeOf((*MockStore)(nil).GetIdempotencyKey), arg0, arg1)
+}
+
+// GetJournal mocks base method.
+func (m *MockStore) GetJournal(arg0 context.Context, arg1 int64) (db.Journal, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "GetJournal", arg0, arg1)
+    ret0, _ := ret[0].(db.Journal)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// GetJournal indicates an expected call of GetJournal.
+func (mr *MockStoreMockRecorder) GetJournal(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJournal", reflect.TypeOf((*MockStore)(nil).GetJournal), arg0, arg1)
+}
+
+// GetReversedAmount mocks base method.
+func (m *MockStore) GetReversedAmount(arg0 context.Context, arg1 int64) (int64, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "GetReversedAmount", arg0, arg1)
+    ret0, _ := ret[0].(int64)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// GetReversedAmount indicates an expected call of GetReversedAmount.
+func (mr *MockStoreMockRecorder) GetReversedAmount(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReversedAmount", reflect.TypeOf((*MockStore)(nil).GetReversedAmount), arg0, arg1)
+}
+
+// GetScheduledTransfer mocks base method.
+func (m *MockStore) GetScheduledTransfer(arg0 context.Context, arg1 int64) (db.ScheduledTransfer, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "GetScheduledTransfer", arg0, arg1)
+    ret0, _ := ret[0].(db.ScheduledTransfer)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// GetScheduledTransfer indicates an expected call of GetScheduledTransfer.
+func (mr *MockStoreMockRecorder) GetScheduledTransfer(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScheduledTransfer", reflect.TypeOf((*MockStore)(nil).GetScheduledTransfer), arg0, arg1)
+}
+
+// GetScheduledTransferForUpdate mocks base method.
+func (m *MockStore) GetScheduledTransferForUpdate(arg0 context.Context, arg1 int64) (db.ScheduledTransfer, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "GetScheduledTransferForUpdate", arg0, arg1)
+    ret0, _ := ret[0].(db.ScheduledTransfer)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// GetScheduledTransferForUpdate indicates an expected call of GetScheduledTransferForUpdate.
+func (mr *MockStoreMockRecorder) GetScheduledTransferForUpdate(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScheduledTransferForUpdate", reflect.TypeOf((*MockStore)(nil).GetScheduledTransferForUpdate), arg0, arg1)
+}
+
+// GetSession mocks base method.
+func (m *MockStore) GetSession(arg0 context.Context, arg1 uuid.UUID) (db.Session, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "GetSession", arg0, arg1)
+    ret0, _ := ret[0].(db.Session)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// GetSession indicates an expected call of GetSession.
+func (mr *MockStoreMockRecorder) GetSession(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockStore)(nil).GetSession), arg0, arg1)
+}
+
+// GetSystemAccount mocks base method.
+func (m *MockStore) GetSystemAccount(arg0 context.Context, arg1 db.GetSystemAccountParams) (db.Account, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "GetSystemAccount", arg0, arg1)
+    ret0, _ := ret[0].(db.Account)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// GetSystemAccount indicates an expected call of GetSystemAccount.
+func (mr *MockStoreMockRecorder) GetSystemAccount(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSystemAccount", reflect.TypeOf((*MockStore)(nil).GetSystemAccount), arg0, arg1)
+}
+
+// GetTransfer mocks base method.
+func (m *MockStore) GetTransfer(arg0 context.Context, arg1 int64) (db.Transfer, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "GetTransfer", arg0, arg1)
+    ret0, _ := ret[0].(db.Transfer)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// GetTransfer indicates an expected call of GetTransfer.
+func (mr *MockStoreMockRecorder) GetTransfer(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransfer", reflect.TypeOf((*MockStore)(nil).GetTransfer), arg0, arg1)
+}
+
+// GetTransferForUpdate mocks base method.
+func (m *MockStore) GetTransferForUpdate(arg0 context.Context, arg1 int64) (db.Transfer, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "GetTransferForUpdate", arg0, arg1)
+    ret0, _ := ret[0].(db.Transfer)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// GetTransferForUpdate indicates an expected call of GetTransferForUpdate.
+func (mr *MockStoreMockRecorder) GetTransferForUpdate(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransferForUpdate", reflect.TypeOf((*MockStore)(nil).GetTransferForUpdate), arg0, arg1)
+}
+
+// GetUser mocks base method.
+func (m *MockStore) GetUser(arg0 context.Context, arg1 string) (db.User, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "GetUser", arg0, arg1)
+    ret0, _ := ret[0].(db.User)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockStoreMockRecorder) GetUser(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockStore)(nil).GetUser), arg0, arg1)
+}
+
+// GetUserTokenRevocation mocks base method.
+func (m *MockStore) GetUserTokenRevocation(arg0 context.Context, arg1 string) (db.UserTokenRevocation, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "GetUserTokenRevocation", arg0, arg1)
+    ret0, _ := ret[0].(db.UserTokenRevocation)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// GetUserTokenRevocation indicates an expected call of GetUserTokenRevocation.
+func (mr *MockStoreMockRecorder) GetUserTokenRevocation(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTokenRevocation", reflect.TypeOf((*MockStore)(nil).GetUserTokenRevocation), arg0, arg1)
+}
+
+// GetWebhookDelivery mocks base method.
+func (m *MockStore) GetWebhookDelivery(arg0 context.Context, arg1 int64) (db.WebhookDelivery, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "GetWebhookDelivery", arg0, arg1)
+    ret0, _ := ret[0].(db.WebhookDelivery)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// GetWebhookDelivery indicates an expected call of GetWebhookDelivery.
+func (mr *MockStoreMockRecorder) GetWebhookDelivery(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookDelivery", reflect.TypeOf((*MockStore)(nil).GetWebhookDelivery), arg0, arg1)
+}
+
+// GetWebhookEndpoint mocks base method.
+func (m *MockStore) GetWebhookEndpoint(arg0 context.Context, arg1 int64) (db.WebhookEndpoint, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "GetWebhookEndpoint", arg0, arg1)
+    ret0, _ := ret[0].(db.WebhookEndpoint)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// GetWebhookEndpoint indicates an expected call of GetWebhookEndpoint.
+func (mr *MockStoreMockRecorder) GetWebhookEndpoint(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookEndpoint", reflect.TypeOf((*MockStore)(nil).GetWebhookEndpoint), arg0, arg1)
+}
+
+// IsReversalTransfer mocks base method.
+func (m *MockStore) IsReversalTransfer(arg0 context.Context, arg1 int64) (bool, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "IsReversalTransfer", arg0, arg1)
+    ret0, _ := ret[0].(bool)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// IsReversalTransfer indicates an expected call of IsReversalTransfer.
+func (mr *MockStoreMockRecorder) IsReversalTransfer(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsReversalTransfer", reflect.TypeOf((*MockStore)(nil).IsReversalTransfer), arg0, arg1)
+}
+
+// IsTokenRevoked mocks base method.
+func (m *MockStore) IsTokenRevoked(arg0 context.Context, arg1 uuid.UUID) (bool, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "IsTokenRevoked", arg0, arg1)
+    ret0, _ := ret[0].(bool)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// IsTokenRevoked indicates an expected call of IsTokenRevoked.
+func (mr *MockStoreMockRecorder) IsTokenRevoked(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsTokenRevoked", reflect.TypeOf((*MockStore)(nil).IsTokenRevoked), arg0, arg1)
+}
+
+// ListAccountBalanceDrift mocks base method.
+func (m *MockStore) ListAccountBalanceDrift(arg0 context.Context) ([]db.ListAccountBalanceDriftRow, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "ListAccountBalanceDrift", arg0)
+    ret0, _ := ret[0].([]db.ListAccountBalanceDriftRow)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// ListAccountBalanceDrift indicates an expected call of ListAccountBalanceDrift.
+func (mr *MockStoreMockRecorder) ListAccountBalanceDrift(arg0 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountBalanceDrift", reflect.TypeOf((*MockStore)(nil).ListAccountBalanceDrift), arg0)
+}
+
+// ListAccountEntries mocks base method.
+func (m *MockStore) ListAccountEntries(arg0 context.Context, arg1 db.ListAccountEntriesParams) ([]db.Entry, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "ListAccountEntries", arg0, arg1)
+    ret0, _ := ret[0].([]db.Entry)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// ListAccountEntries indicates an expected call of ListAccountEntries.
+func (mr *MockStoreMockRecorder) ListAccountEntries(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountEntries", reflect.TypeOf((*MockStore)(nil).ListAccountEntries), arg0, arg1)
+}
+
+// ListAccountEvents mocks base method.
+func (m *MockStore) ListAccountEvents(arg0 context.Context, arg1 db.ListAccountEventsParams) ([]db.Event, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "ListAccountEvents", arg0, arg1)
+    ret0, _ := ret[0].([]db.Event)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// ListAccountEvents indicates an expected call of ListAccountEvents.
+func (mr *MockStoreMockRecorder) ListAccountEvents(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountEvents", reflect.TypeOf((*MockStore)(nil).ListAccountEvents), arg0, arg1)
+}
+
+// ListAccountTransfers mocks base method.
+func (m *MockStore) ListAccountTransfers(arg0 context.Context, arg1 db.ListAccountTransfersParams) ([]db.ListAccountTransfersRow, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "ListAccountTransfers", arg0, arg1)
+    ret0, _ := ret[0].([]db.ListAccountTransfersRow)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// ListAccountTransfers indicates an expected call of ListAccountTransfers.
+func (mr *MockStoreMockRecorder) ListAccountTransfers(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountTransfers", reflect.TypeOf((*MockStore)(nil).ListAccountTransfers), arg0, arg1)
+}
+
+// ListAccounts mocks base method.
+func (m *MockStore) ListAccounts(arg0 context.Context, arg1 db.ListAccountsParams) ([]db.Account, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "ListAccounts", arg0, arg1)
+    ret0, _ := ret[0].([]db.Account)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// ListAccounts indicates an expected call of ListAccounts.
+func (mr *MockStoreMockRecorder) ListAccounts(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccounts", reflect.TypeOf((*MockStore)(nil).ListAccounts), arg0, arg1)
+}
+
+// ListCurrencies mocks base method.
+func (m *MockStore) ListCurrencies(arg0 context.Context) ([]db.Currency, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "ListCurrencies", arg0)
+    ret0, _ := ret[0].([]db.Currency)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// ListCurrencies indicates an expected call of ListCurrencies.
+func (mr *MockStoreMockRecorder) ListCurrencies(arg0 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCurrencies", reflect.TypeOf((*MockStore)(nil).ListCurrencies), arg0)
+}
+
+// ListEntries mocks base method.
+func (m *MockStore) ListEntries(arg0 context.Context, arg1 db.ListEntriesParams) ([]db.Entry, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "ListEntries", arg0, arg1)
+    ret0, _ := ret[0].([]db.Entry)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// ListEntries indicates an expected call of ListEntries.
+func (mr *MockStoreMockRecorder) ListEntries(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEntries", reflect.TypeOf((*MockStore)(nil).ListEntries), arg0, arg1)
+}
+
+// ListInterestAccounts mocks base method.
+func (m *MockStore) ListInterestAccounts(arg0 context.Context, arg1 db.ListInterestAccountsParams) ([]db.Account, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "ListInterestAccounts", arg0, arg1)
+    ret0, _ := ret[0].([]db.Account)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// ListInterestAccounts indicates an expected call of ListInterestAccounts.
+func (mr *MockStoreMockRecorder) ListInterestAccounts(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInterestAccounts", reflect.TypeOf((*MockStore)(nil).ListInterestAccounts), arg0, arg1)
+}
+
+// ListInterestAccruals mocks base method.
+func (m *MockStore) ListInterestAccruals(arg0 context.Context, arg1 db.ListInterestAccrualsParams) ([]db.InterestAccrual, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "ListInterestAccruals", arg0, arg1)
+    ret0, _ := ret[0].([]db.InterestAccrual)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// ListInterestAccruals indicates an expected call of ListInterestAccruals.
+func (mr *MockStoreMockRecorder) ListInterestAccruals(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInterestAccruals", reflect.TypeOf((*MockStore)(nil).ListInterestAccruals), arg0, arg1)
+}
+
+// ListJournalEntries mocks base method.
+func (m *MockStore) ListJournalEntries(arg0 context.Context, arg1 sql.NullInt64) ([]db.Entry, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "ListJournalEntries", arg0, arg1)
+    ret0, _ := ret[0].([]db.Entry)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// ListJournalEntries indicates an expected call of ListJournalEntries.
+func (mr *MockStoreMockRecorder) ListJournalEntries(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJournalEntries", reflect.TypeOf((*MockStore)(nil).ListJournalEntries), arg0, arg1)
+}
+
+// ListScheduledTransferRuns mocks base method.
+func (m *MockStore) ListScheduledTransferRuns(arg0 context.Context, arg1 db.ListScheduledTransferRunsParams) ([]db.ScheduledTransferRun, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "ListScheduledTransferRuns", arg0, arg1)
+    ret0, _ := ret[0].([]db.ScheduledTransferRun)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// ListScheduledTransferRuns indicates an expected call of ListScheduledTransferRuns.
+func (mr *MockStoreMockRecorder) ListScheduledTransferRuns(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListScheduledTransferRuns", reflect.TypeOf((*MockStore)(nil).ListScheduledTransferRuns), arg0, arg1)
+}
+
+// ListScheduledTransfers mocks base method.
+func (m *MockStore) ListScheduledTransfers(arg0 context.Context, arg1 db.ListScheduledTransfersParams) ([]db.ScheduledTransfer, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "ListScheduledTransfers", arg0, arg1)
+    ret0, _ := ret[0].([]db.ScheduledTransfer)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// ListScheduledTransfers indicates an expected call of ListScheduledTransfers.
+func (mr *MockStoreMockRecorder) ListScheduledTransfers(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListScheduledTransfers", reflect.TypeOf((*MockStore)(nil).ListScheduledTransfers), arg0, arg1)
+}
+
+// ListStatementEntries mocks base method.
+func (m *MockStore) ListStatementEntries(arg0 context.Context, arg1 db.ListStatementEntriesParams) ([]db.ListStatementEntriesRow, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "ListStatementEntries", arg0, arg1)
+    ret0, _ := ret[0].([]db.ListStatementEntriesRow)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// ListStatementEntries indicates an expected call of ListStatementEntries.
+func (mr *MockStoreMockRecorder) ListStatementEntries(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStatementEntries", reflect.TypeOf((*MockStore)(nil).ListStatementEntries), arg0, arg1)
+}
+
+// ListTransferDrift mocks base method.
+func (m *MockStore) ListTransferDrift(arg0 context.Context) ([]db.ListTransferDriftRow, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "ListTransferDrift", arg0)
+    ret0, _ := ret[0].([]db.ListTransferDriftRow)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// ListTransferDrift indicates an expected call of ListTransferDrift.
+func (mr *MockStoreMockRecorder) ListTransferDrift(arg0 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransferDrift", reflect.TypeOf((*MockStore)(nil).ListTransferDrift), arg0)
+}
+
+// ListTransferEntries mocks base method.
+func (m *MockStore) ListTransferEntries(arg0 context.Context, arg1 sql.NullInt64) ([]db.Entry, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "ListTransferEntries", arg0, arg1)
+    ret0, _ := ret[0].([]db.Entry)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// ListTransferEntries indicates an expected call of ListTransferEntries.
+func (mr *MockStoreMockRecorder) ListTransferEntries(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransferEntries", reflect.TypeOf((*MockStore)(nil).ListTransferEntries), arg0, arg1)
+}
+
+// ListTransferReversals mocks base method.
+func (m *MockStore) ListTransferReversals(arg0 context.Context, arg1 int64) ([]db.TransferReversal, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "ListTransferReversals", arg0, arg1)
+    ret0, _ := ret[0].([]db.TransferReversal)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// ListTransferReversals indicates an expected call of ListTransferReversals.
+func (mr *MockStoreMockRecorder) ListTransferReversals(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransferReversals", reflect.TypeOf((*MockStore)(nil).ListTransferReversals), arg0, arg1)
+}
+
+// ListTransfers mocks base method.
+func (m *MockStore) ListTransfers(arg0 context.Context, arg1 db.ListTransfersParams) ([]db.Transfer, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "ListTransfers", arg0, arg1)
+    ret0, _ := ret[0].([]db.Transfer)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// ListTransfers indicates an expected call of ListTransfers.
+func (mr *MockStoreMockRecorder) ListTransfers(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransfers", reflect.TypeOf((*MockStore)(nil).ListTransfers), arg0, arg1)
+}
+
+// ListUnpostedInterestAccounts mocks base method.
+func (m *MockStore) ListUnpostedInterestAccounts(arg0 context.Context, arg1 time.Time) ([]int64, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "ListUnpostedInterestAccounts", arg0, arg1)
+    ret0, _ := ret[0].([]int64)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// ListUnpostedInterestAccounts indicates an expected call of ListUnpostedInterestAccounts.
+func (mr *MockStoreMockRecorder) ListUnpostedInterestAccounts(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUnpostedInterestAccounts", reflect.TypeOf((*MockStore)(nil).ListUnpostedInterestAccounts), arg0, arg1)
+}
+
+// ListUnpostedInterestAccrualsForUpdate mocks base method.
+func (m *MockStore) ListUnpostedInterestAccrualsForUpdate(arg0 context.Context, arg1 db.ListUnpostedInterestAccrualsForUpdateParams) ([]db.InterestAccrual, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "ListUnpostedInterestAccrualsForUpdate", arg0, arg1)
+    ret0, _ := ret[0].([]db.InterestAccrual)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// ListUnpostedInterestAccrualsForUpdate indicates an expected call of ListUnpostedInterestAccrualsForUpdate.
+func (mr *MockStoreMockRecorder) ListUnpostedInterestAccrualsForUpdate(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUnpostedInterestAccrualsForUpdate", reflect.TypeOf((*MockStore)(nil).ListUnpostedInterestAccrualsForUpdate), arg0, arg1)
+}
+
+// ListWebhookDeliveries mocks base method.
+func (m *MockStore) ListWebhookDeliveries(arg0 context.Context, arg1 db.ListWebhookDeliveriesParams) ([]db.WebhookDelivery, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "ListWebhookDeliveries", arg0, arg1)
+    ret0, _ := ret[0].([]db.WebhookDelivery)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// ListWebhookDeliveries indicates an expected call of ListWebhookDeliveries.
+func (mr *MockStoreMockRecorder) ListWebhookDeliveries(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookDeliveries", reflect.TypeOf((*MockStore)(nil).ListWebhookDeliveries), arg0, arg1)
+}
+
+// ListWebhookEndpoints mocks base method.
+func (m *MockStore) ListWebhookEndpoints(arg0 context.Context, arg1 db.ListWebhookEndpointsParams) ([]db.WebhookEndpoint, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "ListWebhookEndpoints", arg0, arg1)
+    ret0, _ := ret[0].([]db.WebhookEndpoint)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// ListWebhookEndpoints indicates an expected call of ListWebhookEndpoints.
+func (mr *MockStoreMockRecorder) ListWebhookEndpoints(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookEndpoints", reflect.TypeOf((*MockStore)(nil).ListWebhookEndpoints), arg0, arg1)
+}
+
+// LockEventRelay mocks base method.
+func (m *MockStore) LockEventRelay(arg0 context.Context) error {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "LockEventRelay", arg0)
+    ret0, _ := ret[0].(error)
+    return ret0
+}
+
+// LockEventRelay indicates an expected call of LockEventRelay.
+func (mr *MockStoreMockRecorder) LockEventRelay(arg0 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockEventRelay", reflect.TypeOf((*MockStore)(nil).LockEventRelay), arg0)
+}
+
+// MarkEventPublished mocks base method.
+func (m *MockStore) MarkEventPublished(arg0 context.Context, arg1 int64) error {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "MarkEventPublished", arg0, arg1)
+    ret0, _ := ret[0].(error)
+    return ret0
+}
+
+// MarkEventPublished indicates an expected call of MarkEventPublished.
+func (mr *MockStoreMockRecorder) MarkEventPublished(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkEventPublished", reflect.TypeOf((*MockStore)(nil).MarkEventPublished), arg0, arg1)
+}
+
+// MarkInterestAccrualsPosted mocks base method.
+func (m *MockStore) MarkInterestAccrualsPosted(arg0 context.Context, arg1 db.MarkInterestAccrualsPostedParams) error {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "MarkInterestAccrualsPosted", arg0, arg1)
+    ret0, _ := ret[0].(error)
+    return ret0
+}
+
+// MarkInterestAccrualsPosted indicates an expected call of MarkInterestAccrualsPosted.
+func (mr *MockStoreMockRecorder) MarkInterestAccrualsPosted(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkInterestAccrualsPosted", reflect.TypeOf((*MockStore)(nil).MarkInterestAccrualsPosted), arg0, arg1)
+}
+
+// PlaceHoldTx mocks base method.
+func (m *MockStore) PlaceHoldTx(arg0 context.Context, arg1 db.PlaceHoldTxParams) (db.PlaceHoldTxResult, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "PlaceHoldTx", arg0, arg1)
+    ret0, _ := ret[0].(db.PlaceHoldTxResult)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// PlaceHoldTx indicates an expected call of PlaceHoldTx.
+func (mr *MockStoreMockRecorder) PlaceHoldTx(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlaceHoldTx", reflect.TypeOf((*MockStore)(nil).PlaceHoldTx), arg0, arg1)
+}
+
+// PostInterestTx mocks base method.
+func (m *MockStore) PostInterestTx(arg0 context.Context, arg1 db.PostInterestTxParams) (db.PostInterestTxResult, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "PostInterestTx", arg0, arg1)
+    ret0, _ := ret[0].(db.PostInterestTxResult)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// PostInterestTx indicates an expected call of PostInterestTx.
+func (mr *MockStoreMockRecorder) PostInterestTx(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostInterestTx", reflect.TypeOf((*MockStore)(nil).PostInterestTx), arg0, arg1)
+}
+
+// PostJournalTx mocks base method.
+func (m *MockStore) PostJournalTx(arg0 context.Context, arg1 db.PostJournalTxParams) (db.PostJournalTxResult, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "PostJournalTx", arg0, arg1)
+    ret0, _ := ret[0].(db.PostJournalTxResult)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// PostJournalTx indicates an expected call of PostJournalTx.
+func (mr *MockStoreMockRecorder) PostJournalTx(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostJournalTx", reflect.TypeOf((*MockStore)(nil).PostJournalTx), arg0, arg1)
+}
+
+// RecordEventFailure mocks base method.
+func (m *MockStore) RecordEventFailure(arg0 context.Context, arg1 db.RecordEventFailureParams) error {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "RecordEventFailure", arg0, arg1)
+    ret0, _ := ret[0].(error)
+    return ret0
+}
+
+// RecordEventFailure indicates an expected call of RecordEventFailure.
+func (mr *MockStoreMockRecorder) RecordEventFailure(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordEventFailure", reflect.TypeOf((*MockStore)(nil).RecordEventFailure), arg0, arg1)
+}
+
+// RecordWebhookDeliveryAttempt mocks base method.
+func (m *MockStore) RecordWebhookDeliveryAttempt(arg0 context.Context, arg1 db.RecordWebhookDeliveryAttemptParams) (db.WebhookDelivery, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "RecordWebhookDeliveryAttempt", arg0, arg1)
+    ret0, _ := ret[0].(db.WebhookDelivery)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// RecordWebhookDeliveryAttempt indicates an expected call of RecordWebhookDeliveryAttempt.
+func (mr *MockStoreMockRecorder) RecordWebhookDeliveryAttempt(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordWebhookDeliveryAttempt", reflect.TypeOf((*MockStore)(nil).RecordWebhookDeliveryAttempt), arg0, arg1)
+}
+
+// RelayEventsTx mocks base method.
+func (m *MockStore) RelayEventsTx(arg0 context.Context, arg1 db.RelayEventsTxParams) (db.RelayEventsTxResult, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "RelayEventsTx", arg0, arg1)
+    ret0, _ := ret[0].(db.RelayEventsTxResult)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// RelayEventsTx indicates an expected call of RelayEventsTx.
+func (mr *MockStoreMockRecorder) RelayEventsTx(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RelayEventsTx", reflect.TypeOf((*MockStore)(nil).RelayEventsTx), arg0, arg1)
+}
+
+// ReleaseEvents mocks base method.
+func (m *MockStore) ReleaseEvents(arg0 context.Context, arg1 db.ReleaseEventsParams) error {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "ReleaseEvents", arg0, arg1)
+    ret0, _ := ret[0].(error)
+    return ret0
+}
+
+// ReleaseEvents indicates an expected call of ReleaseEvents.
+func (mr *MockStoreMockRecorder) ReleaseEvents(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseEvents", reflect.TypeOf((*MockStore)(nil).ReleaseEvents), arg0, arg1)
+}
+
+// ReleaseHoldTx mocks base method.
+func (m *MockStore) ReleaseHoldTx(arg0 context.Context, arg1 int64) (db.Hold, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "ReleaseHoldTx", arg0, arg1)
+    ret0, _ := ret[0].(db.Hold)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// ReleaseHoldTx indicates an expected call of ReleaseHoldTx.
+func (mr *MockStoreMockRecorder) ReleaseHoldTx(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseHoldTx", reflect.TypeOf((*MockStore)(nil).ReleaseHoldTx), arg0, arg1)
+}
+
+// ReplayWebhookDelivery mocks base method.
+func (m *MockStore) ReplayWebhookDelivery(arg0 context.Context, arg1 int64) (db.WebhookDelivery, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "ReplayWebhookDelivery", arg0, arg1)
+    ret0, _ := ret[0].(db.WebhookDelivery)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// ReplayWebhookDelivery indicates an expected call of ReplayWebhookDelivery.
+func (mr *MockStoreMockRecorder) ReplayWebhookDelivery(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplayWebhookDelivery", reflect.TypeOf((*MockStore)(nil).ReplayWebhookDelivery), arg0, arg1)
+}
+
+// RescheduleScheduledTransfer mocks base method.
+func (m *MockStore) RescheduleScheduledTransfer(arg0 context.Context, arg1 db.RescheduleScheduledTransferParams) (db.ScheduledTransfer, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "RescheduleScheduledTransfer", arg0, arg1)
+    ret0, _ := ret[0].(db.ScheduledTransfer)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// RescheduleScheduledTransfer indicates an expected call of RescheduleScheduledTransfer.
+func (mr *MockStoreMockRecorder) RescheduleScheduledTransfer(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RescheduleScheduledTransfer", reflect.TypeOf((*MockStore)(nil).RescheduleScheduledTransfer), arg0, arg1)
+}
+
+// ReverseTransferTx mocks base method.
+func (m *MockStore) ReverseTransferTx(arg0 context.Context, arg1 db.ReverseTransferTxParams) (db.ReverseTransferTxResult, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "ReverseTransferTx", arg0, arg1)
+    ret0, _ := ret[0].(db.ReverseTransferTxResult)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// ReverseTransferTx indicates an expected call of ReverseTransferTx.
+func (mr *MockStoreMockRecorder) ReverseTransferTx(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReverseTransferTx", reflect.TypeOf((*MockStore)(nil).ReverseTransferTx), arg0, arg1)
+}
+
+// TransferTx mocks base method.
+func (m *MockStore) TransferTx(arg0 context.Context, arg1 db.TransferTxParams) (db.TransferTxResult, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "TransferTx", arg0, arg1)
+    ret0, _ := ret[0].(db.TransferTxResult)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// TransferTx indicates an expected call of TransferTx.
+func (mr *MockStoreMockRecorder) TransferTx(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferTx", reflect.TypeOf((*MockStore)(nil).TransferTx), arg0, arg1)
+}
+
+// UpdateAccount mocks base method.
+func (m *MockStore) UpdateAccount(arg0 context.Context, arg1 db.UpdateAccountParams) (db.Account, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "UpdateAccount", arg0, arg1)
+    ret0, _ := ret[0].(db.Account)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// UpdateAccount indicates an expected call of UpdateAccount.
+func (mr *MockStoreMockRecorder) UpdateAccount(arg0, arg1 interface{}) *gomock.Call {
     mr.mock.ctrl.T.Helper()
     return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccount", reflect.TypeOf((*MockStore)(nil).UpdateAccount), arg0, arg1)
 }
+
+// UpdateAccountOverdraftLimit mocks base method.
+func (m *MockStore) UpdateAccountOverdraftLimit(arg0 context.Context, arg1 db.UpdateAccountOverdraftLimitParams) (db.Account, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "UpdateAccountOverdraftLimit", arg0, arg1)
+    ret0, _ := ret[0].(db.Account)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// UpdateAccountOverdraftLimit indicates an expected call of UpdateAccountOverdraftLimit.
+func (mr *MockStoreMockRecorder) UpdateAccountOverdraftLimit(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountOverdraftLimit", reflect.TypeOf((*MockStore)(nil).UpdateAccountOverdraftLimit), arg0, arg1)
+}
+
+// UpdateAccountStatus mocks base method.
+func (m *MockStore) UpdateAccountStatus(arg0 context.Context, arg1 db.UpdateAccountStatusParams) (db.Account, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "UpdateAccountStatus", arg0, arg1)
+    ret0, _ := ret[0].(db.Account)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// UpdateAccountStatus indicates an expected call of UpdateAccountStatus.
+func (mr *MockStoreMockRecorder) UpdateAccountStatus(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountStatus", reflect.TypeOf((*MockStore)(nil).UpdateAccountStatus), arg0, arg1)
+}
+
+// UpdateAccountStatusTx mocks base method.
+func (m *MockStore) UpdateAccountStatusTx(arg0 context.Context, arg1 db.UpdateAccountStatusTxParams) (db.Account, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "UpdateAccountStatusTx", arg0, arg1)
+    ret0, _ := ret[0].(db.Account)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// UpdateAccountStatusTx indicates an expected call of UpdateAccountStatusTx.
+func (mr *MockStoreMockRecorder) UpdateAccountStatusTx(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountStatusTx", reflect.TypeOf((*MockStore)(nil).UpdateAccountStatusTx), arg0, arg1)
+}
+
+// UpdateCurrencyEnabled mocks base method.
+func (m *MockStore) UpdateCurrencyEnabled(arg0 context.Context, arg1 db.UpdateCurrencyEnabledParams) (db.Currency, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "UpdateCurrencyEnabled", arg0, arg1)
+    ret0, _ := ret[0].(db.Currency)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// UpdateCurrencyEnabled indicates an expected call of UpdateCurrencyEnabled.
+func (mr *MockStoreMockRecorder) UpdateCurrencyEnabled(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCurrencyEnabled", reflect.TypeOf((*MockStore)(nil).UpdateCurrencyEnabled), arg0, arg1)
+}
+
+// UpdateHoldStatus mocks base method.
+func (m *MockStore) UpdateHoldStatus(arg0 context.Context, arg1 db.UpdateHoldStatusParams) (db.Hold, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "UpdateHoldStatus", arg0, arg1)
+    ret0, _ := ret[0].(db.Hold)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// UpdateHoldStatus indicates an expected call of UpdateHoldStatus.
+func (mr *MockStoreMockRecorder) UpdateHoldStatus(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateHoldStatus", reflect.TypeOf((*MockStore)(nil).UpdateHoldStatus), arg0, arg1)
+}
+
+// UpdateHoldTransfer mocks base method.
+func (m *MockStore) UpdateHoldTransfer(arg0 context.Context, arg1 db.UpdateHoldTransferParams) (db.Hold, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "UpdateHoldTransfer", arg0, arg1)
+    ret0, _ := ret[0].(db.Hold)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// UpdateHoldTransfer indicates an expected call of UpdateHoldTransfer.
+func (mr *MockStoreMockRecorder) UpdateHoldTransfer(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateHoldTransfer", reflect.TypeOf((*MockStore)(nil).UpdateHoldTransfer), arg0, arg1)
+}
+
+// UpdateIdempotencyKeyResponse mocks base method.
+func (m *MockStore) UpdateIdempotencyKeyResponse(arg0 context.Context, arg1 db.UpdateIdempotencyKeyResponseParams) (db.IdempotencyKey, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "UpdateIdempotencyKeyResponse", arg0, arg1)
+    ret0, _ := ret[0].(db.IdempotencyKey)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// UpdateIdempotencyKeyResponse indicates an expected call of UpdateIdempotencyKeyResponse.
+func (mr *MockStoreMockRecorder) UpdateIdempotencyKeyResponse(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateIdempotencyKeyResponse", reflect.TypeOf((*MockStore)(nil).UpdateIdempotencyKeyResponse), arg0, arg1)
+}
+
+// UpdateScheduledTransfer mocks base method.
+func (m *MockStore) UpdateScheduledTransfer(arg0 context.Context, arg1 db.UpdateScheduledTransferParams) (db.ScheduledTransfer, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "UpdateScheduledTransfer", arg0, arg1)
+    ret0, _ := ret[0].(db.ScheduledTransfer)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// UpdateScheduledTransfer indicates an expected call of UpdateScheduledTransfer.
+func (mr *MockStoreMockRecorder) UpdateScheduledTransfer(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateScheduledTransfer", reflect.TypeOf((*MockStore)(nil).UpdateScheduledTransfer), arg0, arg1)
+}
+
+// UpdateScheduledTransferStatus mocks base method.
+func (m *MockStore) UpdateScheduledTransferStatus(arg0 context.Context, arg1 db.UpdateScheduledTransferStatusParams) (db.ScheduledTransfer, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "UpdateScheduledTransferStatus", arg0, arg1)
+    ret0, _ := ret[0].(db.ScheduledTransfer)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// UpdateScheduledTransferStatus indicates an expected call of UpdateScheduledTransferStatus.
+func (mr *MockStoreMockRecorder) UpdateScheduledTransferStatus(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateScheduledTransferStatus", reflect.TypeOf((*MockStore)(nil).UpdateScheduledTransferStatus), arg0, arg1)
+}
+
+// UpsertUserTokenRevocation mocks base method.
+func (m *MockStore) UpsertUserTokenRevocation(arg0 context.Context, arg1 db.UpsertUserTokenRevocationParams) error {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "UpsertUserTokenRevocation", arg0, arg1)
+    ret0, _ := ret[0].(error)
+    return ret0
+}
+
+// UpsertUserTokenRevocation indicates an expected call of UpsertUserTokenRevocation.
+func (mr *MockStoreMockRecorder) UpsertUserTokenRevocation(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertUserTokenRevocation", reflect.TypeOf((*MockStore)(nil).UpsertUserTokenRevocation), arg0, arg1)
+}
+
+// WithdrawTx mocks base method.
+func (m *MockStore) WithdrawTx(arg0 context.Context, arg1 db.WithdrawTxParams) (db.WithdrawTxResult, error) {
+    m.ctrl.T.Helper()
+    ret := m.ctrl.Call(m, "WithdrawTx", arg0, arg1)
+    ret0, _ := ret[0].(db.WithdrawTxResult)
+    ret1, _ := ret[1].(error)
+    return ret0, ret1
+}
+
+// WithdrawTx indicates an expected call of WithdrawTx.
+func (mr *MockStoreMockRecorder) WithdrawTx(arg0, arg1 interface{}) *gomock.Call {
+    mr.mock.ctrl.T.Helper()
+    return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithdrawTx", reflect.TypeOf((*MockStore)(nil).WithdrawTx), arg0, arg1)
+}
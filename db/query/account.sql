@@ -2,9 +2,11 @@
 INSERT INTO accounts (
   owner,
   balance,
-  currency
+  currency,
+  account_type,
+  nickname
 ) VALUES (
-  $1, $2, $3
+  $1, $2, $3, $4, $5
 )
 RETURNING *;
 
@@ -20,9 +22,10 @@ FOR NO KEY UPDATE;
 -- name: ListAccounts :many
 SELECT * FROM accounts
 WHERE owner = $1
+  AND (account_type = $2 OR $2 = '')
 ORDER BY id
-LIMIT $2
-OFFSET $3;
+LIMIT $3
+OFFSET $4;
 
 -- name: DeleteAccount :exec
 DELETE FROM accounts
@@ -39,3 +42,30 @@ UPDATE accounts
 SET balance = balance + sqlc.arg(amount)
 WHERE id = sqlc.arg(id)
 RETURNING *;
+
+-- name: UpdateAccountOverdraftLimit :one
+UPDATE accounts
+SET overdraft_limit = sqlc.arg(overdraft_limit)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: GetSystemAccount :one
+SELECT * FROM accounts
+WHERE owner = $1 AND currency = $2 AND account_type = 'checking'
+LIMIT 1;
+
+-- name: CreateSystemAccount :exec
+INSERT INTO accounts (
+  owner,
+  balance,
+  currency
+) VALUES (
+  $1, 0, $2
+)
+ON CONFLICT (owner, currency, account_type) DO NOTHING;
+
+-- name: UpdateAccountStatus :one
+UPDATE accounts
+SET status = sqlc.arg(status)
+WHERE id = sqlc.arg(id)
+RETURNING *;
@@ -1,9 +1,11 @@
 -- name: CreateEntry :one
 INSERT INTO entries (
   account_id,  
-  amount
+  amount,
+  journal_id,
+  transfer_id
 ) VALUES (
-  $1, $2
+  $1, $2, $3, $4
 )
 RETURNING *;
 
@@ -18,3 +20,37 @@ ORDER BY id
 LIMIT $2
 OFFSET $3;
 
+
+-- name: ListAccountEntries :many
+SELECT * FROM entries
+WHERE account_id = sqlc.arg(account_id)
+  AND id < sqlc.arg(before_id)
+  AND created_at >= sqlc.arg(from_time)
+  AND created_at < sqlc.arg(to_time)
+  AND (sqlc.arg(direction)::varchar = '' OR
+    (sqlc.arg(direction) = 'in' AND amount > 0) OR
+    (sqlc.arg(direction) = 'out' AND amount < 0))
+ORDER BY id DESC
+LIMIT sqlc.arg(page_size);
+
+-- name: ListJournalEntries :many
+SELECT * FROM entries
+WHERE journal_id = $1
+ORDER BY id;
+
+-- name: ListTransferEntries :many
+SELECT * FROM entries
+WHERE transfer_id = $1
+ORDER BY id;
+
+-- name: ListStatementEntries :many
+SELECT e.id, e.account_id, e.amount, e.created_at, e.journal_id, e.transfer_id,
+  COALESCE(j.description, '')::varchar AS description
+FROM entries e
+LEFT JOIN journals j ON j.id = e.journal_id
+WHERE e.account_id = sqlc.arg(account_id)
+  AND e.id > sqlc.arg(after_id)
+  AND e.created_at >= sqlc.arg(from_time)
+  AND e.created_at < sqlc.arg(to_time)
+ORDER BY e.id
+LIMIT sqlc.arg(page_size);
@@ -0,0 +1,56 @@
+-- name: CreateEvent :one
+INSERT INTO events (
+  account_id,
+  type,
+  payload
+) VALUES (
+  $1, $2, $3
+)
+RETURNING *;
+
+-- name: GetEvent :one
+SELECT * FROM events
+WHERE id = $1 LIMIT 1;
+
+-- name: ListAccountEvents :many
+SELECT * FROM events
+WHERE account_id = $1
+ORDER BY id
+LIMIT $2
+OFFSET $3;
+
+-- name: LockEventRelay :exec
+SELECT pg_advisory_xact_lock(hashtext('events_relay'));
+
+-- name: ClaimUnpublishedEvents :many
+UPDATE events
+SET locked_until = sqlc.arg(lease_until)
+WHERE id IN (
+  SELECT id FROM events
+  WHERE published_at IS NULL AND locked_until <= sqlc.arg(now)
+    AND account_id NOT IN (
+      SELECT account_id FROM events
+      WHERE published_at IS NULL AND locked_until > sqlc.arg(now)
+    )
+  ORDER BY id
+  LIMIT sqlc.arg(batch_size)
+  FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: MarkEventPublished :exec
+UPDATE events
+SET published_at = now(),
+  attempts = attempts + 1
+WHERE id = $1;
+
+-- name: RecordEventFailure :exec
+UPDATE events
+SET attempts = attempts + 1,
+  last_error = $2
+WHERE id = $1;
+
+-- name: ReleaseEvents :exec
+UPDATE events
+SET locked_until = sqlc.arg(released_at)
+WHERE id = ANY(sqlc.arg(ids)::bigint[]) AND published_at IS NULL;
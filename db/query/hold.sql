@@ -0,0 +1,40 @@
+-- name: CreateHold :one
+INSERT INTO holds (
+  account_id,
+  to_account_id,
+  amount,
+  expires_at
+) VALUES (
+  $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: GetHold :one
+SELECT * FROM holds
+WHERE id = $1 LIMIT 1;
+
+-- name: GetHoldForUpdate :one
+SELECT * FROM holds
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: UpdateHoldStatus :one
+UPDATE holds
+SET status = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateHoldTransfer :one
+UPDATE holds
+SET transfer_id = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: GetHeldAmount :one
+SELECT COALESCE(SUM(amount), 0)::bigint AS held_amount FROM holds
+WHERE account_id = $1 AND status = 'held' AND expires_at > now();
+
+-- name: ExpireHolds :exec
+UPDATE holds
+SET status = 'expired'
+WHERE status = 'held' AND expires_at <= now();
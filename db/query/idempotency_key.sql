@@ -0,0 +1,38 @@
+-- name: CreateIdempotencyKey :one
+INSERT INTO idempotency_keys (
+  username,
+  idempotency_key,
+  request_method,
+  request_path,
+  request_hash
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+ON CONFLICT (username, idempotency_key) DO UPDATE
+SET request_method = EXCLUDED.request_method,
+  request_path = EXCLUDED.request_path,
+  request_hash = EXCLUDED.request_hash,
+  response_code = 0,
+  response_body = '',
+  created_at = now()
+WHERE idempotency_keys.created_at < sqlc.arg(expired_before)
+RETURNING *;
+
+-- name: GetIdempotencyKey :one
+SELECT * FROM idempotency_keys
+WHERE username = $1 AND idempotency_key = $2 LIMIT 1;
+
+-- name: UpdateIdempotencyKeyResponse :one
+UPDATE idempotency_keys
+SET response_code = sqlc.arg(response_code),
+  response_body = sqlc.arg(response_body)
+WHERE username = sqlc.arg(username) AND idempotency_key = sqlc.arg(idempotency_key)
+RETURNING *;
+
+-- name: DeleteIdempotencyKey :exec
+DELETE FROM idempotency_keys
+WHERE username = $1 AND idempotency_key = $2;
+
+-- name: DeleteExpiredIdempotencyKeys :exec
+DELETE FROM idempotency_keys
+WHERE created_at < $1;
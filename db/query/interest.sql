@@ -0,0 +1,78 @@
+-- name: ListInterestAccounts :many
+SELECT * FROM accounts a
+WHERE a.account_type = sqlc.arg(account_type)
+  AND COALESCE(
+    (SELECT e.payload->>'status' = 'active' FROM events e
+     WHERE e.account_id = a.id
+       AND e.type = 'account.status_changed'
+       AND e.created_at < sqlc.arg(created_before)
+     ORDER BY e.id DESC
+     LIMIT 1),
+    (SELECT e.payload->>'status' <> 'active' FROM events e
+     WHERE e.account_id = a.id
+       AND e.type = 'account.status_changed'
+       AND e.created_at >= sqlc.arg(created_before)
+     ORDER BY e.id
+     LIMIT 1),
+    a.status = 'active'
+  )
+  AND a.created_at < sqlc.arg(created_before)
+  AND a.id > sqlc.arg(after_id)
+ORDER BY a.id
+LIMIT sqlc.arg(batch_size);
+
+-- name: GetAccountBalanceAt :one
+SELECT (a.balance - COALESCE(SUM(e.amount), 0))::bigint AS balance
+FROM accounts a
+LEFT JOIN entries e ON e.account_id = a.id AND e.created_at >= sqlc.arg(at)
+WHERE a.id = sqlc.arg(account_id)
+GROUP BY a.id;
+
+-- name: CreateInterestAccrual :one
+INSERT INTO interest_accruals (
+  account_id,
+  accrual_date,
+  balance,
+  rate,
+  amount_micros
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+ON CONFLICT (account_id, accrual_date) WHERE NOT carried DO NOTHING
+RETURNING *;
+
+-- name: CreateInterestCarry :one
+INSERT INTO interest_accruals (
+  account_id,
+  accrual_date,
+  balance,
+  rate,
+  amount_micros,
+  carried
+) VALUES (
+  $1, $2, 0, 0, $3, true
+)
+RETURNING *;
+
+-- name: ListInterestAccruals :many
+SELECT * FROM interest_accruals
+WHERE account_id = $1
+ORDER BY accrual_date, id
+LIMIT $2
+OFFSET $3;
+
+-- name: ListUnpostedInterestAccounts :many
+SELECT DISTINCT account_id FROM interest_accruals
+WHERE journal_id IS NULL AND accrual_date <= $1
+ORDER BY account_id;
+
+-- name: ListUnpostedInterestAccrualsForUpdate :many
+SELECT * FROM interest_accruals
+WHERE account_id = $1 AND journal_id IS NULL AND accrual_date <= $2
+ORDER BY accrual_date
+FOR UPDATE;
+
+-- name: MarkInterestAccrualsPosted :exec
+UPDATE interest_accruals
+SET journal_id = $2
+WHERE account_id = $1 AND journal_id IS NULL AND accrual_date <= $3;
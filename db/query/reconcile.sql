@@ -0,0 +1,15 @@
+-- name: ListAccountBalanceDrift :many
+SELECT a.id, a.balance, COALESCE(SUM(e.amount), 0)::bigint AS entries_total
+FROM accounts a
+LEFT JOIN entries e ON e.account_id = a.id
+GROUP BY a.id
+HAVING a.balance <> COALESCE(SUM(e.amount), 0)
+ORDER BY a.id;
+
+-- name: ListTransferDrift :many
+SELECT t.id, t.amount, COALESCE(SUM(e.amount), 0)::bigint AS entries_total, COUNT(e.id) AS entries_count
+FROM transfers t
+LEFT JOIN entries e ON e.transfer_id = t.id
+GROUP BY t.id
+HAVING COALESCE(SUM(e.amount), 0) <> 0 OR COUNT(e.id) < 2
+ORDER BY t.id;
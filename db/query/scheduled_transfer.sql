@@ -0,0 +1,87 @@
+-- name: CreateScheduledTransfer :one
+INSERT INTO scheduled_transfers (
+  owner,
+  from_account_id,
+  to_account_id,
+  amount,
+  frequency,
+  next_run_at,
+  run_after,
+  start_at,
+  currency
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $6, $6, $7
+)
+RETURNING *;
+
+-- name: GetScheduledTransfer :one
+SELECT * FROM scheduled_transfers
+WHERE id = $1 LIMIT 1;
+
+-- name: GetScheduledTransferForUpdate :one
+SELECT * FROM scheduled_transfers
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListScheduledTransfers :many
+SELECT * FROM scheduled_transfers
+WHERE owner = $1
+ORDER BY id
+LIMIT $2
+OFFSET $3;
+
+-- name: UpdateScheduledTransfer :one
+UPDATE scheduled_transfers
+SET amount = COALESCE(sqlc.narg(amount), amount),
+  frequency = COALESCE(sqlc.narg(frequency), frequency),
+  status = COALESCE(sqlc.narg(status), status),
+  next_run_at = COALESCE(sqlc.narg(next_run_at), next_run_at),
+  run_after = COALESCE(sqlc.narg(next_run_at), run_after),
+  start_at = COALESCE(sqlc.narg(next_run_at), start_at)
+WHERE id = sqlc.arg(id) AND status IN ('active', 'paused')
+RETURNING *;
+
+-- name: UpdateScheduledTransferStatus :one
+UPDATE scheduled_transfers
+SET status = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: RescheduleScheduledTransfer :one
+UPDATE scheduled_transfers
+SET next_run_at = $2,
+  run_after = $3,
+  attempts = $4
+WHERE id = $1
+RETURNING *;
+
+-- name: ClaimDueScheduledTransfers :many
+UPDATE scheduled_transfers
+SET run_after = sqlc.arg(lease_until)
+WHERE id IN (
+  SELECT id FROM scheduled_transfers
+  WHERE status = 'active' AND run_after <= sqlc.arg(now)
+  ORDER BY run_after
+  LIMIT sqlc.arg(batch_size)
+  FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: CreateScheduledTransferRun :one
+INSERT INTO scheduled_transfer_runs (
+  scheduled_transfer_id,
+  transfer_id,
+  status,
+  error,
+  amount
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: ListScheduledTransferRuns :many
+SELECT * FROM scheduled_transfer_runs
+WHERE scheduled_transfer_id = $1
+ORDER BY id DESC
+LIMIT $2
+OFFSET $3;
@@ -0,0 +1,33 @@
+-- name: CreateRevokedToken :exec
+INSERT INTO revoked_tokens (
+  id,
+  username,
+  expires_at
+) VALUES (
+  $1, $2, $3
+)
+ON CONFLICT DO NOTHING;
+
+-- name: IsTokenRevoked :one
+SELECT EXISTS(
+  SELECT 1 FROM revoked_tokens
+  WHERE id = $1
+);
+
+-- name: DeleteExpiredRevokedTokens :exec
+DELETE FROM revoked_tokens
+WHERE expires_at < now();
+
+-- name: UpsertUserTokenRevocation :exec
+INSERT INTO user_token_revocations (
+  username,
+  revoked_before
+) VALUES (
+  $1, $2
+)
+ON CONFLICT (username) DO UPDATE
+SET revoked_before = GREATEST(user_token_revocations.revoked_before, EXCLUDED.revoked_before);
+
+-- name: GetUserTokenRevocation :one
+SELECT * FROM user_token_revocations
+WHERE username = $1 LIMIT 1;
@@ -2,9 +2,12 @@
 INSERT INTO transfers (
   from_account_id,
   to_account_id,
-  amount
+  amount,
+  to_amount,
+  exchange_rate,
+  fee
 ) VALUES (
-  $1, $2, $3
+  $1, $2, $3, $4, $5, $6
 )
 RETURNING *;
 
@@ -20,3 +23,21 @@ ORDER BY id
 LIMIT $3
 OFFSET $4;
 
+
+-- name: ListAccountTransfers :many
+SELECT t.*, fa.currency AS from_currency, ta.currency AS to_currency
+FROM transfers t
+JOIN accounts fa ON fa.id = t.from_account_id
+JOIN accounts ta ON ta.id = t.to_account_id
+WHERE ((t.from_account_id = sqlc.arg(account_id) AND sqlc.arg(direction)::varchar IN ('', 'out')) OR
+    (t.to_account_id = sqlc.arg(account_id) AND sqlc.arg(direction) IN ('', 'in')))
+  AND t.id < sqlc.arg(before_id)
+  AND t.created_at >= sqlc.arg(from_time)
+  AND t.created_at < sqlc.arg(to_time)
+ORDER BY t.id DESC
+LIMIT sqlc.arg(page_size);
+
+-- name: GetTransferForUpdate :one
+SELECT * FROM transfers
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
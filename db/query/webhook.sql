@@ -0,0 +1,79 @@
+-- name: CreateWebhookEndpoint :one
+INSERT INTO webhook_endpoints (
+  owner,
+  url,
+  secret,
+  event_types
+) VALUES (
+  $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: GetWebhookEndpoint :one
+SELECT * FROM webhook_endpoints
+WHERE id = $1 LIMIT 1;
+
+-- name: ListWebhookEndpoints :many
+SELECT * FROM webhook_endpoints
+WHERE owner = $1
+ORDER BY id
+LIMIT $2
+OFFSET $3;
+
+-- name: DeactivateWebhookEndpoint :one
+UPDATE webhook_endpoints
+SET active = false
+WHERE id = $1
+RETURNING *;
+
+-- name: CreateWebhookDeliveries :exec
+INSERT INTO webhook_deliveries (
+  endpoint_id,
+  event_id
+)
+SELECT w.id, e.id FROM events e
+JOIN accounts a ON a.id = e.account_id
+JOIN webhook_endpoints w ON w.owner = a.owner
+WHERE e.id = $1 AND w.active AND e.type = ANY(w.event_types);
+
+-- name: GetWebhookDelivery :one
+SELECT * FROM webhook_deliveries
+WHERE id = $1 LIMIT 1;
+
+-- name: ListWebhookDeliveries :many
+SELECT * FROM webhook_deliveries
+WHERE endpoint_id = $1
+ORDER BY id DESC
+LIMIT $2
+OFFSET $3;
+
+-- name: ClaimDueWebhookDeliveries :many
+UPDATE webhook_deliveries
+SET next_attempt_at = sqlc.arg(lease_until)
+WHERE id IN (
+  SELECT id FROM webhook_deliveries
+  WHERE status = 'pending' AND next_attempt_at <= sqlc.arg(now)
+  ORDER BY next_attempt_at
+  LIMIT sqlc.arg(batch_size)
+  FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: RecordWebhookDeliveryAttempt :one
+UPDATE webhook_deliveries
+SET status = $2,
+  attempts = attempts + 1,
+  response_code = $3,
+  last_error = $4,
+  next_attempt_at = $5,
+  delivered_at = $6
+WHERE id = $1
+RETURNING *;
+
+-- name: ReplayWebhookDelivery :one
+UPDATE webhook_deliveries
+SET status = 'pending',
+  attempts = 0,
+  next_attempt_at = now()
+WHERE id = $1
+RETURNING *;
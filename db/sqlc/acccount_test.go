@@ -14,9 +14,10 @@ func createRandomAccount(t *testing.T) Account {
     user := createRandomUser(t)
 
     arg := CreateAccountParams{
-        Owner:    user.Username,
-        Balance:  util.RandomMoney(),
-        Currency: util.RandomCurrency(),
+        Owner:       user.Username,
+        Balance:     util.RandomMoney(),
+        Currency:    util.RandomCurrency(),
+        AccountType: AccountTypeChecking,
     }
 
     account, err := testQueries.CreateAccount(context.Background(), arg)
@@ -26,6 +27,7 @@ func createRandomAccount(t *testing.T) Account {
     require.Equal(t, arg.Owner, account.Owner)
     require.Equal(t, arg.Balance, account.Balance)
     require.Equal(t, arg.Currency, account.Currency)
+    require.Equal(t, arg.AccountType, account.AccountType)
 
     require.NotZero(t, account.ID)
     require.NotZero(t, account.CreatedAt)
@@ -101,3 +103,63 @@ func TestListAccounts(t *testing.T) {
     }
 
 }
+
+func TestAccountTypesPerCurrency(t *testing.T) {
+    checking := createRandomAccount(t)
+
+    savings, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+        Owner:       checking.Owner,
+        Currency:    checking.Currency,
+        AccountType: AccountTypeSavings,
+        Nickname:    util.RandomString(6),
+    })
+    require.NoError(t, err)
+    require.Equal(t, AccountTypeSavings, savings.AccountType)
+    require.NotEmpty(t, savings.Nickname)
+
+    _, err = testQueries.CreateAccount(context.Background(), CreateAccountParams{
+        Owner:       checking.Owner,
+        Currency:    checking.Currency,
+        AccountType: AccountTypeSavings,
+    })
+    require.Error(t, err)
+
+    accounts, err := testQueries.ListAccounts(context.Background(), ListAccountsParams{
+        Owner:       checking.Owner,
+        AccountType: AccountTypeSavings,
+        Limit:       5,
+        Offset:      0,
+    })
+    require.NoError(t, err)
+    require.Len(t, accounts, 1)
+    require.Equal(t, savings.ID, accounts[0].ID)
+}
+
+func TestUpdateAccountOverdraftLimit(t *testing.T) {
+    account1 := createRandomAccount(t)
+    require.Zero(t, account1.OverdraftLimit)
+
+    arg := UpdateAccountOverdraftLimitParams{
+        ID:             account1.ID,
+        OverdraftLimit: util.RandomMoney(),
+    }
+    account2, err := testQueries.UpdateAccountOverdraftLimit(context.Background(), arg)
+    require.NoError(t, err)
+    require.Equal(t, account1.ID, account2.ID)
+    require.Equal(t, account1.Balance, account2.Balance)
+    require.Equal(t, arg.OverdraftLimit, account2.OverdraftLimit)
+}
+
+func TestUpdateAccountStatus(t *testing.T) {
+    account1 := createRandomAccount(t)
+    require.Equal(t, AccountStatusActive, account1.Status)
+
+    arg := UpdateAccountStatusParams{
+        ID:     account1.ID,
+        Status: AccountStatusFrozen,
+    }
+    account2, err := testQueries.UpdateAccountStatus(context.Background(), arg)
+    require.NoError(t, err)
+    require.Equal(t, account1.ID, account2.ID)
+    require.Equal(t, AccountStatusFrozen, account2.Status)
+}
@@ -0,0 +1,103 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// 账户状态
+const (
+	AccountStatusActive = "active"
+	AccountStatusFrozen = "frozen"
+	AccountStatusClosed = "closed"
+)
+
+// 账户类型，同一用户每种货币的每种类型最多开一个账户
+const (
+	AccountTypeChecking = "checking"
+	AccountTypeSavings  = "savings"
+)
+
+var (
+	// ErrAccountNotActive 账户已冻结或已销户，不能转入转出
+	ErrAccountNotActive = errors.New("account is not active")
+	// ErrInvalidStatusTransition 不允许的账户状态变更
+	ErrInvalidStatusTransition = errors.New("invalid account status transition")
+	// ErrNonZeroBalance 余额不为零的账户不能销户
+	ErrNonZeroBalance = errors.New("account balance is not zero")
+	// ErrActiveHolds 还有未释放预授权的账户不能销户
+	ErrActiveHolds = errors.New("account has active holds")
+)
+
+// accountStatusTransitions 允许的状态变更：冻结的账户需先解冻才能销户
+var accountStatusTransitions = map[string][]string{
+	AccountStatusActive: {AccountStatusFrozen, AccountStatusClosed},
+	AccountStatusFrozen: {AccountStatusActive},
+	AccountStatusClosed: {AccountStatusActive},
+}
+
+// CanTransitionAccountStatus 判断账户能否从 from 状态变更为 to 状态
+func CanTransitionAccountStatus(from, to string) bool {
+	for _, status := range accountStatusTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+type UpdateAccountStatusTxParams struct {
+	AccountID int64  `json:"account_id"`
+	Status    string `json:"status"`
+}
+
+// UpdateAccountStatusTx 锁定账户后变更状态，销户要求余额为零且没有未释放的预授权
+func (store *SQLStore) UpdateAccountStatusTx(ctx context.Context, arg UpdateAccountStatusTxParams) (Account, error) {
+	var result Account
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		account, err := q.GetAccountForUpdate(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+
+		if !CanTransitionAccountStatus(account.Status, arg.Status) {
+			return fmt.Errorf("account [%d] %s to %s: %w", account.ID, account.Status, arg.Status, ErrInvalidStatusTransition)
+		}
+		if arg.Status == AccountStatusClosed && account.Balance != 0 {
+			return fmt.Errorf("account [%d] balance %d: %w", account.ID, account.Balance, ErrNonZeroBalance)
+		}
+		if arg.Status == AccountStatusClosed {
+			held, err := q.GetHeldAmount(ctx, account.ID)
+			if err != nil {
+				return err
+			}
+			if held > 0 {
+				return fmt.Errorf("account [%d] held %d: %w", account.ID, held, ErrActiveHolds)
+			}
+		}
+
+		result, err = q.UpdateAccountStatus(ctx, UpdateAccountStatusParams{
+			ID:     account.ID,
+			Status: arg.Status,
+		})
+		if err != nil {
+			return err
+		}
+		return recordEvent(ctx, q, account.ID, EventAccountStatusChanged, result)
+	})
+
+	return result, err
+}
+
+// requireActive 检查锁定后的账户都处于正常状态
+func requireActive(accounts map[int64]Account, ids ...int64) error {
+	for _, id := range ids {
+		account := accounts[id]
+		if account.Status != AccountStatusActive {
+			return fmt.Errorf("account [%d] %s: %w", account.ID, account.Status, ErrAccountNotActive)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanTransitionAccountStatus(t *testing.T) {
+	require.True(t, CanTransitionAccountStatus(AccountStatusActive, AccountStatusFrozen))
+	require.True(t, CanTransitionAccountStatus(AccountStatusFrozen, AccountStatusActive))
+	require.True(t, CanTransitionAccountStatus(AccountStatusActive, AccountStatusClosed))
+	require.True(t, CanTransitionAccountStatus(AccountStatusClosed, AccountStatusActive))
+
+	require.False(t, CanTransitionAccountStatus(AccountStatusFrozen, AccountStatusClosed))
+	require.False(t, CanTransitionAccountStatus(AccountStatusClosed, AccountStatusFrozen))
+	require.False(t, CanTransitionAccountStatus(AccountStatusActive, AccountStatusActive))
+}
+
+func TestUpdateAccountStatusTx(t *testing.T) {
+	store := NewStore(testDB)
+	account := createFundedAccount(t, 10)
+
+	// 余额不为零不能销户
+	_, err := store.UpdateAccountStatusTx(context.Background(), UpdateAccountStatusTxParams{
+		AccountID: account.ID,
+		Status:    AccountStatusClosed,
+	})
+	require.True(t, errors.Is(err, ErrNonZeroBalance))
+
+	frozen, err := store.UpdateAccountStatusTx(context.Background(), UpdateAccountStatusTxParams{
+		AccountID: account.ID,
+		Status:    AccountStatusFrozen,
+	})
+	require.NoError(t, err)
+	require.Equal(t, AccountStatusFrozen, frozen.Status)
+
+	// 冻结的账户需要先解冻
+	_, err = store.UpdateAccountStatusTx(context.Background(), UpdateAccountStatusTxParams{
+		AccountID: account.ID,
+		Status:    AccountStatusClosed,
+	})
+	require.True(t, errors.Is(err, ErrInvalidStatusTransition))
+
+	active, err := store.UpdateAccountStatusTx(context.Background(), UpdateAccountStatusTxParams{
+		AccountID: account.ID,
+		Status:    AccountStatusActive,
+	})
+	require.NoError(t, err)
+	require.Equal(t, AccountStatusActive, active.Status)
+}
+
+func TestUpdateAccountStatusTxActiveHolds(t *testing.T) {
+	store := NewStore(testDB)
+	account1 := createFundedAccount(t, 0)
+	account2 := createSameCurrencyAccount(t, account1, 0)
+
+	hold, err := testQueries.CreateHold(context.Background(), CreateHoldParams{
+		AccountID:   account1.ID,
+		ToAccountID: account2.ID,
+		Amount:      10,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	// 还有未释放的预授权不能销户
+	_, err = store.UpdateAccountStatusTx(context.Background(), UpdateAccountStatusTxParams{
+		AccountID: account1.ID,
+		Status:    AccountStatusClosed,
+	})
+	require.True(t, errors.Is(err, ErrActiveHolds))
+
+	_, err = store.ReleaseHoldTx(context.Background(), hold.ID)
+	require.NoError(t, err)
+
+	closed, err := store.UpdateAccountStatusTx(context.Background(), UpdateAccountStatusTxParams{
+		AccountID: account1.ID,
+		Status:    AccountStatusClosed,
+	})
+	require.NoError(t, err)
+	require.Equal(t, AccountStatusClosed, closed.Status)
+}
+
+func TestTransferTxAccountNotActive(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createFundedAccount(t, 100)
+	account2 := createFundedAccount(t, 100)
+
+	_, err := store.UpdateAccountStatusTx(context.Background(), UpdateAccountStatusTxParams{
+		AccountID: account2.ID,
+		Status:    AccountStatusFrozen,
+	})
+	require.NoError(t, err)
+
+	// 冻结的账户既不能转入也不能转出
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.True(t, errors.Is(err, ErrAccountNotActive))
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account2.ID,
+		ToAccountID:   account1.ID,
+		Amount:        10,
+	})
+	require.True(t, errors.Is(err, ErrAccountNotActive))
+
+	_, err = store.DepositTx(context.Background(), DepositTxParams{
+		AccountID: account2.ID,
+		Amount:    10,
+	})
+	require.True(t, errors.Is(err, ErrAccountNotActive))
+
+	updatedAccount1, err := testQueries.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance, updatedAccount1.Balance)
+}
@@ -0,0 +1,42 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xiusl/bank/util"
+)
+
+func TestListCurrencies(t *testing.T) {
+	currencies, err := testQueries.ListCurrencies(context.Background())
+	require.NoError(t, err)
+
+	enabled := make(map[string]bool)
+	for _, currency := range currencies {
+		enabled[currency.Code] = currency.Enabled
+	}
+	require.True(t, enabled[util.USD])
+	require.True(t, enabled[util.EUR])
+}
+
+func TestUpdateCurrencyEnabled(t *testing.T) {
+	currency, err := testQueries.GetCurrency(context.Background(), "GBP")
+	require.NoError(t, err)
+
+	updated, err := testQueries.UpdateCurrencyEnabled(context.Background(), UpdateCurrencyEnabledParams{
+		Code:    currency.Code,
+		Enabled: !currency.Enabled,
+	})
+	require.NoError(t, err)
+	require.Equal(t, currency.Code, updated.Code)
+	require.Equal(t, currency.Exponent, updated.Exponent)
+	require.Equal(t, !currency.Enabled, updated.Enabled)
+
+	restored, err := testQueries.UpdateCurrencyEnabled(context.Background(), UpdateCurrencyEnabledParams{
+		Code:    currency.Code,
+		Enabled: currency.Enabled,
+	})
+	require.NoError(t, err)
+	require.Equal(t, currency, restored)
+}
@@ -25,48 +25,282 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.addAccountBalanceStmt, err = db.PrepareContext(ctx, addAccountBalance); err != nil {
 		return nil, fmt.Errorf("error preparing query AddAccountBalance: %w", err)
 	}
+	if q.blockSessionStmt, err = db.PrepareContext(ctx, blockSession); err != nil {
+		return nil, fmt.Errorf("error preparing query BlockSession: %w", err)
+	}
+	if q.blockUserSessionsStmt, err = db.PrepareContext(ctx, blockUserSessions); err != nil {
+		return nil, fmt.Errorf("error preparing query BlockUserSessions: %w", err)
+	}
+	if q.claimDueScheduledTransfersStmt, err = db.PrepareContext(ctx, claimDueScheduledTransfers); err != nil {
+		return nil, fmt.Errorf("error preparing query ClaimDueScheduledTransfers: %w", err)
+	}
+	if q.claimDueWebhookDeliveriesStmt, err = db.PrepareContext(ctx, claimDueWebhookDeliveries); err != nil {
+		return nil, fmt.Errorf("error preparing query ClaimDueWebhookDeliveries: %w", err)
+	}
+	if q.claimUnpublishedEventsStmt, err = db.PrepareContext(ctx, claimUnpublishedEvents); err != nil {
+		return nil, fmt.Errorf("error preparing query ClaimUnpublishedEvents: %w", err)
+	}
 	if q.createAccountStmt, err = db.PrepareContext(ctx, createAccount); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateAccount: %w", err)
 	}
 	if q.createEntryStmt, err = db.PrepareContext(ctx, createEntry); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateEntry: %w", err)
 	}
+	if q.createEventStmt, err = db.PrepareContext(ctx, createEvent); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateEvent: %w", err)
+	}
+	if q.createHoldStmt, err = db.PrepareContext(ctx, createHold); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateHold: %w", err)
+	}
+	if q.createIdempotencyKeyStmt, err = db.PrepareContext(ctx, createIdempotencyKey); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateIdempotencyKey: %w", err)
+	}
+	if q.createInterestAccrualStmt, err = db.PrepareContext(ctx, createInterestAccrual); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateInterestAccrual: %w", err)
+	}
+	if q.createInterestCarryStmt, err = db.PrepareContext(ctx, createInterestCarry); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateInterestCarry: %w", err)
+	}
+	if q.createJournalStmt, err = db.PrepareContext(ctx, createJournal); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateJournal: %w", err)
+	}
+	if q.createRevokedTokenStmt, err = db.PrepareContext(ctx, createRevokedToken); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateRevokedToken: %w", err)
+	}
+	if q.createScheduledTransferStmt, err = db.PrepareContext(ctx, createScheduledTransfer); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateScheduledTransfer: %w", err)
+	}
+	if q.createScheduledTransferRunStmt, err = db.PrepareContext(ctx, createScheduledTransferRun); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateScheduledTransferRun: %w", err)
+	}
+	if q.createSessionStmt, err = db.PrepareContext(ctx, createSession); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateSession: %w", err)
+	}
+	if q.createSystemAccountStmt, err = db.PrepareContext(ctx, createSystemAccount); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateSystemAccount: %w", err)
+	}
 	if q.createTransferStmt, err = db.PrepareContext(ctx, createTransfer); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateTransfer: %w", err)
 	}
+	if q.createTransferReversalStmt, err = db.PrepareContext(ctx, createTransferReversal); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateTransferReversal: %w", err)
+	}
 	if q.createUserStmt, err = db.PrepareContext(ctx, createUser); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateUser: %w", err)
 	}
+	if q.createWebhookDeliveriesStmt, err = db.PrepareContext(ctx, createWebhookDeliveries); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateWebhookDeliveries: %w", err)
+	}
+	if q.createWebhookEndpointStmt, err = db.PrepareContext(ctx, createWebhookEndpoint); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateWebhookEndpoint: %w", err)
+	}
+	if q.deactivateWebhookEndpointStmt, err = db.PrepareContext(ctx, deactivateWebhookEndpoint); err != nil {
+		return nil, fmt.Errorf("error preparing query DeactivateWebhookEndpoint: %w", err)
+	}
 	if q.deleteAccountStmt, err = db.PrepareContext(ctx, deleteAccount); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteAccount: %w", err)
 	}
+	if q.deleteExpiredIdempotencyKeysStmt, err = db.PrepareContext(ctx, deleteExpiredIdempotencyKeys); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteExpiredIdempotencyKeys: %w", err)
+	}
+	if q.deleteExpiredRevokedTokensStmt, err = db.PrepareContext(ctx, deleteExpiredRevokedTokens); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteExpiredRevokedTokens: %w", err)
+	}
+	if q.deleteIdempotencyKeyStmt, err = db.PrepareContext(ctx, deleteIdempotencyKey); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteIdempotencyKey: %w", err)
+	}
+	if q.expireHoldsStmt, err = db.PrepareContext(ctx, expireHolds); err != nil {
+		return nil, fmt.Errorf("error preparing query ExpireHolds: %w", err)
+	}
 	if q.getAccountStmt, err = db.PrepareContext(ctx, getAccount); err != nil {
 		return nil, fmt.Errorf("error preparing query GetAccount: %w", err)
 	}
+	if q.getAccountBalanceAtStmt, err = db.PrepareContext(ctx, getAccountBalanceAt); err != nil {
+		return nil, fmt.Errorf("error preparing query GetAccountBalanceAt: %w", err)
+	}
 	if q.getAccountForUpdateStmt, err = db.PrepareContext(ctx, getAccountForUpdate); err != nil {
 		return nil, fmt.Errorf("error preparing query GetAccountForUpdate: %w", err)
 	}
+	if q.getCurrencyStmt, err = db.PrepareContext(ctx, getCurrency); err != nil {
+		return nil, fmt.Errorf("error preparing query GetCurrency: %w", err)
+	}
 	if q.getEntryStmt, err = db.PrepareContext(ctx, getEntry); err != nil {
 		return nil, fmt.Errorf("error preparing query GetEntry: %w", err)
 	}
+	if q.getEventStmt, err = db.PrepareContext(ctx, getEvent); err != nil {
+		return nil, fmt.Errorf("error preparing query GetEvent: %w", err)
+	}
+	if q.getHeldAmountStmt, err = db.PrepareContext(ctx, getHeldAmount); err != nil {
+		return nil, fmt.Errorf("error preparing query GetHeldAmount: %w", err)
+	}
+	if q.getHoldStmt, err = db.PrepareContext(ctx, getHold); err != nil {
+		return nil, fmt.Errorf("error preparing query GetHold: %w", err)
+	}
+	if q.getHoldForUpdateStmt, err = db.PrepareContext(ctx, getHoldForUpdate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetHoldForUpdate: %w", err)
+	}
+	if q.getIdempotencyKeyStmt, err = db.PrepareContext(ctx, getIdempotencyKey); err != nil {
+		return nil, fmt.Errorf("error preparing query GetIdempotencyKey: %w", err)
+	}
+	if q.getJournalStmt, err = db.PrepareContext(ctx, getJournal); err != nil {
+		return nil, fmt.Errorf("error preparing query GetJournal: %w", err)
+	}
+	if q.getReversedAmountStmt, err = db.PrepareContext(ctx, getReversedAmount); err != nil {
+		return nil, fmt.Errorf("error preparing query GetReversedAmount: %w", err)
+	}
+	if q.getScheduledTransferStmt, err = db.PrepareContext(ctx, getScheduledTransfer); err != nil {
+		return nil, fmt.Errorf("error preparing query GetScheduledTransfer: %w", err)
+	}
+	if q.getScheduledTransferForUpdateStmt, err = db.PrepareContext(ctx, getScheduledTransferForUpdate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetScheduledTransferForUpdate: %w", err)
+	}
+	if q.getSessionStmt, err = db.PrepareContext(ctx, getSession); err != nil {
+		return nil, fmt.Errorf("error preparing query GetSession: %w", err)
+	}
+	if q.getSystemAccountStmt, err = db.PrepareContext(ctx, getSystemAccount); err != nil {
+		return nil, fmt.Errorf("error preparing query GetSystemAccount: %w", err)
+	}
 	if q.getTransferStmt, err = db.PrepareContext(ctx, getTransfer); err != nil {
 		return nil, fmt.Errorf("error preparing query GetTransfer: %w", err)
 	}
+	if q.getTransferForUpdateStmt, err = db.PrepareContext(ctx, getTransferForUpdate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTransferForUpdate: %w", err)
+	}
 	if q.getUserStmt, err = db.PrepareContext(ctx, getUser); err != nil {
 		return nil, fmt.Errorf("error preparing query GetUser: %w", err)
 	}
+	if q.getUserTokenRevocationStmt, err = db.PrepareContext(ctx, getUserTokenRevocation); err != nil {
+		return nil, fmt.Errorf("error preparing query GetUserTokenRevocation: %w", err)
+	}
+	if q.getWebhookDeliveryStmt, err = db.PrepareContext(ctx, getWebhookDelivery); err != nil {
+		return nil, fmt.Errorf("error preparing query GetWebhookDelivery: %w", err)
+	}
+	if q.getWebhookEndpointStmt, err = db.PrepareContext(ctx, getWebhookEndpoint); err != nil {
+		return nil, fmt.Errorf("error preparing query GetWebhookEndpoint: %w", err)
+	}
+	if q.isReversalTransferStmt, err = db.PrepareContext(ctx, isReversalTransfer); err != nil {
+		return nil, fmt.Errorf("error preparing query IsReversalTransfer: %w", err)
+	}
+	if q.isTokenRevokedStmt, err = db.PrepareContext(ctx, isTokenRevoked); err != nil {
+		return nil, fmt.Errorf("error preparing query IsTokenRevoked: %w", err)
+	}
+	if q.listAccountBalanceDriftStmt, err = db.PrepareContext(ctx, listAccountBalanceDrift); err != nil {
+		return nil, fmt.Errorf("error preparing query ListAccountBalanceDrift: %w", err)
+	}
+	if q.listAccountEntriesStmt, err = db.PrepareContext(ctx, listAccountEntries); err != nil {
+		return nil, fmt.Errorf("error preparing query ListAccountEntries: %w", err)
+	}
+	if q.listAccountEventsStmt, err = db.PrepareContext(ctx, listAccountEvents); err != nil {
+		return nil, fmt.Errorf("error preparing query ListAccountEvents: %w", err)
+	}
+	if q.listAccountTransfersStmt, err = db.PrepareContext(ctx, listAccountTransfers); err != nil {
+		return nil, fmt.Errorf("error preparing query ListAccountTransfers: %w", err)
+	}
 	if q.listAccountsStmt, err = db.PrepareContext(ctx, listAccounts); err != nil {
 		return nil, fmt.Errorf("error preparing query ListAccounts: %w", err)
 	}
+	if q.listCurrenciesStmt, err = db.PrepareContext(ctx, listCurrencies); err != nil {
+		return nil, fmt.Errorf("error preparing query ListCurrencies: %w", err)
+	}
 	if q.listEntriesStmt, err = db.PrepareContext(ctx, listEntries); err != nil {
 		return nil, fmt.Errorf("error preparing query ListEntries: %w", err)
 	}
+	if q.listInterestAccountsStmt, err = db.PrepareContext(ctx, listInterestAccounts); err != nil {
+		return nil, fmt.Errorf("error preparing query ListInterestAccounts: %w", err)
+	}
+	if q.listInterestAccrualsStmt, err = db.PrepareContext(ctx, listInterestAccruals); err != nil {
+		return nil, fmt.Errorf("error preparing query ListInterestAccruals: %w", err)
+	}
+	if q.listJournalEntriesStmt, err = db.PrepareContext(ctx, listJournalEntries); err != nil {
+		return nil, fmt.Errorf("error preparing query ListJournalEntries: %w", err)
+	}
+	if q.listScheduledTransferRunsStmt, err = db.PrepareContext(ctx, listScheduledTransferRuns); err != nil {
+		return nil, fmt.Errorf("error preparing query ListScheduledTransferRuns: %w", err)
+	}
+	if q.listScheduledTransfersStmt, err = db.PrepareContext(ctx, listScheduledTransfers); err != nil {
+		return nil, fmt.Errorf("error preparing query ListScheduledTransfers: %w", err)
+	}
+	if q.listStatementEntriesStmt, err = db.PrepareContext(ctx, listStatementEntries); err != nil {
+		return nil, fmt.Errorf("error preparing query ListStatementEntries: %w", err)
+	}
+	if q.listTransferDriftStmt, err = db.PrepareContext(ctx, listTransferDrift); err != nil {
+		return nil, fmt.Errorf("error preparing query ListTransferDrift: %w", err)
+	}
+	if q.listTransferEntriesStmt, err = db.PrepareContext(ctx, listTransferEntries); err != nil {
+		return nil, fmt.Errorf("error preparing query ListTransferEntries: %w", err)
+	}
+	if q.listTransferReversalsStmt, err = db.PrepareContext(ctx, listTransferReversals); err != nil {
+		return nil, fmt.Errorf("error preparing query ListTransferReversals: %w", err)
+	}
 	if q.listTransfersStmt, err = db.PrepareContext(ctx, listTransfers); err != nil {
 		return nil, fmt.Errorf("error preparing query ListTransfers: %w", err)
 	}
+	if q.listUnpostedInterestAccountsStmt, err = db.PrepareContext(ctx, listUnpostedInterestAccounts); err != nil {
+		return nil, fmt.Errorf("error preparing query ListUnpostedInterestAccounts: %w", err)
+	}
+	if q.listUnpostedInterestAccrualsForUpdateStmt, err = db.PrepareContext(ctx, listUnpostedInterestAccrualsForUpdate); err != nil {
+		return nil, fmt.Errorf("error preparing query ListUnpostedInterestAccrualsForUpdate: %w", err)
+	}
+	if q.listWebhookDeliveriesStmt, err = db.PrepareContext(ctx, listWebhookDeliveries); err != nil {
+		return nil, fmt.Errorf("error preparing query ListWebhookDeliveries: %w", err)
+	}
+	if q.listWebhookEndpointsStmt, err = db.PrepareContext(ctx, listWebhookEndpoints); err != nil {
+		return nil, fmt.Errorf("error preparing query ListWebhookEndpoints: %w", err)
+	}
+	if q.lockEventRelayStmt, err = db.PrepareContext(ctx, lockEventRelay); err != nil {
+		return nil, fmt.Errorf("error preparing query LockEventRelay: %w", err)
+	}
+	if q.markEventPublishedStmt, err = db.PrepareContext(ctx, markEventPublished); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkEventPublished: %w", err)
+	}
+	if q.markInterestAccrualsPostedStmt, err = db.PrepareContext(ctx, markInterestAccrualsPosted); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkInterestAccrualsPosted: %w", err)
+	}
+	if q.recordEventFailureStmt, err = db.PrepareContext(ctx, recordEventFailure); err != nil {
+		return nil, fmt.Errorf("error preparing query RecordEventFailure: %w", err)
+	}
+	if q.recordWebhookDeliveryAttemptStmt, err = db.PrepareContext(ctx, recordWebhookDeliveryAttempt); err != nil {
+		return nil, fmt.Errorf("error preparing query RecordWebhookDeliveryAttempt: %w", err)
+	}
+	if q.releaseEventsStmt, err = db.PrepareContext(ctx, releaseEvents); err != nil {
+		return nil, fmt.Errorf("error preparing query ReleaseEvents: %w", err)
+	}
+	if q.replayWebhookDeliveryStmt, err = db.PrepareContext(ctx, replayWebhookDelivery); err != nil {
+		return nil, fmt.Errorf("error preparing query ReplayWebhookDelivery: %w", err)
+	}
+	if q.rescheduleScheduledTransferStmt, err = db.PrepareContext(ctx, rescheduleScheduledTransfer); err != nil {
+		return nil, fmt.Errorf("error preparing query RescheduleScheduledTransfer: %w", err)
+	}
 	if q.updateAccountStmt, err = db.PrepareContext(ctx, updateAccount); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateAccount: %w", err)
 	}
+	if q.updateAccountOverdraftLimitStmt, err = db.PrepareContext(ctx, updateAccountOverdraftLimit); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateAccountOverdraftLimit: %w", err)
+	}
+	if q.updateAccountStatusStmt, err = db.PrepareContext(ctx, updateAccountStatus); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateAccountStatus: %w", err)
+	}
+	if q.updateCurrencyEnabledStmt, err = db.PrepareContext(ctx, updateCurrencyEnabled); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateCurrencyEnabled: %w", err)
+	}
+	if q.updateHoldStatusStmt, err = db.PrepareContext(ctx, updateHoldStatus); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateHoldStatus: %w", err)
+	}
+	if q.updateHoldTransferStmt, err = db.PrepareContext(ctx, updateHoldTransfer); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateHoldTransfer: %w", err)
+	}
+	if q.updateIdempotencyKeyResponseStmt, err = db.PrepareContext(ctx, updateIdempotencyKeyResponse); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateIdempotencyKeyResponse: %w", err)
+	}
+	if q.updateScheduledTransferStmt, err = db.PrepareContext(ctx, updateScheduledTransfer); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateScheduledTransfer: %w", err)
+	}
+	if q.updateScheduledTransferStatusStmt, err = db.PrepareContext(ctx, updateScheduledTransferStatus); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateScheduledTransferStatus: %w", err)
+	}
+	if q.upsertUserTokenRevocationStmt, err = db.PrepareContext(ctx, upsertUserTokenRevocation); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertUserTokenRevocation: %w", err)
+	}
 	return &q, nil
 }
 
@@ -77,6 +311,31 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing addAccountBalanceStmt: %w", cerr)
 		}
 	}
+	if q.blockSessionStmt != nil {
+		if cerr := q.blockSessionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing blockSessionStmt: %w", cerr)
+		}
+	}
+	if q.blockUserSessionsStmt != nil {
+		if cerr := q.blockUserSessionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing blockUserSessionsStmt: %w", cerr)
+		}
+	}
+	if q.claimDueScheduledTransfersStmt != nil {
+		if cerr := q.claimDueScheduledTransfersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing claimDueScheduledTransfersStmt: %w", cerr)
+		}
+	}
+	if q.claimDueWebhookDeliveriesStmt != nil {
+		if cerr := q.claimDueWebhookDeliveriesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing claimDueWebhookDeliveriesStmt: %w", cerr)
+		}
+	}
+	if q.claimUnpublishedEventsStmt != nil {
+		if cerr := q.claimUnpublishedEventsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing claimUnpublishedEventsStmt: %w", cerr)
+		}
+	}
 	if q.createAccountStmt != nil {
 		if cerr := q.createAccountStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createAccountStmt: %w", cerr)
@@ -87,66 +346,431 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing createEntryStmt: %w", cerr)
 		}
 	}
+	if q.createEventStmt != nil {
+		if cerr := q.createEventStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createEventStmt: %w", cerr)
+		}
+	}
+	if q.createHoldStmt != nil {
+		if cerr := q.createHoldStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createHoldStmt: %w", cerr)
+		}
+	}
+	if q.createIdempotencyKeyStmt != nil {
+		if cerr := q.createIdempotencyKeyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createIdempotencyKeyStmt: %w", cerr)
+		}
+	}
+	if q.createInterestAccrualStmt != nil {
+		if cerr := q.createInterestAccrualStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createInterestAccrualStmt: %w", cerr)
+		}
+	}
+	if q.createInterestCarryStmt != nil {
+		if cerr := q.createInterestCarryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createInterestCarryStmt: %w", cerr)
+		}
+	}
+	if q.createJournalStmt != nil {
+		if cerr := q.createJournalStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createJournalStmt: %w", cerr)
+		}
+	}
+	if q.createRevokedTokenStmt != nil {
+		if cerr := q.createRevokedTokenStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createRevokedTokenStmt: %w", cerr)
+		}
+	}
+	if q.createScheduledTransferStmt != nil {
+		if cerr := q.createScheduledTransferStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createScheduledTransferStmt: %w", cerr)
+		}
+	}
+	if q.createScheduledTransferRunStmt != nil {
+		if cerr := q.createScheduledTransferRunStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createScheduledTransferRunStmt: %w", cerr)
+		}
+	}
+	if q.createSessionStmt != nil {
+		if cerr := q.createSessionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createSessionStmt: %w", cerr)
+		}
+	}
+	if q.createSystemAccountStmt != nil {
+		if cerr := q.createSystemAccountStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createSystemAccountStmt: %w", cerr)
+		}
+	}
 	if q.createTransferStmt != nil {
 		if cerr := q.createTransferStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createTransferStmt: %w", cerr)
 		}
 	}
+	if q.createTransferReversalStmt != nil {
+		if cerr := q.createTransferReversalStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createTransferReversalStmt: %w", cerr)
+		}
+	}
 	if q.createUserStmt != nil {
 		if cerr := q.createUserStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createUserStmt: %w", cerr)
 		}
 	}
+	if q.createWebhookDeliveriesStmt != nil {
+		if cerr := q.createWebhookDeliveriesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createWebhookDeliveriesStmt: %w", cerr)
+		}
+	}
+	if q.createWebhookEndpointStmt != nil {
+		if cerr := q.createWebhookEndpointStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createWebhookEndpointStmt: %w", cerr)
+		}
+	}
+	if q.deactivateWebhookEndpointStmt != nil {
+		if cerr := q.deactivateWebhookEndpointStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deactivateWebhookEndpointStmt: %w", cerr)
+		}
+	}
 	if q.deleteAccountStmt != nil {
 		if cerr := q.deleteAccountStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteAccountStmt: %w", cerr)
 		}
 	}
+	if q.deleteExpiredIdempotencyKeysStmt != nil {
+		if cerr := q.deleteExpiredIdempotencyKeysStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteExpiredIdempotencyKeysStmt: %w", cerr)
+		}
+	}
+	if q.deleteExpiredRevokedTokensStmt != nil {
+		if cerr := q.deleteExpiredRevokedTokensStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteExpiredRevokedTokensStmt: %w", cerr)
+		}
+	}
+	if q.deleteIdempotencyKeyStmt != nil {
+		if cerr := q.deleteIdempotencyKeyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteIdempotencyKeyStmt: %w", cerr)
+		}
+	}
+	if q.expireHoldsStmt != nil {
+		if cerr := q.expireHoldsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing expireHoldsStmt: %w", cerr)
+		}
+	}
 	if q.getAccountStmt != nil {
 		if cerr := q.getAccountStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getAccountStmt: %w", cerr)
 		}
 	}
+	if q.getAccountBalanceAtStmt != nil {
+		if cerr := q.getAccountBalanceAtStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getAccountBalanceAtStmt: %w", cerr)
+		}
+	}
 	if q.getAccountForUpdateStmt != nil {
 		if cerr := q.getAccountForUpdateStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getAccountForUpdateStmt: %w", cerr)
 		}
 	}
+	if q.getCurrencyStmt != nil {
+		if cerr := q.getCurrencyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getCurrencyStmt: %w", cerr)
+		}
+	}
 	if q.getEntryStmt != nil {
 		if cerr := q.getEntryStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getEntryStmt: %w", cerr)
 		}
 	}
+	if q.getEventStmt != nil {
+		if cerr := q.getEventStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getEventStmt: %w", cerr)
+		}
+	}
+	if q.getHeldAmountStmt != nil {
+		if cerr := q.getHeldAmountStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getHeldAmountStmt: %w", cerr)
+		}
+	}
+	if q.getHoldStmt != nil {
+		if cerr := q.getHoldStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getHoldStmt: %w", cerr)
+		}
+	}
+	if q.getHoldForUpdateStmt != nil {
+		if cerr := q.getHoldForUpdateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getHoldForUpdateStmt: %w", cerr)
+		}
+	}
+	if q.getIdempotencyKeyStmt != nil {
+		if cerr := q.getIdempotencyKeyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getIdempotencyKeyStmt: %w", cerr)
+		}
+	}
+	if q.getJournalStmt != nil {
+		if cerr := q.getJournalStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getJournalStmt: %w", cerr)
+		}
+	}
+	if q.getReversedAmountStmt != nil {
+		if cerr := q.getReversedAmountStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getReversedAmountStmt: %w", cerr)
+		}
+	}
+	if q.getScheduledTransferStmt != nil {
+		if cerr := q.getScheduledTransferStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getScheduledTransferStmt: %w", cerr)
+		}
+	}
+	if q.getScheduledTransferForUpdateStmt != nil {
+		if cerr := q.getScheduledTransferForUpdateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getScheduledTransferForUpdateStmt: %w", cerr)
+		}
+	}
+	if q.getSessionStmt != nil {
+		if cerr := q.getSessionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getSessionStmt: %w", cerr)
+		}
+	}
+	if q.getSystemAccountStmt != nil {
+		if cerr := q.getSystemAccountStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getSystemAccountStmt: %w", cerr)
+		}
+	}
 	if q.getTransferStmt != nil {
 		if cerr := q.getTransferStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getTransferStmt: %w", cerr)
 		}
 	}
+	if q.getTransferForUpdateStmt != nil {
+		if cerr := q.getTransferForUpdateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTransferForUpdateStmt: %w", cerr)
+		}
+	}
 	if q.getUserStmt != nil {
 		if cerr := q.getUserStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getUserStmt: %w", cerr)
 		}
 	}
+	if q.getUserTokenRevocationStmt != nil {
+		if cerr := q.getUserTokenRevocationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getUserTokenRevocationStmt: %w", cerr)
+		}
+	}
+	if q.getWebhookDeliveryStmt != nil {
+		if cerr := q.getWebhookDeliveryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getWebhookDeliveryStmt: %w", cerr)
+		}
+	}
+	if q.getWebhookEndpointStmt != nil {
+		if cerr := q.getWebhookEndpointStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getWebhookEndpointStmt: %w", cerr)
+		}
+	}
+	if q.isReversalTransferStmt != nil {
+		if cerr := q.isReversalTransferStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing isReversalTransferStmt: %w", cerr)
+		}
+	}
+	if q.isTokenRevokedStmt != nil {
+		if cerr := q.isTokenRevokedStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing isTokenRevokedStmt: %w", cerr)
+		}
+	}
+	if q.listAccountBalanceDriftStmt != nil {
+		if cerr := q.listAccountBalanceDriftStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listAccountBalanceDriftStmt: %w", cerr)
+		}
+	}
+	if q.listAccountEntriesStmt != nil {
+		if cerr := q.listAccountEntriesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listAccountEntriesStmt: %w", cerr)
+		}
+	}
+	if q.listAccountEventsStmt != nil {
+		if cerr := q.listAccountEventsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listAccountEventsStmt: %w", cerr)
+		}
+	}
+	if q.listAccountTransfersStmt != nil {
+		if cerr := q.listAccountTransfersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listAccountTransfersStmt: %w", cerr)
+		}
+	}
 	if q.listAccountsStmt != nil {
 		if cerr := q.listAccountsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listAccountsStmt: %w", cerr)
 		}
 	}
+	if q.listCurrenciesStmt != nil {
+		if cerr := q.listCurrenciesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listCurrenciesStmt: %w", cerr)
+		}
+	}
 	if q.listEntriesStmt != nil {
 		if cerr := q.listEntriesStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listEntriesStmt: %w", cerr)
 		}
 	}
+	if q.listInterestAccountsStmt != nil {
+		if cerr := q.listInterestAccountsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listInterestAccountsStmt: %w", cerr)
+		}
+	}
+	if q.listInterestAccrualsStmt != nil {
+		if cerr := q.listInterestAccrualsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listInterestAccrualsStmt: %w", cerr)
+		}
+	}
+	if q.listJournalEntriesStmt != nil {
+		if cerr := q.listJournalEntriesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listJournalEntriesStmt: %w", cerr)
+		}
+	}
+	if q.listScheduledTransferRunsStmt != nil {
+		if cerr := q.listScheduledTransferRunsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listScheduledTransferRunsStmt: %w", cerr)
+		}
+	}
+	if q.listScheduledTransfersStmt != nil {
+		if cerr := q.listScheduledTransfersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listScheduledTransfersStmt: %w", cerr)
+		}
+	}
+	if q.listStatementEntriesStmt != nil {
+		if cerr := q.listStatementEntriesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listStatementEntriesStmt: %w", cerr)
+		}
+	}
+	if q.listTransferDriftStmt != nil {
+		if cerr := q.listTransferDriftStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listTransferDriftStmt: %w", cerr)
+		}
+	}
+	if q.listTransferEntriesStmt != nil {
+		if cerr := q.listTransferEntriesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listTransferEntriesStmt: %w", cerr)
+		}
+	}
+	if q.listTransferReversalsStmt != nil {
+		if cerr := q.listTransferReversalsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listTransferReversalsStmt: %w", cerr)
+		}
+	}
 	if q.listTransfersStmt != nil {
 		if cerr := q.listTransfersStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listTransfersStmt: %w", cerr)
 		}
 	}
+	if q.listUnpostedInterestAccountsStmt != nil {
+		if cerr := q.listUnpostedInterestAccountsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listUnpostedInterestAccountsStmt: %w", cerr)
+		}
+	}
+	if q.listUnpostedInterestAccrualsForUpdateStmt != nil {
+		if cerr := q.listUnpostedInterestAccrualsForUpdateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listUnpostedInterestAccrualsForUpdateStmt: %w", cerr)
+		}
+	}
+	if q.listWebhookDeliveriesStmt != nil {
+		if cerr := q.listWebhookDeliveriesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listWebhookDeliveriesStmt: %w", cerr)
+		}
+	}
+	if q.listWebhookEndpointsStmt != nil {
+		if cerr := q.listWebhookEndpointsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listWebhookEndpointsStmt: %w", cerr)
+		}
+	}
+	if q.lockEventRelayStmt != nil {
+		if cerr := q.lockEventRelayStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing lockEventRelayStmt: %w", cerr)
+		}
+	}
+	if q.markEventPublishedStmt != nil {
+		if cerr := q.markEventPublishedStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markEventPublishedStmt: %w", cerr)
+		}
+	}
+	if q.markInterestAccrualsPostedStmt != nil {
+		if cerr := q.markInterestAccrualsPostedStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markInterestAccrualsPostedStmt: %w", cerr)
+		}
+	}
+	if q.recordEventFailureStmt != nil {
+		if cerr := q.recordEventFailureStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing recordEventFailureStmt: %w", cerr)
+		}
+	}
+	if q.recordWebhookDeliveryAttemptStmt != nil {
+		if cerr := q.recordWebhookDeliveryAttemptStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing recordWebhookDeliveryAttemptStmt: %w", cerr)
+		}
+	}
+	if q.releaseEventsStmt != nil {
+		if cerr := q.releaseEventsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing releaseEventsStmt: %w", cerr)
+		}
+	}
+	if q.replayWebhookDeliveryStmt != nil {
+		if cerr := q.replayWebhookDeliveryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing replayWebhookDeliveryStmt: %w", cerr)
+		}
+	}
+	if q.rescheduleScheduledTransferStmt != nil {
+		if cerr := q.rescheduleScheduledTransferStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing rescheduleScheduledTransferStmt: %w", cerr)
+		}
+	}
 	if q.updateAccountStmt != nil {
 		if cerr := q.updateAccountStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateAccountStmt: %w", cerr)
 		}
 	}
+	if q.updateAccountOverdraftLimitStmt != nil {
+		if cerr := q.updateAccountOverdraftLimitStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateAccountOverdraftLimitStmt: %w", cerr)
+		}
+	}
+	if q.updateAccountStatusStmt != nil {
+		if cerr := q.updateAccountStatusStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateAccountStatusStmt: %w", cerr)
+		}
+	}
+	if q.updateCurrencyEnabledStmt != nil {
+		if cerr := q.updateCurrencyEnabledStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateCurrencyEnabledStmt: %w", cerr)
+		}
+	}
+	if q.updateHoldStatusStmt != nil {
+		if cerr := q.updateHoldStatusStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateHoldStatusStmt: %w", cerr)
+		}
+	}
+	if q.updateHoldTransferStmt != nil {
+		if cerr := q.updateHoldTransferStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateHoldTransferStmt: %w", cerr)
+		}
+	}
+	if q.updateIdempotencyKeyResponseStmt != nil {
+		if cerr := q.updateIdempotencyKeyResponseStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateIdempotencyKeyResponseStmt: %w", cerr)
+		}
+	}
+	if q.updateScheduledTransferStmt != nil {
+		if cerr := q.updateScheduledTransferStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateScheduledTransferStmt: %w", cerr)
+		}
+	}
+	if q.updateScheduledTransferStatusStmt != nil {
+		if cerr := q.updateScheduledTransferStatusStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateScheduledTransferStatusStmt: %w", cerr)
+		}
+	}
+	if q.upsertUserTokenRevocationStmt != nil {
+		if cerr := q.upsertUserTokenRevocationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertUserTokenRevocationStmt: %w", cerr)
+		}
+	}
 	return err
 }
 
@@ -184,43 +808,199 @@ func (q *Queries) queryRow(ctx context.Context, stmt *sql.Stmt, query string, ar
 }
 
 type Queries struct {
-	db                      DBTX
-	tx                      *sql.Tx
-	addAccountBalanceStmt   *sql.Stmt
-	createAccountStmt       *sql.Stmt
-	createEntryStmt         *sql.Stmt
-	createTransferStmt      *sql.Stmt
-	createUserStmt          *sql.Stmt
-	deleteAccountStmt       *sql.Stmt
-	getAccountStmt          *sql.Stmt
-	getAccountForUpdateStmt *sql.Stmt
-	getEntryStmt            *sql.Stmt
-	getTransferStmt         *sql.Stmt
-	getUserStmt             *sql.Stmt
-	listAccountsStmt        *sql.Stmt
-	listEntriesStmt         *sql.Stmt
-	listTransfersStmt       *sql.Stmt
-	updateAccountStmt       *sql.Stmt
+	db                                        DBTX
+	tx                                        *sql.Tx
+	addAccountBalanceStmt                     *sql.Stmt
+	blockSessionStmt                          *sql.Stmt
+	blockUserSessionsStmt                     *sql.Stmt
+	claimDueScheduledTransfersStmt            *sql.Stmt
+	claimDueWebhookDeliveriesStmt             *sql.Stmt
+	claimUnpublishedEventsStmt                *sql.Stmt
+	createAccountStmt                         *sql.Stmt
+	createEntryStmt                           *sql.Stmt
+	createEventStmt                           *sql.Stmt
+	createHoldStmt                            *sql.Stmt
+	createIdempotencyKeyStmt                  *sql.Stmt
+	createInterestAccrualStmt                 *sql.Stmt
+	createInterestCarryStmt                   *sql.Stmt
+	createJournalStmt                         *sql.Stmt
+	createRevokedTokenStmt                    *sql.Stmt
+	createScheduledTransferStmt               *sql.Stmt
+	createScheduledTransferRunStmt            *sql.Stmt
+	createSessionStmt                         *sql.Stmt
+	createSystemAccountStmt                   *sql.Stmt
+	createTransferStmt                        *sql.Stmt
+	createTransferReversalStmt                *sql.Stmt
+	createUserStmt                            *sql.Stmt
+	createWebhookDeliveriesStmt               *sql.Stmt
+	createWebhookEndpointStmt                 *sql.Stmt
+	deactivateWebhookEndpointStmt             *sql.Stmt
+	deleteAccountStmt                         *sql.Stmt
+	deleteExpiredIdempotencyKeysStmt          *sql.Stmt
+	deleteExpiredRevokedTokensStmt            *sql.Stmt
+	deleteIdempotencyKeyStmt                  *sql.Stmt
+	expireHoldsStmt                           *sql.Stmt
+	getAccountStmt                            *sql.Stmt
+	getAccountBalanceAtStmt                   *sql.Stmt
+	getAccountForUpdateStmt                   *sql.Stmt
+	getCurrencyStmt                           *sql.Stmt
+	getEntryStmt                              *sql.Stmt
+	getEventStmt                              *sql.Stmt
+	getHeldAmountStmt                         *sql.Stmt
+	getHoldStmt                               *sql.Stmt
+	getHoldForUpdateStmt                      *sql.Stmt
+	getIdempotencyKeyStmt                     *sql.Stmt
+	getJournalStmt                            *sql.Stmt
+	getReversedAmountStmt                     *sql.Stmt
+	getScheduledTransferStmt                  *sql.Stmt
+	getScheduledTransferForUpdateStmt         *sql.Stmt
+	getSessionStmt                            *sql.Stmt
+	getSystemAccountStmt                      *sql.Stmt
+	getTransferStmt                           *sql.Stmt
+	getTransferForUpdateStmt                  *sql.Stmt
+	getUserStmt                               *sql.Stmt
+	getUserTokenRevocationStmt                *sql.Stmt
+	getWebhookDeliveryStmt                    *sql.Stmt
+	getWebhookEndpointStmt                    *sql.Stmt
+	isReversalTransferStmt                    *sql.Stmt
+	isTokenRevokedStmt                        *sql.Stmt
+	listAccountBalanceDriftStmt               *sql.Stmt
+	listAccountEntriesStmt                    *sql.Stmt
+	listAccountEventsStmt                     *sql.Stmt
+	listAccountTransfersStmt                  *sql.Stmt
+	listAccountsStmt                          *sql.Stmt
+	listCurrenciesStmt                        *sql.Stmt
+	listEntriesStmt                           *sql.Stmt
+	listInterestAccountsStmt                  *sql.Stmt
+	listInterestAccrualsStmt                  *sql.Stmt
+	listJournalEntriesStmt                    *sql.Stmt
+	listScheduledTransferRunsStmt             *sql.Stmt
+	listScheduledTransfersStmt                *sql.Stmt
+	listStatementEntriesStmt                  *sql.Stmt
+	listTransferDriftStmt                     *sql.Stmt
+	listTransferEntriesStmt                   *sql.Stmt
+	listTransferReversalsStmt                 *sql.Stmt
+	listTransfersStmt                         *sql.Stmt
+	listUnpostedInterestAccountsStmt          *sql.Stmt
+	listUnpostedInterestAccrualsForUpdateStmt *sql.Stmt
+	listWebhookDeliveriesStmt                 *sql.Stmt
+	listWebhookEndpointsStmt                  *sql.Stmt
+	lockEventRelayStmt                        *sql.Stmt
+	markEventPublishedStmt                    *sql.Stmt
+	markInterestAccrualsPostedStmt            *sql.Stmt
+	recordEventFailureStmt                    *sql.Stmt
+	recordWebhookDeliveryAttemptStmt          *sql.Stmt
+	releaseEventsStmt                         *sql.Stmt
+	replayWebhookDeliveryStmt                 *sql.Stmt
+	rescheduleScheduledTransferStmt           *sql.Stmt
+	updateAccountStmt                         *sql.Stmt
+	updateAccountOverdraftLimitStmt           *sql.Stmt
+	updateAccountStatusStmt                   *sql.Stmt
+	updateCurrencyEnabledStmt                 *sql.Stmt
+	updateHoldStatusStmt                      *sql.Stmt
+	updateHoldTransferStmt                    *sql.Stmt
+	updateIdempotencyKeyResponseStmt          *sql.Stmt
+	updateScheduledTransferStmt               *sql.Stmt
+	updateScheduledTransferStatusStmt         *sql.Stmt
+	upsertUserTokenRevocationStmt             *sql.Stmt
 }
 
 func (q *Queries) WithTx(tx *sql.Tx) *Queries {
 	return &Queries{
-		db:                      tx,
-		tx:                      tx,
-		addAccountBalanceStmt:   q.addAccountBalanceStmt,
-		createAccountStmt:       q.createAccountStmt,
-		createEntryStmt:         q.createEntryStmt,
-		createTransferStmt:      q.createTransferStmt,
-		createUserStmt:          q.createUserStmt,
-		deleteAccountStmt:       q.deleteAccountStmt,
-		getAccountStmt:          q.getAccountStmt,
-		getAccountForUpdateStmt: q.getAccountForUpdateStmt,
-		getEntryStmt:            q.getEntryStmt,
-		getTransferStmt:         q.getTransferStmt,
-		getUserStmt:             q.getUserStmt,
-		listAccountsStmt:        q.listAccountsStmt,
-		listEntriesStmt:         q.listEntriesStmt,
-		listTransfersStmt:       q.listTransfersStmt,
-		updateAccountStmt:       q.updateAccountStmt,
+		db:                                        tx,
+		tx:                                        tx,
+		addAccountBalanceStmt:                     q.addAccountBalanceStmt,
+		blockSessionStmt:                          q.blockSessionStmt,
+		blockUserSessionsStmt:                     q.blockUserSessionsStmt,
+		claimDueScheduledTransfersStmt:            q.claimDueScheduledTransfersStmt,
+		claimDueWebhookDeliveriesStmt:             q.claimDueWebhookDeliveriesStmt,
+		claimUnpublishedEventsStmt:                q.claimUnpublishedEventsStmt,
+		createAccountStmt:                         q.createAccountStmt,
+		createEntryStmt:                           q.createEntryStmt,
+		createEventStmt:                           q.createEventStmt,
+		createHoldStmt:                            q.createHoldStmt,
+		createIdempotencyKeyStmt:                  q.createIdempotencyKeyStmt,
+		createInterestAccrualStmt:                 q.createInterestAccrualStmt,
+		createInterestCarryStmt:                   q.createInterestCarryStmt,
+		createJournalStmt:                         q.createJournalStmt,
+		createRevokedTokenStmt:                    q.createRevokedTokenStmt,
+		createScheduledTransferStmt:               q.createScheduledTransferStmt,
+		createScheduledTransferRunStmt:            q.createScheduledTransferRunStmt,
+		createSessionStmt:                         q.createSessionStmt,
+		createSystemAccountStmt:                   q.createSystemAccountStmt,
+		createTransferStmt:                        q.createTransferStmt,
+		createTransferReversalStmt:                q.createTransferReversalStmt,
+		createUserStmt:                            q.createUserStmt,
+		createWebhookDeliveriesStmt:               q.createWebhookDeliveriesStmt,
+		createWebhookEndpointStmt:                 q.createWebhookEndpointStmt,
+		deactivateWebhookEndpointStmt:             q.deactivateWebhookEndpointStmt,
+		deleteAccountStmt:                         q.deleteAccountStmt,
+		deleteExpiredIdempotencyKeysStmt:          q.deleteExpiredIdempotencyKeysStmt,
+		deleteExpiredRevokedTokensStmt:            q.deleteExpiredRevokedTokensStmt,
+		deleteIdempotencyKeyStmt:                  q.deleteIdempotencyKeyStmt,
+		expireHoldsStmt:                           q.expireHoldsStmt,
+		getAccountStmt:                            q.getAccountStmt,
+		getAccountBalanceAtStmt:                   q.getAccountBalanceAtStmt,
+		getAccountForUpdateStmt:                   q.getAccountForUpdateStmt,
+		getCurrencyStmt:                           q.getCurrencyStmt,
+		getEntryStmt:                              q.getEntryStmt,
+		getEventStmt:                              q.getEventStmt,
+		getHeldAmountStmt:                         q.getHeldAmountStmt,
+		getHoldStmt:                               q.getHoldStmt,
+		getHoldForUpdateStmt:                      q.getHoldForUpdateStmt,
+		getIdempotencyKeyStmt:                     q.getIdempotencyKeyStmt,
+		getJournalStmt:                            q.getJournalStmt,
+		getReversedAmountStmt:                     q.getReversedAmountStmt,
+		getScheduledTransferStmt:                  q.getScheduledTransferStmt,
+		getScheduledTransferForUpdateStmt:         q.getScheduledTransferForUpdateStmt,
+		getSessionStmt:                            q.getSessionStmt,
+		getSystemAccountStmt:                      q.getSystemAccountStmt,
+		getTransferStmt:                           q.getTransferStmt,
+		getTransferForUpdateStmt:                  q.getTransferForUpdateStmt,
+		getUserStmt:                               q.getUserStmt,
+		getUserTokenRevocationStmt:                q.getUserTokenRevocationStmt,
+		getWebhookDeliveryStmt:                    q.getWebhookDeliveryStmt,
+		getWebhookEndpointStmt:                    q.getWebhookEndpointStmt,
+		isReversalTransferStmt:                    q.isReversalTransferStmt,
+		isTokenRevokedStmt:                        q.isTokenRevokedStmt,
+		listAccountBalanceDriftStmt:               q.listAccountBalanceDriftStmt,
+		listAccountEntriesStmt:                    q.listAccountEntriesStmt,
+		listAccountEventsStmt:                     q.listAccountEventsStmt,
+		listAccountTransfersStmt:                  q.listAccountTransfersStmt,
+		listAccountsStmt:                          q.listAccountsStmt,
+		listCurrenciesStmt:                        q.listCurrenciesStmt,
+		listEntriesStmt:                           q.listEntriesStmt,
+		listInterestAccountsStmt:                  q.listInterestAccountsStmt,
+		listInterestAccrualsStmt:                  q.listInterestAccrualsStmt,
+		listJournalEntriesStmt:                    q.listJournalEntriesStmt,
+		listScheduledTransferRunsStmt:             q.listScheduledTransferRunsStmt,
+		listScheduledTransfersStmt:                q.listScheduledTransfersStmt,
+		listStatementEntriesStmt:                  q.listStatementEntriesStmt,
+		listTransferDriftStmt:                     q.listTransferDriftStmt,
+		listTransferEntriesStmt:                   q.listTransferEntriesStmt,
+		listTransferReversalsStmt:                 q.listTransferReversalsStmt,
+		listTransfersStmt:                         q.listTransfersStmt,
+		listUnpostedInterestAccountsStmt:          q.listUnpostedInterestAccountsStmt,
+		listUnpostedInterestAccrualsForUpdateStmt: q.listUnpostedInterestAccrualsForUpdateStmt,
+		listWebhookDeliveriesStmt:                 q.listWebhookDeliveriesStmt,
+		listWebhookEndpointsStmt:                  q.listWebhookEndpointsStmt,
+		lockEventRelayStmt:                        q.lockEventRelayStmt,
+		markEventPublishedStmt:                    q.markEventPublishedStmt,
+		markInterestAccrualsPostedStmt:            q.markInterestAccrualsPostedStmt,
+		recordEventFailureStmt:                    q.recordEventFailureStmt,
+		recordWebhookDeliveryAttemptStmt:          q.recordWebhookDeliveryAttemptStmt,
+		releaseEventsStmt:                         q.releaseEventsStmt,
+		replayWebhookDeliveryStmt:                 q.replayWebhookDeliveryStmt,
+		rescheduleScheduledTransferStmt:           q.rescheduleScheduledTransferStmt,
+		updateAccountStmt:                         q.updateAccountStmt,
+		updateAccountOverdraftLimitStmt:           q.updateAccountOverdraftLimitStmt,
+		updateAccountStatusStmt:                   q.updateAccountStatusStmt,
+		updateCurrencyEnabledStmt:                 q.updateCurrencyEnabledStmt,
+		updateHoldStatusStmt:                      q.updateHoldStatusStmt,
+		updateHoldTransferStmt:                    q.updateHoldTransferStmt,
+		updateIdempotencyKeyResponseStmt:          q.updateIdempotencyKeyResponseStmt,
+		updateScheduledTransferStmt:               q.updateScheduledTransferStmt,
+		updateScheduledTransferStatusStmt:         q.updateScheduledTransferStatusStmt,
+		upsertUserTokenRevocationStmt:             q.upsertUserTokenRevocationStmt,
 	}
 }
@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"math"
 	"testing"
 	"time"
 
@@ -67,3 +68,77 @@ func TestListEntries(t *testing.T) {
 		require.Equal(t, entry.AccountID, account.ID)
 	}
 }
+
+func TestListAccountEntries(t *testing.T) {
+	account := createRandomAccount(t)
+	for i := 0; i < 6; i++ {
+		amount := util.RandomMoney() + 1
+		if i%2 == 1 {
+			amount = -amount
+		}
+		_, err := testQueries.CreateEntry(context.Background(), CreateEntryParams{
+			AccountID: account.ID,
+			Amount:    amount,
+		})
+		require.NoError(t, err)
+	}
+
+	arg := ListAccountEntriesParams{
+		AccountID: account.ID,
+		BeforeID:  math.MaxInt64,
+		ToTime:    time.Now().Add(time.Minute),
+		PageSize:  4,
+	}
+	page1, err := testQueries.ListAccountEntries(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, page1, 4)
+	for i := 1; i < len(page1); i++ {
+		require.Less(t, page1[i].ID, page1[i-1].ID)
+	}
+
+	arg.BeforeID = page1[len(page1)-1].ID
+	page2, err := testQueries.ListAccountEntries(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, page2, 2)
+
+	arg.BeforeID = math.MaxInt64
+	arg.Direction = "out"
+	entries, err := testQueries.ListAccountEntries(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	for _, entry := range entries {
+		require.Less(t, entry.Amount, int64(0))
+	}
+}
+
+func TestListStatementEntries(t *testing.T) {
+	store := NewStore(testDB)
+
+	account := createFundedAccount(t, 0)
+	for i := 0; i < 3; i++ {
+		_, err := store.DepositTx(context.Background(), DepositTxParams{
+			AccountID: account.ID,
+			Amount:    10,
+		})
+		require.NoError(t, err)
+	}
+
+	arg := ListStatementEntriesParams{
+		AccountID: account.ID,
+		ToTime:    time.Now().Add(time.Minute),
+		PageSize:  2,
+	}
+	page1, err := testQueries.ListStatementEntries(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, page1, 2)
+	require.Less(t, page1[0].ID, page1[1].ID)
+	for _, entry := range page1 {
+		require.Equal(t, int64(10), entry.Amount)
+		require.Equal(t, "deposit", entry.Description)
+	}
+
+	arg.AfterID = page1[1].ID
+	page2, err := testQueries.ListStatementEntries(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, page2, 1)
+}
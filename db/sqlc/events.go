@@ -0,0 +1,172 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+)
+
+// 领域事件类型，与账户变更写在同一个事务里，由 outbox 中继投递给其他服务
+const (
+	EventAccountCreated       = "account.created"
+	EventAccountStatusChanged = "account.status_changed"
+	EventBalanceChanged       = "account.balance_changed"
+	EventTransferCreated      = "transfer.created"
+	EventTransferReversed     = "transfer.reversed"
+)
+
+// EventTypes 所有的事件类型
+var EventTypes = []string{
+	EventAccountCreated,
+	EventAccountStatusChanged,
+	EventBalanceChanged,
+	EventTransferCreated,
+	EventTransferReversed,
+}
+
+// webhook 投递状态，pending 的投递按退避时间重试，重试次数用完后为 failed
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryFailed    = "failed"
+)
+
+// BalanceChangedEvent 账户余额变动，每条分录对应一个事件
+type BalanceChangedEvent struct {
+	AccountID   int64  `json:"account_id"`
+	JournalID   int64  `json:"journal_id"`
+	EntryID     int64  `json:"entry_id"`
+	TransferID  int64  `json:"transfer_id,omitempty"`
+	Description string `json:"description"`
+	Amount      int64  `json:"amount"`
+	Balance     int64  `json:"balance"`
+	Currency    string `json:"currency"`
+}
+
+// recordEvent 在事务 q 中写入一条待投递的事件。
+// 调用方应先锁定 accountID 对应的账户，保证同一账户的事件 id 与提交顺序一致
+func recordEvent(ctx context.Context, q *Queries, accountID int64, eventType string, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	event, err := q.CreateEvent(ctx, CreateEventParams{
+		AccountID: accountID,
+		Type:      eventType,
+		Payload:   data,
+	})
+	if err != nil {
+		return err
+	}
+
+	// 同时为订阅了该事件的 webhook 创建投递记录
+	return q.CreateWebhookDeliveries(ctx, event.ID)
+}
+
+// CreateAccountTx 开户并记录 account.created 事件
+func (store *SQLStore) CreateAccountTx(ctx context.Context, arg CreateAccountParams) (Account, error) {
+	var result Account
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result, err = q.CreateAccount(ctx, arg)
+		if err != nil {
+			return err
+		}
+		return recordEvent(ctx, q, result.ID, EventAccountCreated, result)
+	})
+
+	return result, err
+}
+
+type RelayEventsTxParams struct {
+	BatchSize int32 `json:"batch_size"`
+	// 领取的事件在 LeaseUntil 之前不会被其他 relay 领取
+	Now        time.Time `json:"now"`
+	LeaseUntil time.Time `json:"lease_until"`
+	// Publish 投递单个事件，返回错误时该事件留待下一轮重试
+	Publish func(event Event) error `json:"-"`
+}
+
+type RelayEventsTxResult struct {
+	Published int `json:"published"`
+	Failed    int `json:"failed"`
+}
+
+// RelayEventsTx 领取一批待投递的事件，在事务之外按 id 顺序逐个投递，再用另一个短事务记录结果。
+// 领取依次进行，某个账户还有被领取未完成的事件时不会领取该账户的事件；
+// 某个事件投递失败后，同一账户后面的事件本轮不再投递，以保证每个账户的事件按顺序到达。
+// 投递成功但记录失败时事件会在租约到期后被重复投递，即至少投递一次
+func (store *SQLStore) RelayEventsTx(ctx context.Context, arg RelayEventsTxParams) (RelayEventsTxResult, error) {
+	var result RelayEventsTxResult
+
+	var events []Event
+	err := store.execTx(ctx, func(q *Queries) error {
+		if err := q.LockEventRelay(ctx); err != nil {
+			return err
+		}
+
+		var err error
+		events, err = q.ClaimUnpublishedEvents(ctx, ClaimUnpublishedEventsParams{
+			LeaseUntil: arg.LeaseUntil,
+			Now:        arg.Now,
+			BatchSize:  arg.BatchSize,
+		})
+		return err
+	})
+	if err != nil || len(events) == 0 {
+		return result, err
+	}
+	// UPDATE ... RETURNING 不保证顺序
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].ID < events[j].ID
+	})
+
+	var published, released []int64
+	var failures []RecordEventFailureParams
+	blocked := make(map[int64]bool)
+	for _, event := range events {
+		if blocked[event.AccountID] {
+			released = append(released, event.ID)
+			continue
+		}
+
+		if err := arg.Publish(event); err != nil {
+			blocked[event.AccountID] = true
+			failures = append(failures, RecordEventFailureParams{
+				ID:        event.ID,
+				LastError: err.Error(),
+			})
+			released = append(released, event.ID)
+			continue
+		}
+		published = append(published, event.ID)
+	}
+	result.Published, result.Failed = len(published), len(failures)
+
+	err = store.execTx(ctx, func(q *Queries) error {
+		for _, id := range published {
+			if err := q.MarkEventPublished(ctx, id); err != nil {
+				return err
+			}
+		}
+		for _, failure := range failures {
+			if err := q.RecordEventFailure(ctx, failure); err != nil {
+				return err
+			}
+		}
+
+		// 未投递的事件立即释放，下一轮重试
+		if len(released) == 0 {
+			return nil
+		}
+		return q.ReleaseEvents(ctx, ReleaseEventsParams{
+			ReleasedAt: arg.Now,
+			Ids:        released,
+		})
+	})
+
+	return result, err
+}
@@ -0,0 +1,199 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xiusl/bank/util"
+)
+
+func accountEvents(t *testing.T, accountID int64) []Event {
+	events, err := testQueries.ListAccountEvents(context.Background(), ListAccountEventsParams{
+		AccountID: accountID,
+		Limit:     100,
+	})
+	require.NoError(t, err)
+	return events
+}
+
+func TestCreateAccountTx(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+
+	account, err := store.CreateAccountTx(context.Background(), CreateAccountParams{
+		Owner:       user.Username,
+		Currency:    util.RandomCurrency(),
+		AccountType: AccountTypeChecking,
+	})
+	require.NoError(t, err)
+
+	events := accountEvents(t, account.ID)
+	require.Len(t, events, 1)
+	require.Equal(t, EventAccountCreated, events[0].Type)
+	require.False(t, events[0].PublishedAt.Valid)
+
+	var payload Account
+	require.NoError(t, json.Unmarshal(events[0].Payload, &payload))
+	require.Equal(t, account.ID, payload.ID)
+	require.Equal(t, user.Username, payload.Owner)
+}
+
+func TestTransferTxEvents(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createFundedAccount(t, 100)
+	account2 := createSameCurrencyAccount(t, account1, 0)
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        30,
+	})
+	require.NoError(t, err)
+
+	events := accountEvents(t, account1.ID)
+	require.Len(t, events, 2)
+	require.Equal(t, EventBalanceChanged, events[0].Type)
+	require.Equal(t, EventTransferCreated, events[1].Type)
+
+	var changed BalanceChangedEvent
+	require.NoError(t, json.Unmarshal(events[0].Payload, &changed))
+	require.Equal(t, BalanceChangedEvent{
+		AccountID:   account1.ID,
+		JournalID:   result.Journal.ID,
+		EntryID:     result.FromEntry.ID,
+		TransferID:  result.Transfer.ID,
+		Description: "transfer",
+		Amount:      -30,
+		Balance:     70,
+		Currency:    account1.Currency,
+	}, changed)
+
+	var transferred Transfer
+	require.NoError(t, json.Unmarshal(events[1].Payload, &transferred))
+	require.Equal(t, result.Transfer.ID, transferred.ID)
+
+	events = accountEvents(t, account2.ID)
+	require.Len(t, events, 1)
+	require.Equal(t, EventBalanceChanged, events[0].Type)
+
+	// 失败的转账不留下事件
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account2.ID,
+		ToAccountID:   account1.ID,
+		Amount:        100,
+	})
+	require.True(t, errors.Is(err, ErrInsufficientFunds))
+	require.Len(t, accountEvents(t, account2.ID), 1)
+}
+
+func TestRelayEventsTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createFundedAccount(t, 100)
+	account2 := createSameCurrencyAccount(t, account1, 0)
+
+	_, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+
+	// account1 的事件始终投递失败，其他账户的事件正常投递，直到没有可投递的事件
+	var published []Event
+	publish := func(event Event) error {
+		if event.AccountID == account1.ID {
+			return errors.New("downstream unavailable")
+		}
+		published = append(published, event)
+		return nil
+	}
+	for {
+		now := time.Now()
+		result, err := store.RelayEventsTx(context.Background(), RelayEventsTxParams{
+			BatchSize:  100,
+			Now:        now,
+			LeaseUntil: now.Add(time.Minute),
+			Publish:    publish,
+		})
+		require.NoError(t, err)
+		if result.Published == 0 {
+			break
+		}
+	}
+
+	for _, event := range published {
+		require.NotEqual(t, account1.ID, event.AccountID)
+	}
+
+	events := accountEvents(t, account2.ID)
+	require.Len(t, events, 1)
+	require.True(t, events[0].PublishedAt.Valid)
+	require.Equal(t, int32(1), events[0].Attempts)
+
+	// 第一个事件失败后，同一账户后面的事件不会越过它被投递
+	events = accountEvents(t, account1.ID)
+	require.Len(t, events, 2)
+	require.False(t, events[0].PublishedAt.Valid)
+	require.NotZero(t, events[0].Attempts)
+	require.Equal(t, "downstream unavailable", events[0].LastError)
+	require.False(t, events[1].PublishedAt.Valid)
+	require.Zero(t, events[1].Attempts)
+}
+
+func TestRelayEventsTxLeased(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createFundedAccount(t, 100)
+	account2 := createSameCurrencyAccount(t, account1, 0)
+
+	_, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+
+	// 模拟另一个 relay 已领取 account1 的第一个事件，租约尚未到期
+	now := time.Now()
+	events := accountEvents(t, account1.ID)
+	require.Len(t, events, 2)
+	err = testQueries.ReleaseEvents(context.Background(), ReleaseEventsParams{
+		ReleasedAt: now.Add(time.Minute),
+		Ids:        []int64{events[0].ID},
+	})
+	require.NoError(t, err)
+
+	publish := func(event Event) error {
+		require.NotEqual(t, account1.ID, event.AccountID)
+		return nil
+	}
+	for {
+		result, err := store.RelayEventsTx(context.Background(), RelayEventsTxParams{
+			BatchSize:  100,
+			Now:        now,
+			LeaseUntil: now.Add(time.Minute),
+			Publish:    publish,
+		})
+		require.NoError(t, err)
+		if result.Published == 0 {
+			break
+		}
+	}
+
+	// account1 后面的事件也要等被领取的事件完成后才能投递
+	events = accountEvents(t, account1.ID)
+	for _, event := range events {
+		require.False(t, event.PublishedAt.Valid)
+		require.Zero(t, event.Attempts)
+	}
+
+	events = accountEvents(t, account2.ID)
+	require.Len(t, events, 1)
+	require.True(t, events[0].PublishedAt.Valid)
+}
@@ -0,0 +1,149 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xiusl/bank/util"
+)
+
+func createRandomIdempotencyKey(t *testing.T, user User) IdempotencyKey {
+	arg := CreateIdempotencyKeyParams{
+		Username:       user.Username,
+		IdempotencyKey: util.RandomString(16),
+		RequestMethod:  http.MethodPost,
+		RequestPath:    "/transfers",
+		RequestHash:    util.RandomString(64),
+	}
+
+	key, err := testQueries.CreateIdempotencyKey(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, key)
+
+	require.Equal(t, arg.Username, key.Username)
+	require.Equal(t, arg.IdempotencyKey, key.IdempotencyKey)
+	require.Equal(t, arg.RequestMethod, key.RequestMethod)
+	require.Equal(t, arg.RequestPath, key.RequestPath)
+	require.Equal(t, arg.RequestHash, key.RequestHash)
+	require.Zero(t, key.ResponseCode)
+	require.Empty(t, key.ResponseBody)
+	require.NotZero(t, key.CreatedAt)
+
+	return key
+}
+
+func TestCreateIdempotencyKey(t *testing.T) {
+	user := createRandomUser(t)
+	key1 := createRandomIdempotencyKey(t, user)
+
+	// 重复的 key 不会覆盖已有记录
+	_, err := testQueries.CreateIdempotencyKey(context.Background(), CreateIdempotencyKeyParams{
+		Username:       key1.Username,
+		IdempotencyKey: key1.IdempotencyKey,
+		RequestMethod:  http.MethodPost,
+		RequestPath:    "/accounts",
+		RequestHash:    util.RandomString(64),
+	})
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+
+	key2, err := testQueries.GetIdempotencyKey(context.Background(), GetIdempotencyKeyParams{
+		Username:       key1.Username,
+		IdempotencyKey: key1.IdempotencyKey,
+	})
+	require.NoError(t, err)
+	require.Equal(t, key1.RequestPath, key2.RequestPath)
+	require.Equal(t, key1.RequestHash, key2.RequestHash)
+}
+
+func TestCreateIdempotencyKeyExpired(t *testing.T) {
+	user := createRandomUser(t)
+	key1 := createRandomIdempotencyKey(t, user)
+
+	_, err := testQueries.UpdateIdempotencyKeyResponse(context.Background(), UpdateIdempotencyKeyResponseParams{
+		ResponseCode:   http.StatusOK,
+		ResponseBody:   []byte(`{"id":1}`),
+		Username:       key1.Username,
+		IdempotencyKey: key1.IdempotencyKey,
+	})
+	require.NoError(t, err)
+
+	// 已过期的 key 再次使用时视为新的请求，覆盖原记录
+	arg := CreateIdempotencyKeyParams{
+		Username:       key1.Username,
+		IdempotencyKey: key1.IdempotencyKey,
+		RequestMethod:  http.MethodPost,
+		RequestPath:    "/accounts",
+		RequestHash:    util.RandomString(64),
+		ExpiredBefore:  time.Now().Add(time.Minute),
+	}
+	key2, err := testQueries.CreateIdempotencyKey(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.RequestPath, key2.RequestPath)
+	require.Equal(t, arg.RequestHash, key2.RequestHash)
+	require.Zero(t, key2.ResponseCode)
+	require.Empty(t, key2.ResponseBody)
+	require.True(t, key2.CreatedAt.After(key1.CreatedAt))
+}
+
+func TestDeleteExpiredIdempotencyKeys(t *testing.T) {
+	user := createRandomUser(t)
+	key1 := createRandomIdempotencyKey(t, user)
+
+	err := testQueries.DeleteExpiredIdempotencyKeys(context.Background(), key1.CreatedAt)
+	require.NoError(t, err)
+
+	_, err = testQueries.GetIdempotencyKey(context.Background(), GetIdempotencyKeyParams{
+		Username:       key1.Username,
+		IdempotencyKey: key1.IdempotencyKey,
+	})
+	require.NoError(t, err)
+
+	err = testQueries.DeleteExpiredIdempotencyKeys(context.Background(), key1.CreatedAt.Add(time.Second))
+	require.NoError(t, err)
+
+	_, err = testQueries.GetIdempotencyKey(context.Background(), GetIdempotencyKeyParams{
+		Username:       key1.Username,
+		IdempotencyKey: key1.IdempotencyKey,
+	})
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+}
+
+func TestUpdateIdempotencyKeyResponse(t *testing.T) {
+	user := createRandomUser(t)
+	key1 := createRandomIdempotencyKey(t, user)
+
+	arg := UpdateIdempotencyKeyResponseParams{
+		ResponseCode:   http.StatusOK,
+		ResponseBody:   []byte(`{"id":1}`),
+		Username:       key1.Username,
+		IdempotencyKey: key1.IdempotencyKey,
+	}
+	key2, err := testQueries.UpdateIdempotencyKeyResponse(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.ResponseCode, key2.ResponseCode)
+	require.Equal(t, arg.ResponseBody, key2.ResponseBody)
+	require.Equal(t, key1.RequestHash, key2.RequestHash)
+}
+
+func TestDeleteIdempotencyKey(t *testing.T) {
+	user := createRandomUser(t)
+	key1 := createRandomIdempotencyKey(t, user)
+
+	err := testQueries.DeleteIdempotencyKey(context.Background(), DeleteIdempotencyKeyParams{
+		Username:       key1.Username,
+		IdempotencyKey: key1.IdempotencyKey,
+	})
+	require.NoError(t, err)
+
+	key2, err := testQueries.GetIdempotencyKey(context.Background(), GetIdempotencyKeyParams{
+		Username:       key1.Username,
+		IdempotencyKey: key1.IdempotencyKey,
+	})
+	require.Error(t, err)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+	require.Empty(t, key2)
+}
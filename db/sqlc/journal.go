@@ -0,0 +1,130 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrUnbalancedJournal 凭证的分录按货币合计不为零，或分录少于两条
+var ErrUnbalancedJournal = errors.New("journal is not balanced")
+
+// Posting 凭证中的一笔记账，给账户加上 Amount（可以为负）
+type Posting struct {
+	AccountID int64 `json:"account_id"`
+	Amount    int64 `json:"amount"`
+}
+
+type PostJournalTxParams struct {
+	Description string `json:"description"`
+	// 关联的转账记录，0 表示不关联
+	TransferID int64     `json:"transfer_id"`
+	Postings   []Posting `json:"postings"`
+}
+
+type PostJournalTxResult struct {
+	Journal Journal `json:"journal"`
+	// 与 Postings 一一对应
+	Entries  []Entry           `json:"entries"`
+	Accounts map[int64]Account `json:"accounts"`
+}
+
+// PostJournalTx 在一个事务里记一笔凭证：写入所有分录并更新余额
+func (store *SQLStore) PostJournalTx(ctx context.Context, arg PostJournalTxParams) (PostJournalTxResult, error) {
+	var result PostJournalTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result, err = postJournal(ctx, q, arg)
+		return err
+	})
+
+	return result, err
+}
+
+// postJournal 按 id 顺序锁定账户后检查凭证：每种货币的分录合计必须为零，
+// 用户账户必须处于正常状态，净转出时可用余额（含透支额度）要足够；系统账户不受这些限制
+func postJournal(ctx context.Context, q *Queries, arg PostJournalTxParams) (PostJournalTxResult, error) {
+	var result PostJournalTxResult
+
+	if len(arg.Postings) < 2 {
+		return result, fmt.Errorf("journal has %d postings: %w", len(arg.Postings), ErrUnbalancedJournal)
+	}
+
+	accounts, err := lockAccounts(ctx, q, arg.Postings)
+	if err != nil {
+		return result, err
+	}
+
+	totals := make(map[string]int64)
+	for _, p := range arg.Postings {
+		totals[accounts[p.AccountID].Currency] += p.Amount
+	}
+	for currency, total := range totals {
+		if total != 0 {
+			return result, fmt.Errorf("%s total %d: %w", currency, total, ErrUnbalancedJournal)
+		}
+	}
+
+	ids := make([]int64, 0, len(accounts))
+	for _, id := range sortedAccountIDs(arg.Postings) {
+		if !isSystemOwner(accounts[id].Owner) {
+			ids = append(ids, id)
+		}
+	}
+	if err := requireActive(accounts, ids...); err != nil {
+		return result, err
+	}
+
+	amounts := netAmounts(arg.Postings)
+	for _, id := range ids {
+		if amounts[id] >= 0 {
+			continue
+		}
+		account := accounts[id]
+		available, err := availableBalance(ctx, q, account)
+		if err != nil {
+			return result, err
+		}
+		if available+amounts[id] < -account.OverdraftLimit {
+			return result, fmt.Errorf("account [%d] available balance %d: %w", account.ID, available, ErrInsufficientFunds)
+		}
+	}
+
+	result.Journal, err = q.CreateJournal(ctx, arg.Description)
+	if err != nil {
+		return result, err
+	}
+
+	result.Entries, err = createEntries(ctx, q, result.Journal.ID, arg.TransferID, arg.Postings)
+	if err != nil {
+		return result, err
+	}
+
+	result.Accounts, err = addBalances(ctx, q, arg.Postings)
+	if err != nil {
+		return result, err
+	}
+
+	// 系统账户的变动不对外发布
+	for i, p := range arg.Postings {
+		account := result.Accounts[p.AccountID]
+		if isSystemOwner(account.Owner) {
+			continue
+		}
+		err = recordEvent(ctx, q, account.ID, EventBalanceChanged, BalanceChangedEvent{
+			AccountID:   account.ID,
+			JournalID:   result.Journal.ID,
+			EntryID:     result.Entries[i].ID,
+			TransferID:  arg.TransferID,
+			Description: arg.Description,
+			Amount:      p.Amount,
+			Balance:     account.Balance,
+			Currency:    account.Currency,
+		})
+		if err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
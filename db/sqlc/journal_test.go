@@ -0,0 +1,118 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createSameCurrencyAccount(t *testing.T, account Account, balance int64) Account {
+	other := createFundedAccount(t, balance)
+	for other.Currency != account.Currency {
+		other = createFundedAccount(t, balance)
+	}
+	return other
+}
+
+func TestPostJournalTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createFundedAccount(t, 100)
+	account2 := createSameCurrencyAccount(t, account1, 0)
+	account3 := createSameCurrencyAccount(t, account1, 0)
+
+	// 一笔转出拆给两个账户
+	arg := PostJournalTxParams{
+		Description: "split",
+		Postings: []Posting{
+			{AccountID: account1.ID, Amount: -30},
+			{AccountID: account2.ID, Amount: 20},
+			{AccountID: account3.ID, Amount: 10},
+		},
+	}
+	result, err := store.PostJournalTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	require.NotZero(t, result.Journal.ID)
+	require.Equal(t, arg.Description, result.Journal.Description)
+	require.Len(t, result.Entries, 3)
+	for i, entry := range result.Entries {
+		require.Equal(t, arg.Postings[i].AccountID, entry.AccountID)
+		require.Equal(t, arg.Postings[i].Amount, entry.Amount)
+		require.Equal(t, result.Journal.ID, entry.JournalID.Int64)
+		require.False(t, entry.TransferID.Valid)
+	}
+
+	require.Equal(t, int64(70), result.Accounts[account1.ID].Balance)
+	require.Equal(t, int64(20), result.Accounts[account2.ID].Balance)
+	require.Equal(t, int64(10), result.Accounts[account3.ID].Balance)
+
+	entries, err := testQueries.ListJournalEntries(context.Background(), sql.NullInt64{Int64: result.Journal.ID, Valid: true})
+	require.NoError(t, err)
+	require.Equal(t, result.Entries, entries)
+}
+
+func TestPostJournalTxUnbalanced(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createFundedAccount(t, 100)
+	account2 := createSameCurrencyAccount(t, account1, 0)
+
+	_, err := store.PostJournalTx(context.Background(), PostJournalTxParams{
+		Postings: []Posting{
+			{AccountID: account1.ID, Amount: -30},
+			{AccountID: account2.ID, Amount: 20},
+		},
+	})
+	require.True(t, errors.Is(err, ErrUnbalancedJournal))
+
+	_, err = store.PostJournalTx(context.Background(), PostJournalTxParams{
+		Postings: []Posting{
+			{AccountID: account1.ID, Amount: 0},
+		},
+	})
+	require.True(t, errors.Is(err, ErrUnbalancedJournal))
+
+	// 不同币种的分录不能互相抵消
+	account3 := createFundedAccount(t, 0)
+	for account3.Currency == account1.Currency {
+		account3 = createFundedAccount(t, 0)
+	}
+	_, err = store.PostJournalTx(context.Background(), PostJournalTxParams{
+		Postings: []Posting{
+			{AccountID: account1.ID, Amount: -30},
+			{AccountID: account3.ID, Amount: 30},
+		},
+	})
+	require.True(t, errors.Is(err, ErrUnbalancedJournal))
+
+	account1, err = testQueries.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), account1.Balance)
+}
+
+func TestPostJournalTxInsufficientFunds(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createFundedAccount(t, 100)
+	account2 := createSameCurrencyAccount(t, account1, 0)
+	account3 := createSameCurrencyAccount(t, account1, 0)
+
+	// 按账户的净额检查余额
+	_, err := store.PostJournalTx(context.Background(), PostJournalTxParams{
+		Postings: []Posting{
+			{AccountID: account1.ID, Amount: -80},
+			{AccountID: account1.ID, Amount: -40},
+			{AccountID: account2.ID, Amount: 60},
+			{AccountID: account3.ID, Amount: 60},
+		},
+	})
+	require.True(t, errors.Is(err, ErrInsufficientFunds))
+
+	account1, err = testQueries.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), account1.Balance)
+}
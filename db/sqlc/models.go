@@ -3,30 +3,156 @@
 package db
 
 import (
+	"database/sql"
+	"encoding/json"
 	"time"
+
+	"github.com/google/uuid"
 )
 
 type Account struct {
-	ID        int64     `json:"id"`
-	Owner     string    `json:"owner"`
-	Balance   int64     `json:"balance"`
-	Currency  string    `json:"currency"`
+	ID             int64     `json:"id"`
+	Owner          string    `json:"owner"`
+	Balance        int64     `json:"balance"`
+	Currency       string    `json:"currency"`
+	CreatedAt      time.Time `json:"created_at"`
+	OverdraftLimit int64     `json:"overdraft_limit"`
+	Status         string    `json:"status"`
+	AccountType    string    `json:"account_type"`
+	Nickname       string    `json:"nickname"`
+}
+
+type Currency struct {
+	Code      string    `json:"code"`
+	Exponent  int32     `json:"exponent"`
+	Enabled   bool      `json:"enabled"`
 	CreatedAt time.Time `json:"created_at"`
 }
 
 type Entry struct {
-	ID        int64     `json:"id"`
-	AccountID int64     `json:"account_id"`
-	Amount    int64     `json:"amount"`
+	ID         int64         `json:"id"`
+	AccountID  int64         `json:"account_id"`
+	Amount     int64         `json:"amount"`
+	CreatedAt  time.Time     `json:"created_at"`
+	JournalID  sql.NullInt64 `json:"journal_id"`
+	TransferID sql.NullInt64 `json:"transfer_id"`
+}
+
+type Event struct {
+	ID          int64           `json:"id"`
+	AccountID   int64           `json:"account_id"`
+	Type        string          `json:"type"`
+	Payload     json.RawMessage `json:"payload"`
+	Attempts    int32           `json:"attempts"`
+	LastError   string          `json:"last_error"`
+	PublishedAt sql.NullTime    `json:"published_at"`
+	CreatedAt   time.Time       `json:"created_at"`
+	LockedUntil time.Time       `json:"locked_until"`
+}
+
+type Hold struct {
+	ID          int64         `json:"id"`
+	AccountID   int64         `json:"account_id"`
+	ToAccountID int64         `json:"to_account_id"`
+	Amount      int64         `json:"amount"`
+	Status      string        `json:"status"`
+	ExpiresAt   time.Time     `json:"expires_at"`
+	TransferID  sql.NullInt64 `json:"transfer_id"`
+	CreatedAt   time.Time     `json:"created_at"`
+}
+
+type IdempotencyKey struct {
+	Username       string    `json:"username"`
+	IdempotencyKey string    `json:"idempotency_key"`
+	RequestMethod  string    `json:"request_method"`
+	RequestPath    string    `json:"request_path"`
+	RequestHash    string    `json:"request_hash"`
+	ResponseCode   int32     `json:"response_code"`
+	ResponseBody   []byte    `json:"response_body"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type InterestAccrual struct {
+	ID           int64         `json:"id"`
+	AccountID    int64         `json:"account_id"`
+	AccrualDate  time.Time     `json:"accrual_date"`
+	Balance      int64         `json:"balance"`
+	Rate         float64       `json:"rate"`
+	AmountMicros int64         `json:"amount_micros"`
+	JournalID    sql.NullInt64 `json:"journal_id"`
+	CreatedAt    time.Time     `json:"created_at"`
+	Carried      bool          `json:"carried"`
+}
+
+type Journal struct {
+	ID          int64     `json:"id"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type RevokedToken struct {
+	ID        uuid.UUID `json:"id"`
+	Username  string    `json:"username"`
+	ExpiresAt time.Time `json:"expires_at"`
 	CreatedAt time.Time `json:"created_at"`
 }
 
+type ScheduledTransfer struct {
+	ID            int64     `json:"id"`
+	Owner         string    `json:"owner"`
+	FromAccountID int64     `json:"from_account_id"`
+	ToAccountID   int64     `json:"to_account_id"`
+	Amount        int64     `json:"amount"`
+	Frequency     string    `json:"frequency"`
+	Status        string    `json:"status"`
+	NextRunAt     time.Time `json:"next_run_at"`
+	RunAfter      time.Time `json:"run_after"`
+	Attempts      int32     `json:"attempts"`
+	CreatedAt     time.Time `json:"created_at"`
+	StartAt       time.Time `json:"start_at"`
+	Currency      string    `json:"currency"`
+}
+
+type ScheduledTransferRun struct {
+	ID                  int64         `json:"id"`
+	ScheduledTransferID int64         `json:"scheduled_transfer_id"`
+	TransferID          sql.NullInt64 `json:"transfer_id"`
+	Status              string        `json:"status"`
+	Error               string        `json:"error"`
+	CreatedAt           time.Time     `json:"created_at"`
+	Amount              int64         `json:"amount"`
+}
+
+type Session struct {
+	ID           uuid.UUID `json:"id"`
+	Username     string    `json:"username"`
+	RefreshToken string    `json:"refresh_token"`
+	UserAgent    string    `json:"user_agent"`
+	ClientIp     string    `json:"client_ip"`
+	IsBlocked    bool      `json:"is_blocked"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
 type Transfer struct {
 	ID            int64     `json:"id"`
 	FromAccountID int64     `json:"from_account_id"`
 	ToAccountID   int64     `json:"to_account_id"`
 	Amount        int64     `json:"amount"`
 	CreatedAt     time.Time `json:"created_at"`
+	ToAmount      int64     `json:"to_amount"`
+	ExchangeRate  float64   `json:"exchange_rate"`
+	Fee           int64     `json:"fee"`
+}
+
+type TransferReversal struct {
+	ID                 int64     `json:"id"`
+	TransferID         int64     `json:"transfer_id"`
+	ReversalTransferID int64     `json:"reversal_transfer_id"`
+	Amount             int64     `json:"amount"`
+	Reason             string    `json:"reason"`
+	InitiatedBy        string    `json:"initiated_by"`
+	CreatedAt          time.Time `json:"created_at"`
 }
 
 type User struct {
@@ -36,4 +162,33 @@ type User struct {
 	Email             string    `json:"email"`
 	PasswordChangedAt time.Time `json:"password_changed_at"`
 	CreatedAt         time.Time `json:"created_at"`
+	Role              string    `json:"role"`
+}
+
+type UserTokenRevocation struct {
+	Username      string    `json:"username"`
+	RevokedBefore time.Time `json:"revoked_before"`
+}
+
+type WebhookDelivery struct {
+	ID            int64        `json:"id"`
+	EndpointID    int64        `json:"endpoint_id"`
+	EventID       int64        `json:"event_id"`
+	Status        string       `json:"status"`
+	Attempts      int32        `json:"attempts"`
+	ResponseCode  int32        `json:"response_code"`
+	LastError     string       `json:"last_error"`
+	NextAttemptAt time.Time    `json:"next_attempt_at"`
+	DeliveredAt   sql.NullTime `json:"delivered_at"`
+	CreatedAt     time.Time    `json:"created_at"`
+}
+
+type WebhookEndpoint struct {
+	ID         int64     `json:"id"`
+	Owner      string    `json:"owner"`
+	Url        string    `json:"url"`
+	Secret     string    `json:"secret"`
+	EventTypes []string  `json:"event_types"`
+	Active     bool      `json:"active"`
+	CreatedAt  time.Time `json:"created_at"`
 }
@@ -0,0 +1,68 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestListAccountBalanceDrift(t *testing.T) {
+	store := NewStore(testDB)
+
+	// 通过 DepositTx 入账的账户余额与分录一致
+	account := createRandomAccount(t)
+	account, err := testQueries.UpdateAccount(context.Background(), UpdateAccountParams{
+		ID:      account.ID,
+		Balance: 0,
+	})
+	require.NoError(t, err)
+	_, err = store.DepositTx(context.Background(), DepositTxParams{
+		AccountID: account.ID,
+		Amount:    10,
+	})
+	require.NoError(t, err)
+
+	// 直接改余额造成偏差
+	drifted := createFundedAccount(t, 42)
+
+	rows, err := testQueries.ListAccountBalanceDrift(context.Background())
+	require.NoError(t, err)
+
+	found := make(map[int64]ListAccountBalanceDriftRow)
+	for _, row := range rows {
+		found[row.ID] = row
+	}
+	require.NotContains(t, found, account.ID)
+	require.Contains(t, found, drifted.ID)
+	require.Equal(t, int64(42), found[drifted.ID].Balance)
+	require.Zero(t, found[drifted.ID].EntriesTotal)
+}
+
+func TestListTransferDrift(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createFundedAccount(t, 100)
+	account2 := createFundedAccount(t, 100)
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+
+	// 没有分录的转账记录
+	orphan := createRandomTransfer(t, account1, account2)
+
+	rows, err := testQueries.ListTransferDrift(context.Background())
+	require.NoError(t, err)
+
+	found := make(map[int64]ListTransferDriftRow)
+	for _, row := range rows {
+		found[row.ID] = row
+	}
+	require.NotContains(t, found, result.Transfer.ID)
+	require.Contains(t, found, orphan.ID)
+	require.Zero(t, found[orphan.ID].EntriesCount)
+}
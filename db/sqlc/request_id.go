@@ -0,0 +1,40 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/xiusl/bank/logger"
+)
+
+// requestIDDBTX 在每条 SQL 前加上 /* request_id=... */ 注释，
+// 数据库错误日志、慢查询日志和 pg_stat_activity 中的语句可以据此对应到请求日志
+type requestIDDBTX struct {
+	DBTX
+}
+
+func (db requestIDDBTX) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return db.DBTX.ExecContext(ctx, tagQuery(ctx, query), args...)
+}
+
+func (db requestIDDBTX) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return db.DBTX.PrepareContext(ctx, tagQuery(ctx, query))
+}
+
+func (db requestIDDBTX) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return db.DBTX.QueryContext(ctx, tagQuery(ctx, query), args...)
+}
+
+func (db requestIDDBTX) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return db.DBTX.QueryRowContext(ctx, tagQuery(ctx, query), args...)
+}
+
+// tagQuery ctx 中没有请求 ID 时原样返回；
+// 请求 ID 可能来自客户端，不合法的不写入 SQL
+func tagQuery(ctx context.Context, query string) string {
+	requestID := logger.RequestID(ctx)
+	if !logger.ValidRequestID(requestID) {
+		return query
+	}
+	return "/* request_id=" + requestID + " */ " + query
+}
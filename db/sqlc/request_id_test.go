@@ -0,0 +1,41 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xiusl/bank/logger"
+)
+
+func TestTagQuery(t *testing.T) {
+	const query = "SELECT 1"
+
+	require.Equal(t, query, tagQuery(context.Background(), query))
+
+	ctx := logger.WithRequestID(context.Background(), "req-1")
+	require.Equal(t, "/* request_id=req-1 */ SELECT 1", tagQuery(ctx, query))
+
+	// 不合法的请求 ID 不写入 SQL
+	ctx = logger.WithRequestID(context.Background(), "*/ DROP TABLE accounts; /*")
+	require.Equal(t, query, tagQuery(ctx, query))
+}
+
+func TestStoreWithRequestID(t *testing.T) {
+	store := NewStore(testDB)
+	account1 := createRandomAccount(t)
+
+	// 带注释的语句在普通查询和事务中都能正常执行
+	ctx := logger.WithRequestID(context.Background(), logger.NewRequestID())
+	account2, err := store.GetAccount(ctx, account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.ID, account2.ID)
+
+	account3, err := store.CreateAccountTx(ctx, CreateAccountParams{
+		Owner:       account1.Owner,
+		Currency:    account1.Currency,
+		AccountType: AccountTypeSavings,
+	})
+	require.NoError(t, err)
+	require.Equal(t, account1.Owner, account3.Owner)
+}
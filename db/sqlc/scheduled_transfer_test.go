@@ -0,0 +1,264 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomScheduledTransfer(t *testing.T, nextRunAt time.Time) ScheduledTransfer {
+	account1 := createFundedAccount(t, 100)
+	account2 := createSameCurrencyAccount(t, account1, 0)
+
+	arg := CreateScheduledTransferParams{
+		Owner:         account1.Owner,
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+		Frequency:     FrequencyMonthly,
+		NextRunAt:     nextRunAt,
+		Currency:      account1.Currency,
+	}
+
+	scheduled, err := testQueries.CreateScheduledTransfer(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, scheduled.ID)
+	require.Equal(t, arg.Owner, scheduled.Owner)
+	require.Equal(t, arg.FromAccountID, scheduled.FromAccountID)
+	require.Equal(t, arg.ToAccountID, scheduled.ToAccountID)
+	require.Equal(t, arg.Amount, scheduled.Amount)
+	require.Equal(t, arg.Frequency, scheduled.Frequency)
+	require.Equal(t, arg.Currency, scheduled.Currency)
+	require.Equal(t, ScheduledTransferActive, scheduled.Status)
+	require.WithinDuration(t, arg.NextRunAt, scheduled.NextRunAt, time.Second)
+	require.WithinDuration(t, arg.NextRunAt, scheduled.RunAfter, time.Second)
+	require.WithinDuration(t, arg.NextRunAt, scheduled.StartAt, time.Second)
+	require.Zero(t, scheduled.Attempts)
+
+	return scheduled
+}
+
+func TestClaimDueScheduledTransfers(t *testing.T) {
+	now := time.Now()
+	due := createRandomScheduledTransfer(t, now.Add(-time.Minute))
+	later := createRandomScheduledTransfer(t, now.Add(time.Hour))
+
+	arg := ClaimDueScheduledTransfersParams{
+		LeaseUntil: now.Add(5 * time.Minute),
+		Now:        now,
+		BatchSize:  1000,
+	}
+	claimed, err := testQueries.ClaimDueScheduledTransfers(context.Background(), arg)
+	require.NoError(t, err)
+
+	ids := make(map[int64]bool)
+	for _, scheduled := range claimed {
+		ids[scheduled.ID] = true
+	}
+	require.True(t, ids[due.ID])
+	require.False(t, ids[later.ID])
+
+	// 已领取的在租期内不会再被领取
+	claimed, err = testQueries.ClaimDueScheduledTransfers(context.Background(), arg)
+	require.NoError(t, err)
+	for _, scheduled := range claimed {
+		require.NotEqual(t, due.ID, scheduled.ID)
+	}
+}
+
+func TestUpdateScheduledTransfer(t *testing.T) {
+	scheduled := createRandomScheduledTransfer(t, time.Now().Add(time.Hour))
+
+	// 只改金额时保留调度器写入的 run_after
+	lease := time.Now().Add(2 * time.Hour)
+	_, err := testQueries.RescheduleScheduledTransfer(context.Background(), RescheduleScheduledTransferParams{
+		ID:        scheduled.ID,
+		NextRunAt: scheduled.NextRunAt,
+		RunAfter:  lease,
+	})
+	require.NoError(t, err)
+
+	updated, err := testQueries.UpdateScheduledTransfer(context.Background(), UpdateScheduledTransferParams{
+		ID:     scheduled.ID,
+		Amount: sql.NullInt64{Int64: 20, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(20), updated.Amount)
+	require.Equal(t, scheduled.Frequency, updated.Frequency)
+	require.Equal(t, scheduled.Status, updated.Status)
+	require.WithinDuration(t, scheduled.NextRunAt, updated.NextRunAt, time.Second)
+	require.WithinDuration(t, lease, updated.RunAfter, time.Second)
+	require.WithinDuration(t, scheduled.StartAt, updated.StartAt, time.Second)
+
+	nextRunAt := time.Now().Add(3 * time.Hour)
+	updated, err = testQueries.UpdateScheduledTransfer(context.Background(), UpdateScheduledTransferParams{
+		ID:        scheduled.ID,
+		NextRunAt: sql.NullTime{Time: nextRunAt, Valid: true},
+	})
+	require.NoError(t, err)
+	require.WithinDuration(t, nextRunAt, updated.NextRunAt, time.Second)
+	require.WithinDuration(t, nextRunAt, updated.RunAfter, time.Second)
+	require.WithinDuration(t, nextRunAt, updated.StartAt, time.Second)
+
+	// 已取消的不能再修改
+	_, err = testQueries.UpdateScheduledTransferStatus(context.Background(), UpdateScheduledTransferStatusParams{
+		ID:     scheduled.ID,
+		Status: ScheduledTransferCancelled,
+	})
+	require.NoError(t, err)
+
+	_, err = testQueries.UpdateScheduledTransfer(context.Background(), UpdateScheduledTransferParams{
+		ID:     scheduled.ID,
+		Status: sql.NullString{String: ScheduledTransferActive, Valid: true},
+	})
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+}
+
+func TestExecuteScheduledTransferTx(t *testing.T) {
+	store := NewStore(testDB)
+	scheduled := createRandomScheduledTransfer(t, time.Now())
+	next := scheduled.NextRunAt.AddDate(0, 1, 0)
+
+	var transferErr error
+	result, err := store.ExecuteScheduledTransferTx(context.Background(), ExecuteScheduledTransferTxParams{
+		ScheduledTransfer: scheduled,
+		Transfer: TransferTxParams{
+			FromAccountID: scheduled.FromAccountID,
+			ToAccountID:   scheduled.ToAccountID,
+			Amount:        scheduled.Amount,
+		},
+		Record: func(result TransferTxResult, err error) ScheduledTransferRunRecord {
+			transferErr = err
+			return ScheduledTransferRunRecord{
+				Run: CreateScheduledTransferRunParams{
+					TransferID: sql.NullInt64{Int64: result.Transfer.ID, Valid: true},
+					Status:     ScheduledRunSucceeded,
+				},
+				Reschedule: RescheduleScheduledTransferParams{
+					NextRunAt: next,
+					RunAfter:  next,
+				},
+			}
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, transferErr)
+	require.NotZero(t, result.Transfer.Transfer.ID)
+	require.Equal(t, scheduled.ID, result.Run.ScheduledTransferID)
+	require.Equal(t, result.Transfer.Transfer.ID, result.Run.TransferID.Int64)
+	require.Equal(t, scheduled.Amount, result.Run.Amount)
+	require.Equal(t, ScheduledTransferActive, result.ScheduledTransfer.Status)
+	require.WithinDuration(t, next, result.ScheduledTransfer.NextRunAt, time.Second)
+	require.Equal(t, int64(90), result.Transfer.FromAccount.Balance)
+
+	// 余额不足时只回滚转账，执行记录照常写入
+	scheduled = result.ScheduledTransfer
+	result, err = store.ExecuteScheduledTransferTx(context.Background(), ExecuteScheduledTransferTxParams{
+		ScheduledTransfer: scheduled,
+		Transfer: TransferTxParams{
+			FromAccountID: scheduled.FromAccountID,
+			ToAccountID:   scheduled.ToAccountID,
+			Amount:        1000,
+		},
+		Record: func(result TransferTxResult, err error) ScheduledTransferRunRecord {
+			transferErr = err
+			return ScheduledTransferRunRecord{
+				Run: CreateScheduledTransferRunParams{
+					Status: ScheduledRunSkipped,
+					Error:  err.Error(),
+				},
+				Completed: true,
+			}
+		},
+	})
+	require.NoError(t, err)
+	require.True(t, errors.Is(transferErr, ErrInsufficientFunds))
+	require.Zero(t, result.Transfer.Transfer.ID)
+	require.Equal(t, ScheduledTransferCompleted, result.ScheduledTransfer.Status)
+
+	account, err := testQueries.GetAccount(context.Background(), scheduled.FromAccountID)
+	require.NoError(t, err)
+	require.Equal(t, int64(90), account.Balance)
+
+	runs, err := testQueries.ListScheduledTransferRuns(context.Background(), ListScheduledTransferRunsParams{
+		ScheduledTransferID: scheduled.ID,
+		Limit:               5,
+		Offset:              0,
+	})
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+	require.Equal(t, ScheduledRunSkipped, runs[0].Status)
+	require.Equal(t, ScheduledRunSucceeded, runs[1].Status)
+}
+
+func TestExecuteScheduledTransferTxChanged(t *testing.T) {
+	store := NewStore(testDB)
+	claimed := createRandomScheduledTransfer(t, time.Now())
+
+	// 领取后被用户暂停，不执行也不写执行记录
+	_, err := testQueries.UpdateScheduledTransferStatus(context.Background(), UpdateScheduledTransferStatusParams{
+		ID:     claimed.ID,
+		Status: ScheduledTransferPaused,
+	})
+	require.NoError(t, err)
+
+	_, err = store.ExecuteScheduledTransferTx(context.Background(), ExecuteScheduledTransferTxParams{
+		ScheduledTransfer: claimed,
+		Transfer: TransferTxParams{
+			FromAccountID: claimed.FromAccountID,
+			ToAccountID:   claimed.ToAccountID,
+			Amount:        claimed.Amount,
+		},
+		Record: func(result TransferTxResult, err error) ScheduledTransferRunRecord {
+			t.Fatal("record should not be called")
+			return ScheduledTransferRunRecord{}
+		},
+	})
+	require.True(t, errors.Is(err, ErrScheduledTransferChanged))
+
+	account, err := testQueries.GetAccount(context.Background(), claimed.FromAccountID)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), account.Balance)
+
+	runs, err := testQueries.ListScheduledTransferRuns(context.Background(), ListScheduledTransferRunsParams{
+		ScheduledTransferID: claimed.ID,
+		Limit:               5,
+		Offset:              0,
+	})
+	require.NoError(t, err)
+	require.Empty(t, runs)
+}
+
+func TestExecuteScheduledTransferTxFrequencyChanged(t *testing.T) {
+	store := NewStore(testDB)
+	claimed := createRandomScheduledTransfer(t, time.Now())
+
+	// 领取后修改了频率，按旧频率执行会算错下一次执行时间，本轮跳过
+	_, err := testQueries.UpdateScheduledTransfer(context.Background(), UpdateScheduledTransferParams{
+		Frequency: sql.NullString{String: FrequencyWeekly, Valid: true},
+		ID:        claimed.ID,
+	})
+	require.NoError(t, err)
+
+	_, err = store.ExecuteScheduledTransferTx(context.Background(), ExecuteScheduledTransferTxParams{
+		ScheduledTransfer: claimed,
+		Transfer: TransferTxParams{
+			FromAccountID: claimed.FromAccountID,
+			ToAccountID:   claimed.ToAccountID,
+			Amount:        claimed.Amount,
+		},
+		Record: func(result TransferTxResult, err error) ScheduledTransferRunRecord {
+			t.Fatal("record should not be called")
+			return ScheduledTransferRunRecord{}
+		},
+	})
+	require.True(t, errors.Is(err, ErrScheduledTransferChanged))
+
+	account, err := testQueries.GetAccount(context.Background(), claimed.FromAccountID)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), account.Balance)
+}
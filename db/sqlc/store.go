@@ -3,12 +3,33 @@ package db
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"sort"
+)
+
+var (
+	// ErrInsufficientFunds 转出账户余额（含透支额度）不足
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	// ErrCurrencyMismatch 跨币种转账缺少汇率报价
+	ErrCurrencyMismatch = errors.New("currency mismatch")
 )
 
 type Store interface {
 	Querier
 	TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error)
+	DepositTx(ctx context.Context, arg DepositTxParams) (DepositTxResult, error)
+	WithdrawTx(ctx context.Context, arg WithdrawTxParams) (WithdrawTxResult, error)
+	UpdateAccountStatusTx(ctx context.Context, arg UpdateAccountStatusTxParams) (Account, error)
+	PostJournalTx(ctx context.Context, arg PostJournalTxParams) (PostJournalTxResult, error)
+	ReverseTransferTx(ctx context.Context, arg ReverseTransferTxParams) (ReverseTransferTxResult, error)
+	ExecuteScheduledTransferTx(ctx context.Context, arg ExecuteScheduledTransferTxParams) (ExecuteScheduledTransferTxResult, error)
+	PlaceHoldTx(ctx context.Context, arg PlaceHoldTxParams) (PlaceHoldTxResult, error)
+	CaptureHoldTx(ctx context.Context, arg CaptureHoldTxParams) (CaptureHoldTxResult, error)
+	ReleaseHoldTx(ctx context.Context, holdID int64) (Hold, error)
+	PostInterestTx(ctx context.Context, arg PostInterestTxParams) (PostInterestTxResult, error)
+	CreateAccountTx(ctx context.Context, arg CreateAccountParams) (Account, error)
+	RelayEventsTx(ctx context.Context, arg RelayEventsTxParams) (RelayEventsTxResult, error)
 }
 
 type SQLStore struct {
@@ -19,7 +40,7 @@ type SQLStore struct {
 func NewStore(db *sql.DB) *SQLStore {
 	return &SQLStore{
 		db:      db,
-		Queries: New(db),
+		Queries: New(requestIDDBTX{db}),
 	}
 }
 
@@ -30,7 +51,7 @@ func (store *SQLStore) execTx(ctx context.Context, fn func(*Queries) error) erro
 		return err
 	}
 
-	q := New(tx)
+	q := New(requestIDDBTX{tx})
 	err = fn(q)
 	if err != nil {
 		if rbErr := tx.Rollback(); rbErr != nil {
@@ -42,85 +63,227 @@ func (store *SQLStore) execTx(ctx context.Context, fn func(*Queries) error) erro
 	return tx.Commit()
 }
 
+// savepoint 在事务 q 中执行 fn，fn 失败时只回滚 fn 所做的改动，事务可以继续使用
+func savepoint(ctx context.Context, q *Queries, name string, fn func() error) error {
+	if _, err := q.db.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return err
+	}
+
+	if err := fn(); err != nil {
+		if _, rbErr := q.db.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name); rbErr != nil {
+			return fmt.Errorf("savepoint error: %v, rb error: %v", err, rbErr)
+		}
+		return err
+	}
+
+	_, err := q.db.ExecContext(ctx, "RELEASE SAVEPOINT "+name)
+	return err
+}
+
 type TransferTxParams struct {
 	FromAccountID int64 `json:"from_account_id"`
 	ToAccountID   int64 `json:"To_account_id"`
 	Amount        int64 `json:"amount"`
+	// 跨币种转账时由调用方给出报价：到账金额和汇率，同币种转账留空
+	ToAmount     int64   `json:"to_amount"`
+	ExchangeRate float64 `json:"exchange_rate"`
+	// 由调用方按收费标准计算的手续费，从转出账户额外扣除
+	Fee TransferFee `json:"fee"`
+}
+
+// TransferFee 手续费明细，币种与转出账户相同，Amount 是实际收取的金额
+type TransferFee struct {
+	Flat       int64 `json:"flat"`
+	Percentage int64 `json:"percentage"`
+	Amount     int64 `json:"amount"`
 }
 
 type TransferTxResult struct {
 	Transfer    Transfer `json:"transfer"`
+	Journal     Journal  `json:"journal"`
 	FromAccount Account  `json:"from_account"`
 	ToAccount   Account  `json:"To_account"`
 	FromEntry   Entry    `json:"from_entry"`
 	ToEntry     Entry    `json:"to_entry"`
+	// 手续费明细和转出账户的手续费分录，不收手续费时为空
+	Fee      TransferFee `json:"fee"`
+	FeeEntry Entry       `json:"fee_entry"`
 }
 
+// TransferTx 在一个事务里完成转账：创建转账记录，再记一笔关联该转账的凭证。
+// 跨币种转账经过银行换汇账户，两种货币各自借贷平衡；手续费记入转出货币的手续费收入账户。
 func (store *SQLStore) TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error) {
 	var result TransferTxResult
 
 	err := store.execTx(ctx, func(q *Queries) error {
 		var err error
+		result, err = transfer(ctx, q, arg)
+		return err
+	})
 
-		result.Transfer, err = q.CreateTransfer(ctx, CreateTransferParams{
-			FromAccountID: arg.FromAccountID,
-			ToAccountID:   arg.ToAccountID,
-			Amount:        arg.Amount,
-		})
-		if err != nil {
-			return err
+	return result, err
+}
+
+// transfer 在事务 q 中完成一笔转账，供 TransferTx 和冲正共用
+func transfer(ctx context.Context, q *Queries, arg TransferTxParams) (TransferTxResult, error) {
+	var result TransferTxResult
+
+	fromAccount, err := q.GetAccount(ctx, arg.FromAccountID)
+	if err != nil {
+		return result, err
+	}
+	toAccount, err := q.GetAccount(ctx, arg.ToAccountID)
+	if err != nil {
+		return result, err
+	}
+
+	toAmount, exchangeRate := arg.Amount, 1.0
+	postings := []Posting{
+		{AccountID: arg.FromAccountID, Amount: -arg.Amount},
+		{AccountID: arg.ToAccountID, Amount: arg.Amount},
+	}
+
+	if fromAccount.Currency != toAccount.Currency {
+		if arg.ToAmount <= 0 || arg.ExchangeRate <= 0 {
+			return result, fmt.Errorf("account [%d] %s vs account [%d] %s: %w",
+				fromAccount.ID, fromAccount.Currency, toAccount.ID, toAccount.Currency, ErrCurrencyMismatch)
 		}
+		toAmount, exchangeRate = arg.ToAmount, arg.ExchangeRate
 
-		result.FromEntry, err = q.CreateEntry(ctx, CreateEntryParams{
-			AccountID: arg.FromAccountID,
-			Amount:    -arg.Amount,
-		})
+		fxFromAccount, err := systemAccount(ctx, q, SystemFXOwner, fromAccount.Currency)
 		if err != nil {
-			return err
+			return result, err
 		}
-
-		result.ToEntry, err = q.CreateEntry(ctx, CreateEntryParams{
-			AccountID: arg.ToAccountID,
-			Amount:    arg.Amount,
-		})
+		fxToAccount, err := systemAccount(ctx, q, SystemFXOwner, toAccount.Currency)
 		if err != nil {
-			return err
+			return result, err
 		}
 
-		// update accounts' banlance
-		if arg.FromAccountID < arg.ToAccountID {
-			result.FromAccount, result.ToAccount, err = addMoney(ctx, q, arg.FromAccountID, -arg.Amount, arg.ToAccountID, arg.Amount)
-		} else {
-			result.FromAccount, result.ToAccount, err = addMoney(ctx, q, arg.ToAccountID, arg.Amount, arg.FromAccountID, -arg.Amount)
+		postings = []Posting{
+			{AccountID: arg.FromAccountID, Amount: -arg.Amount},
+			{AccountID: arg.ToAccountID, Amount: toAmount},
+			{AccountID: fxFromAccount.ID, Amount: arg.Amount},
+			{AccountID: fxToAccount.ID, Amount: -toAmount},
 		}
+	}
 
-		return err
-	})
-
-	return result, err
-}
+	if arg.Fee.Amount < 0 {
+		return result, fmt.Errorf("invalid transfer fee: %d", arg.Fee.Amount)
+	}
+	if arg.Fee.Amount > 0 {
+		feeAccount, err := systemAccount(ctx, q, SystemFeeOwner, fromAccount.Currency)
+		if err != nil {
+			return result, err
+		}
+		postings = append(postings,
+			Posting{AccountID: arg.FromAccountID, Amount: -arg.Fee.Amount},
+			Posting{AccountID: feeAccount.ID, Amount: arg.Fee.Amount},
+		)
+	}
 
-func addMoney(
-	ctx context.Context,
-	q *Queries,
-	accountID1 int64,
-	amount1 int64,
-	accountID2 int64,
-	amount2 int64,
-) (account1 Account, account2 Account, err error) {
-	account1, err = q.AddAccountBalance(ctx, AddAccountBalanceParams{
-		ID:     accountID1,
-		Amount: amount1,
+	result.Transfer, err = q.CreateTransfer(ctx, CreateTransferParams{
+		FromAccountID: arg.FromAccountID,
+		ToAccountID:   arg.ToAccountID,
+		Amount:        arg.Amount,
+		ToAmount:      toAmount,
+		ExchangeRate:  exchangeRate,
+		Fee:           arg.Fee.Amount,
 	})
 	if err != nil {
-		return
+		return result, err
 	}
-	account2, err = q.AddAccountBalance(ctx, AddAccountBalanceParams{
-		ID:     accountID2,
-		Amount: amount2,
+
+	// 凭证检查失败时整个事务回滚，转账记录一并撤销
+	posted, err := postJournal(ctx, q, PostJournalTxParams{
+		Description: "transfer",
+		TransferID:  result.Transfer.ID,
+		Postings:    postings,
 	})
 	if err != nil {
-		return
+		return result, err
+	}
+
+	result.Journal = posted.Journal
+	result.FromEntry, result.ToEntry = posted.Entries[0], posted.Entries[1]
+	result.FromAccount, result.ToAccount = posted.Accounts[arg.FromAccountID], posted.Accounts[arg.ToAccountID]
+	if arg.Fee.Amount > 0 {
+		result.Fee = arg.Fee
+		result.FeeEntry = posted.Entries[len(posted.Entries)-2]
+	}
+
+	// postJournal 已锁定转出账户，转账事件归在转出账户下
+	err = recordEvent(ctx, q, arg.FromAccountID, EventTransferCreated, result.Transfer)
+	return result, err
+}
+
+// sortedAccountIDs 返回 postings 涉及的账户 id，从小到大排列
+func sortedAccountIDs(postings []Posting) []int64 {
+	ids := make([]int64, 0, len(postings))
+	seen := make(map[int64]bool, len(postings))
+	for _, p := range postings {
+		if !seen[p.AccountID] {
+			seen[p.AccountID] = true
+			ids = append(ids, p.AccountID)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// netAmounts 返回每个账户在 postings 中的合计金额
+func netAmounts(postings []Posting) map[int64]int64 {
+	amounts := make(map[int64]int64, len(postings))
+	for _, p := range postings {
+		amounts[p.AccountID] += p.Amount
+	}
+	return amounts
+}
+
+// lockAccounts 按 id 顺序锁定 postings 涉及的账户，避免并发事务互相等待造成死锁
+func lockAccounts(ctx context.Context, q *Queries, postings []Posting) (map[int64]Account, error) {
+	accounts := make(map[int64]Account, len(postings))
+	for _, id := range sortedAccountIDs(postings) {
+		account, err := q.GetAccountForUpdate(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		accounts[id] = account
+	}
+	return accounts, nil
+}
+
+// createEntries 为每笔记账写一条分录，transferID 为 0 时不关联转账
+func createEntries(ctx context.Context, q *Queries, journalID int64, transferID int64, postings []Posting) ([]Entry, error) {
+	entries := make([]Entry, len(postings))
+	for i, p := range postings {
+		entry, err := q.CreateEntry(ctx, CreateEntryParams{
+			AccountID:  p.AccountID,
+			Amount:     p.Amount,
+			JournalID:  sql.NullInt64{Int64: journalID, Valid: true},
+			TransferID: sql.NullInt64{Int64: transferID, Valid: transferID != 0},
+		})
+		if err != nil {
+			return nil, err
+		}
+		entries[i] = entry
+	}
+	return entries, nil
+}
+
+// addBalances 按 id 顺序更新 postings 涉及的账户余额
+func addBalances(ctx context.Context, q *Queries, postings []Posting) (map[int64]Account, error) {
+	amounts := netAmounts(postings)
+
+	accounts := make(map[int64]Account, len(amounts))
+	for _, id := range sortedAccountIDs(postings) {
+		account, err := q.AddAccountBalance(ctx, AddAccountBalanceParams{
+			ID:     id,
+			Amount: amounts[id],
+		})
+		if err != nil {
+			return nil, err
+		}
+		accounts[id] = account
 	}
-	return
+	return accounts, nil
 }
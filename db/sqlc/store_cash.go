@@ -0,0 +1,88 @@
+package db
+
+import (
+	"context"
+)
+
+type DepositTxParams struct {
+	AccountID int64 `json:"account_id"`
+	Amount    int64 `json:"amount"`
+}
+
+type DepositTxResult struct {
+	Account   Account `json:"account"`
+	Entry     Entry   `json:"entry"`
+	CashEntry Entry   `json:"cash_entry"`
+}
+
+// DepositTx 向账户存入现金：账户记 +amount，现金清算账户记 -amount
+func (store *SQLStore) DepositTx(ctx context.Context, arg DepositTxParams) (DepositTxResult, error) {
+	var result DepositTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result.Account, result.Entry, result.CashEntry, err = postCashTx(ctx, q, "deposit", arg.AccountID, arg.Amount)
+		return err
+	})
+
+	return result, err
+}
+
+type WithdrawTxParams struct {
+	AccountID int64 `json:"account_id"`
+	Amount    int64 `json:"amount"`
+}
+
+type WithdrawTxResult struct {
+	Account   Account `json:"account"`
+	Entry     Entry   `json:"entry"`
+	CashEntry Entry   `json:"cash_entry"`
+}
+
+// WithdrawTx 从账户取出现金：账户记 -amount，现金清算账户记 +amount，
+// 余额（含透支额度）不足时返回 ErrInsufficientFunds
+func (store *SQLStore) WithdrawTx(ctx context.Context, arg WithdrawTxParams) (WithdrawTxResult, error) {
+	var result WithdrawTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result.Account, result.Entry, result.CashEntry, err = postCashTx(ctx, q, "withdrawal", arg.AccountID, -arg.Amount)
+		return err
+	})
+
+	return result, err
+}
+
+// postCashTx 在账户和对应货币的现金清算账户之间记一借一贷，amount 为负时检查余额
+func postCashTx(
+	ctx context.Context,
+	q *Queries,
+	description string,
+	accountID int64,
+	amount int64,
+) (account Account, entry Entry, cashEntry Entry, err error) {
+	account, err = q.GetAccount(ctx, accountID)
+	if err != nil {
+		return
+	}
+
+	cashAccount, err := systemAccount(ctx, q, SystemCashOwner, account.Currency)
+	if err != nil {
+		return
+	}
+
+	posted, err := postJournal(ctx, q, PostJournalTxParams{
+		Description: description,
+		Postings: []Posting{
+			{AccountID: account.ID, Amount: amount},
+			{AccountID: cashAccount.ID, Amount: -amount},
+		},
+	})
+	if err != nil {
+		return
+	}
+
+	entry, cashEntry = posted.Entries[0], posted.Entries[1]
+	account = posted.Accounts[account.ID]
+	return
+}
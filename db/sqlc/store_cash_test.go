@@ -0,0 +1,88 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDepositTx(t *testing.T) {
+	store := NewStore(testDB)
+	account := createRandomAccount(t)
+	amount := int64(10)
+
+	result, err := store.DepositTx(context.Background(), DepositTxParams{
+		AccountID: account.ID,
+		Amount:    amount,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, account.ID, result.Account.ID)
+	require.Equal(t, account.Balance+amount, result.Account.Balance)
+
+	require.Equal(t, account.ID, result.Entry.AccountID)
+	require.Equal(t, amount, result.Entry.Amount)
+	require.NotEqual(t, account.ID, result.CashEntry.AccountID)
+	require.Equal(t, -amount, result.CashEntry.Amount)
+
+	cashAccount, err := testQueries.GetAccount(context.Background(), result.CashEntry.AccountID)
+	require.NoError(t, err)
+	require.Equal(t, SystemCashOwner, cashAccount.Owner)
+	require.Equal(t, account.Currency, cashAccount.Currency)
+}
+
+func TestWithdrawTx(t *testing.T) {
+	store := NewStore(testDB)
+	account := createFundedAccount(t, 100)
+	amount := int64(10)
+
+	result, err := store.WithdrawTx(context.Background(), WithdrawTxParams{
+		AccountID: account.ID,
+		Amount:    amount,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, account.Balance-amount, result.Account.Balance)
+	require.Equal(t, -amount, result.Entry.Amount)
+	require.Equal(t, amount, result.CashEntry.Amount)
+
+	_, err = store.WithdrawTx(context.Background(), WithdrawTxParams{
+		AccountID: account.ID,
+		Amount:    result.Account.Balance + 1,
+	})
+	require.True(t, errors.Is(err, ErrInsufficientFunds))
+
+	updatedAccount, err := testQueries.GetAccount(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, result.Account.Balance, updatedAccount.Balance)
+}
+
+func TestDepositTxConcurrent(t *testing.T) {
+	store := NewStore(testDB)
+	account := createRandomAccount(t)
+
+	n := 5
+	amount := int64(10)
+	errs := make(chan error)
+
+	for i := 0; i < n; i++ {
+		go func() {
+			_, err := store.DepositTx(context.Background(), DepositTxParams{
+				AccountID: account.ID,
+				Amount:    amount,
+			})
+			errs <- err
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		err := <-errs
+		require.NoError(t, err)
+	}
+
+	updatedAccount, err := testQueries.GetAccount(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, account.Balance+int64(n)*amount, updatedAccount.Balance)
+}
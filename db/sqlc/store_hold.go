@@ -0,0 +1,176 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// 预授权的状态，只有 held 且未过期的预授权占用可用余额
+const (
+	HoldStatusHeld     = "held"
+	HoldStatusCaptured = "captured"
+	HoldStatusReleased = "released"
+	HoldStatusExpired  = "expired"
+)
+
+var (
+	// ErrHoldNotActive 预授权已扣款、已释放或已过期
+	ErrHoldNotActive = errors.New("hold is not active")
+	// ErrInvalidCaptureAmount 扣款金额超过预授权金额
+	ErrInvalidCaptureAmount = errors.New("invalid capture amount")
+)
+
+type PlaceHoldTxParams struct {
+	AccountID   int64     `json:"account_id"`
+	ToAccountID int64     `json:"to_account_id"`
+	Amount      int64     `json:"amount"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+type PlaceHoldTxResult struct {
+	Hold Hold `json:"hold"`
+	// 预授权后的可用余额
+	AvailableBalance int64 `json:"available_balance"`
+}
+
+// PlaceHoldTx 冻结账户的一部分可用余额，可用余额（含透支额度）不足时返回 ErrInsufficientFunds
+func (store *SQLStore) PlaceHoldTx(ctx context.Context, arg PlaceHoldTxParams) (PlaceHoldTxResult, error) {
+	var result PlaceHoldTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		account, err := q.GetAccountForUpdate(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+		if err := requireActive(map[int64]Account{account.ID: account}, account.ID); err != nil {
+			return err
+		}
+
+		available, err := availableBalance(ctx, q, account)
+		if err != nil {
+			return err
+		}
+		if available-arg.Amount < -account.OverdraftLimit {
+			return fmt.Errorf("account [%d] available balance %d: %w", account.ID, available, ErrInsufficientFunds)
+		}
+
+		result.Hold, err = q.CreateHold(ctx, CreateHoldParams{
+			AccountID:   arg.AccountID,
+			ToAccountID: arg.ToAccountID,
+			Amount:      arg.Amount,
+			ExpiresAt:   arg.ExpiresAt,
+		})
+		if err != nil {
+			return err
+		}
+		result.AvailableBalance = available - arg.Amount
+		return nil
+	})
+
+	return result, err
+}
+
+type CaptureHoldTxParams struct {
+	HoldID int64 `json:"hold_id"`
+	// 0 表示按预授权金额全额扣款，部分扣款时剩余金额一并释放
+	Amount int64 `json:"amount"`
+}
+
+type CaptureHoldTxResult struct {
+	Hold     Hold             `json:"hold"`
+	Transfer TransferTxResult `json:"transfer"`
+}
+
+// CaptureHoldTx 把预授权转为一笔真实的转账
+func (store *SQLStore) CaptureHoldTx(ctx context.Context, arg CaptureHoldTxParams) (CaptureHoldTxResult, error) {
+	var result CaptureHoldTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		hold, err := activeHold(ctx, q, arg.HoldID)
+		if err != nil {
+			return err
+		}
+
+		amount := arg.Amount
+		if amount == 0 {
+			amount = hold.Amount
+		}
+		if amount < 0 || amount > hold.Amount {
+			return fmt.Errorf("amount %d, held %d: %w", amount, hold.Amount, ErrInvalidCaptureAmount)
+		}
+
+		// 先结束预授权，转账检查余额时不再扣除这部分冻结金额
+		_, err = q.UpdateHoldStatus(ctx, UpdateHoldStatusParams{
+			ID:     hold.ID,
+			Status: HoldStatusCaptured,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Transfer, err = transfer(ctx, q, TransferTxParams{
+			FromAccountID: hold.AccountID,
+			ToAccountID:   hold.ToAccountID,
+			Amount:        amount,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Hold, err = q.UpdateHoldTransfer(ctx, UpdateHoldTransferParams{
+			ID:         hold.ID,
+			TransferID: sql.NullInt64{Int64: result.Transfer.Transfer.ID, Valid: true},
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// ReleaseHoldTx 释放预授权，冻结的金额回到可用余额
+func (store *SQLStore) ReleaseHoldTx(ctx context.Context, holdID int64) (Hold, error) {
+	var hold Hold
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		hold, err = activeHold(ctx, q, holdID)
+		if err != nil {
+			return err
+		}
+
+		hold, err = q.UpdateHoldStatus(ctx, UpdateHoldStatusParams{
+			ID:     hold.ID,
+			Status: HoldStatusReleased,
+		})
+		return err
+	})
+
+	return hold, err
+}
+
+// activeHold 锁定预授权并检查它仍然有效
+func activeHold(ctx context.Context, q *Queries, id int64) (Hold, error) {
+	hold, err := q.GetHoldForUpdate(ctx, id)
+	if err != nil {
+		return hold, err
+	}
+	if hold.Status != HoldStatusHeld {
+		return hold, fmt.Errorf("hold [%d] %s: %w", hold.ID, hold.Status, ErrHoldNotActive)
+	}
+	if !hold.ExpiresAt.After(time.Now()) {
+		return hold, fmt.Errorf("hold [%d] expired: %w", hold.ID, ErrHoldNotActive)
+	}
+	return hold, nil
+}
+
+// availableBalance 账户余额减去未过期的预授权金额
+func availableBalance(ctx context.Context, q *Queries, account Account) (int64, error) {
+	held, err := q.GetHeldAmount(ctx, account.ID)
+	if err != nil {
+		return 0, err
+	}
+	return account.Balance - held, nil
+}
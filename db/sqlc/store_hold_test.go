@@ -0,0 +1,132 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlaceAndCaptureHoldTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createFundedAccount(t, 100)
+	account2 := createSameCurrencyAccount(t, account1, 0)
+
+	placed, err := store.PlaceHoldTx(context.Background(), PlaceHoldTxParams{
+		AccountID:   account1.ID,
+		ToAccountID: account2.ID,
+		Amount:      60,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+	require.Equal(t, HoldStatusHeld, placed.Hold.Status)
+	require.Equal(t, int64(60), placed.Hold.Amount)
+	require.Equal(t, int64(40), placed.AvailableBalance)
+
+	held, err := testQueries.GetHeldAmount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(60), held)
+
+	// 冻结的金额不能再被转走或再次冻结
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        50,
+	})
+	require.True(t, errors.Is(err, ErrInsufficientFunds))
+
+	_, err = store.PlaceHoldTx(context.Background(), PlaceHoldTxParams{
+		AccountID:   account1.ID,
+		ToAccountID: account2.ID,
+		Amount:      50,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	require.True(t, errors.Is(err, ErrInsufficientFunds))
+
+	// 扣款金额不能超过预授权金额
+	_, err = store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{
+		HoldID: placed.Hold.ID,
+		Amount: 61,
+	})
+	require.True(t, errors.Is(err, ErrInvalidCaptureAmount))
+
+	// 部分扣款，剩余金额一并释放
+	captured, err := store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{
+		HoldID: placed.Hold.ID,
+		Amount: 45,
+	})
+	require.NoError(t, err)
+	require.Equal(t, HoldStatusCaptured, captured.Hold.Status)
+	require.True(t, captured.Hold.TransferID.Valid)
+	require.Equal(t, captured.Transfer.Transfer.ID, captured.Hold.TransferID.Int64)
+	require.Equal(t, int64(45), captured.Transfer.Transfer.Amount)
+	require.Equal(t, int64(55), captured.Transfer.FromAccount.Balance)
+	require.Equal(t, int64(45), captured.Transfer.ToAccount.Balance)
+
+	held, err = testQueries.GetHeldAmount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Zero(t, held)
+
+	_, err = store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{HoldID: placed.Hold.ID})
+	require.True(t, errors.Is(err, ErrHoldNotActive))
+}
+
+func TestReleaseHoldTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createFundedAccount(t, 100)
+	account2 := createSameCurrencyAccount(t, account1, 0)
+
+	placed, err := store.PlaceHoldTx(context.Background(), PlaceHoldTxParams{
+		AccountID:   account1.ID,
+		ToAccountID: account2.ID,
+		Amount:      100,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+	require.Zero(t, placed.AvailableBalance)
+
+	hold, err := store.ReleaseHoldTx(context.Background(), placed.Hold.ID)
+	require.NoError(t, err)
+	require.Equal(t, HoldStatusReleased, hold.Status)
+
+	held, err := testQueries.GetHeldAmount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Zero(t, held)
+
+	_, err = store.ReleaseHoldTx(context.Background(), placed.Hold.ID)
+	require.True(t, errors.Is(err, ErrHoldNotActive))
+}
+
+func TestExpireHolds(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createFundedAccount(t, 100)
+	account2 := createSameCurrencyAccount(t, account1, 0)
+
+	hold, err := testQueries.CreateHold(context.Background(), CreateHoldParams{
+		AccountID:   account1.ID,
+		ToAccountID: account2.ID,
+		Amount:      100,
+		ExpiresAt:   time.Now().Add(-time.Minute),
+	})
+	require.NoError(t, err)
+
+	// 过期的预授权不占用可用余额，也不能再扣款
+	held, err := testQueries.GetHeldAmount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Zero(t, held)
+
+	_, err = store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{HoldID: hold.ID})
+	require.True(t, errors.Is(err, ErrHoldNotActive))
+
+	err = testQueries.ExpireHolds(context.Background())
+	require.NoError(t, err)
+
+	hold, err = testQueries.GetHold(context.Background(), hold.ID)
+	require.NoError(t, err)
+	require.Equal(t, HoldStatusExpired, hold.Status)
+}
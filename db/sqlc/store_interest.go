@@ -0,0 +1,102 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// InterestMicrosPerUnit 计提利息以百万分之一个最小货币单位记录，入账时再折算
+const InterestMicrosPerUnit = 1000000
+
+type PostInterestTxParams struct {
+	AccountID int64 `json:"account_id"`
+	// 入账截至日期（含），通常是月末
+	Through time.Time `json:"through"`
+}
+
+type PostInterestTxResult struct {
+	// 入账的计提记录数，合计不足一个最小货币单位时为 0
+	Accruals int   `json:"accruals"`
+	Amount   int64 `json:"amount"`
+	// 不足一个最小货币单位、结转到下次入账的利息
+	CarriedMicros int64   `json:"carried_micros"`
+	Account       Account `json:"account"`
+	Entry         Entry   `json:"entry"`
+	ExpenseEntry  Entry   `json:"expense_entry"`
+}
+
+// PostInterestTx 把账户截至 Through 尚未入账的计提利息合计后入账：账户记 +amount，
+// 利息支出账户记 -amount。不足一个最小货币单位的部分记为一条 Through 日的结转计提，下次入账时合并；
+// 合计不足一个单位时不入账，留到下次合并
+func (store *SQLStore) PostInterestTx(ctx context.Context, arg PostInterestTxParams) (PostInterestTxResult, error) {
+	var result PostInterestTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		accruals, err := q.ListUnpostedInterestAccrualsForUpdate(ctx, ListUnpostedInterestAccrualsForUpdateParams{
+			AccountID:   arg.AccountID,
+			AccrualDate: arg.Through,
+		})
+		if err != nil {
+			return err
+		}
+
+		var micros int64
+		for _, accrual := range accruals {
+			micros += accrual.AmountMicros
+		}
+		amount := micros / InterestMicrosPerUnit
+		if amount <= 0 {
+			return nil
+		}
+
+		account, err := q.GetAccount(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+		expenseAccount, err := systemAccount(ctx, q, SystemInterestOwner, account.Currency)
+		if err != nil {
+			return err
+		}
+
+		posted, err := postJournal(ctx, q, PostJournalTxParams{
+			Description: "interest",
+			Postings: []Posting{
+				{AccountID: account.ID, Amount: amount},
+				{AccountID: expenseAccount.ID, Amount: -amount},
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		err = q.MarkInterestAccrualsPosted(ctx, MarkInterestAccrualsPostedParams{
+			AccountID:   arg.AccountID,
+			JournalID:   sql.NullInt64{Int64: posted.Journal.ID, Valid: true},
+			AccrualDate: arg.Through,
+		})
+		if err != nil {
+			return err
+		}
+
+		if remainder := micros % InterestMicrosPerUnit; remainder > 0 {
+			_, err = q.CreateInterestCarry(ctx, CreateInterestCarryParams{
+				AccountID:    arg.AccountID,
+				AccrualDate:  arg.Through,
+				AmountMicros: remainder,
+			})
+			if err != nil {
+				return err
+			}
+			result.CarriedMicros = remainder
+		}
+
+		result.Accruals = len(accruals)
+		result.Amount = amount
+		result.Account = posted.Accounts[account.ID]
+		result.Entry, result.ExpenseEntry = posted.Entries[0], posted.Entries[1]
+		return nil
+	})
+
+	return result, err
+}
@@ -0,0 +1,180 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAccountBalanceAt(t *testing.T) {
+	store := NewStore(testDB)
+
+	account := createFundedAccount(t, 100)
+	before := time.Now()
+
+	_, err := store.DepositTx(context.Background(), DepositTxParams{
+		AccountID: account.ID,
+		Amount:    50,
+	})
+	require.NoError(t, err)
+
+	balance, err := testQueries.GetAccountBalanceAt(context.Background(), GetAccountBalanceAtParams{
+		At:        before,
+		AccountID: account.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(100), balance)
+
+	balance, err = testQueries.GetAccountBalanceAt(context.Background(), GetAccountBalanceAtParams{
+		At:        time.Now().Add(time.Minute),
+		AccountID: account.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(150), balance)
+}
+
+func TestListInterestAccountsStatusAt(t *testing.T) {
+	store := NewStore(testDB)
+
+	account := createFundedAccount(t, 100)
+	listed := func(at time.Time) bool {
+		accounts, err := testQueries.ListInterestAccounts(context.Background(), ListInterestAccountsParams{
+			AccountType:   account.AccountType,
+			CreatedBefore: at,
+			AfterID:       account.ID - 1,
+			BatchSize:     1,
+		})
+		require.NoError(t, err)
+		return len(accounts) == 1 && accounts[0].ID == account.ID
+	}
+
+	active := time.Now()
+	_, err := store.UpdateAccountStatusTx(context.Background(), UpdateAccountStatusTxParams{
+		AccountID: account.ID,
+		Status:    AccountStatusFrozen,
+	})
+	require.NoError(t, err)
+	frozen := time.Now()
+
+	// 按当时的状态筛选：冻结前仍计息，冻结后不计息
+	require.True(t, listed(active))
+	require.False(t, listed(frozen))
+
+	_, err = store.UpdateAccountStatusTx(context.Background(), UpdateAccountStatusTxParams{
+		AccountID: account.ID,
+		Status:    AccountStatusActive,
+	})
+	require.NoError(t, err)
+
+	// 解冻后补跑冻结期间的日期仍然不计息
+	require.False(t, listed(frozen))
+	require.True(t, listed(time.Now()))
+}
+
+func TestPostInterestTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	account := createFundedAccount(t, 1000)
+	day1 := time.Date(2021, 3, 30, 0, 0, 0, 0, time.UTC)
+	day2 := day1.AddDate(0, 0, 1)
+
+	for _, date := range []time.Time{day1, day2} {
+		accrual, err := testQueries.CreateInterestAccrual(context.Background(), CreateInterestAccrualParams{
+			AccountID:    account.ID,
+			AccrualDate:  date,
+			Balance:      account.Balance,
+			Rate:         0.02,
+			AmountMicros: InterestMicrosPerUnit * 6 / 10,
+		})
+		require.NoError(t, err)
+		require.False(t, accrual.JournalID.Valid)
+	}
+
+	// 同一天重复计提不会新增记录
+	_, err := testQueries.CreateInterestAccrual(context.Background(), CreateInterestAccrualParams{
+		AccountID:    account.ID,
+		AccrualDate:  day1,
+		Balance:      account.Balance,
+		Rate:         0.02,
+		AmountMicros: InterestMicrosPerUnit,
+	})
+	require.Equal(t, sql.ErrNoRows, err)
+
+	// 截至第一天只有 0.6 个最小单位，不入账
+	result, err := store.PostInterestTx(context.Background(), PostInterestTxParams{
+		AccountID: account.ID,
+		Through:   day1,
+	})
+	require.NoError(t, err)
+	require.Zero(t, result.Accruals)
+	require.Zero(t, result.Amount)
+
+	// 两天合计 1.2 个最小单位，入账 1 个
+	result, err = store.PostInterestTx(context.Background(), PostInterestTxParams{
+		AccountID: account.ID,
+		Through:   day2,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, result.Accruals)
+	require.Equal(t, int64(1), result.Amount)
+	require.Equal(t, account.Balance+1, result.Account.Balance)
+	require.Equal(t, int64(1), result.Entry.Amount)
+	require.Equal(t, int64(-1), result.ExpenseEntry.Amount)
+
+	expenseAccount, err := testQueries.GetAccount(context.Background(), result.ExpenseEntry.AccountID)
+	require.NoError(t, err)
+	require.Equal(t, SystemInterestOwner, expenseAccount.Owner)
+	require.Equal(t, account.Currency, expenseAccount.Currency)
+
+	accruals, err := testQueries.ListInterestAccruals(context.Background(), ListInterestAccrualsParams{
+		AccountID: account.ID,
+		Limit:     5,
+		Offset:    0,
+	})
+	require.NoError(t, err)
+	require.Len(t, accruals, 3)
+	for _, accrual := range accruals[:2] {
+		require.Equal(t, result.Entry.JournalID, accrual.JournalID)
+		require.False(t, accrual.Carried)
+	}
+
+	// 不足一个单位的 0.2 结转为一条未入账的计提
+	require.Equal(t, int64(InterestMicrosPerUnit*2/10), result.CarriedMicros)
+	carry := accruals[2]
+	require.True(t, carry.Carried)
+	require.False(t, carry.JournalID.Valid)
+	require.WithinDuration(t, day2, carry.AccrualDate, time.Second)
+	require.Equal(t, result.CarriedMicros, carry.AmountMicros)
+
+	// 已入账的计提不会重复入账，结转的 0.2 单独不足一个单位
+	result, err = store.PostInterestTx(context.Background(), PostInterestTxParams{
+		AccountID: account.ID,
+		Through:   day2,
+	})
+	require.NoError(t, err)
+	require.Zero(t, result.Amount)
+
+	// 结转的 0.2 与第三天的 0.8 合计 1 个单位，入账后没有结转
+	day3 := day2.AddDate(0, 0, 1)
+	_, err = testQueries.CreateInterestAccrual(context.Background(), CreateInterestAccrualParams{
+		AccountID:    account.ID,
+		AccrualDate:  day3,
+		Balance:      account.Balance,
+		Rate:         0.02,
+		AmountMicros: InterestMicrosPerUnit * 8 / 10,
+	})
+	require.NoError(t, err)
+
+	result, err = store.PostInterestTx(context.Background(), PostInterestTxParams{
+		AccountID: account.ID,
+		Through:   day3,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, result.Accruals)
+	require.Equal(t, int64(1), result.Amount)
+	require.Zero(t, result.CarriedMicros)
+	require.Equal(t, account.Balance+2, result.Account.Balance)
+}
@@ -0,0 +1,133 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+var (
+	// ErrTransferFullyReversed 转账已全额冲正
+	ErrTransferFullyReversed = errors.New("transfer has been fully reversed")
+	// ErrInvalidReversalAmount 冲正金额超过剩余可冲正金额，或换算后不足最小货币单位
+	ErrInvalidReversalAmount = errors.New("invalid reversal amount")
+	// ErrReverseReversal 冲正产生的转账不能再冲正
+	ErrReverseReversal = errors.New("cannot reverse a reversal transfer")
+)
+
+type ReverseTransferTxParams struct {
+	TransferID int64 `json:"transfer_id"`
+	// 以原转账的转出货币计，0 表示冲正全部剩余金额
+	Amount      int64  `json:"amount"`
+	Reason      string `json:"reason"`
+	InitiatedBy string `json:"initiated_by"`
+}
+
+type ReverseTransferTxResult struct {
+	Reversal TransferReversal `json:"reversal"`
+	Original Transfer         `json:"original"`
+	// 反向的补偿转账，从原收款账户转回原付款账户
+	Transfer    Transfer `json:"transfer"`
+	FromAccount Account  `json:"from_account"`
+	ToAccount   Account  `json:"to_account"`
+}
+
+// ReverseTransferTx 为原转账创建一笔反向的补偿转账，支持多次部分冲正，
+// 累计金额不超过原转账金额；手续费不退还。
+// 跨币种转账按原汇率冲正，原付款方收回的金额与冲正金额一致，
+// 期间汇率变动和折算取整的差额都由换汇账户承担
+func (store *SQLStore) ReverseTransferTx(ctx context.Context, arg ReverseTransferTxParams) (ReverseTransferTxResult, error) {
+	var result ReverseTransferTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		// 锁定原转账，同一转账的冲正依次进行，防止重复冲正
+		original, err := q.GetTransferForUpdate(ctx, arg.TransferID)
+		if err != nil {
+			return err
+		}
+		result.Original = original
+
+		isReversal, err := q.IsReversalTransfer(ctx, original.ID)
+		if err != nil {
+			return err
+		}
+		if isReversal {
+			return fmt.Errorf("transfer [%d]: %w", original.ID, ErrReverseReversal)
+		}
+
+		reversed, err := q.GetReversedAmount(ctx, original.ID)
+		if err != nil {
+			return err
+		}
+		remaining := original.Amount - reversed
+		if remaining <= 0 {
+			return fmt.Errorf("transfer [%d]: %w", original.ID, ErrTransferFullyReversed)
+		}
+
+		amount := arg.Amount
+		if amount == 0 {
+			amount = remaining
+		}
+		if amount < 0 || amount > remaining {
+			return fmt.Errorf("amount %d, remaining %d: %w", amount, remaining, ErrInvalidReversalAmount)
+		}
+
+		// 按原汇率折算收款方需要退回的金额，不按当前汇率重新报价
+		toAmount, err := mulDiv(amount, original.ToAmount, original.Amount)
+		if err != nil {
+			return err
+		}
+		if toAmount <= 0 {
+			return fmt.Errorf("amount %d is too small: %w", amount, ErrInvalidReversalAmount)
+		}
+
+		// 汇率以主币单位计，取原汇率的倒数，不能用最小单位的金额相除
+		transferred, err := transfer(ctx, q, TransferTxParams{
+			FromAccountID: original.ToAccountID,
+			ToAccountID:   original.FromAccountID,
+			Amount:        toAmount,
+			ToAmount:      amount,
+			ExchangeRate:  1 / original.ExchangeRate,
+		})
+		if err != nil {
+			return err
+		}
+		result.Transfer = transferred.Transfer
+		result.FromAccount, result.ToAccount = transferred.FromAccount, transferred.ToAccount
+
+		result.Reversal, err = q.CreateTransferReversal(ctx, CreateTransferReversalParams{
+			TransferID:         original.ID,
+			ReversalTransferID: transferred.Transfer.ID,
+			Amount:             amount,
+			Reason:             arg.Reason,
+			InitiatedBy:        arg.InitiatedBy,
+		})
+		if err != nil {
+			return err
+		}
+		return recordEvent(ctx, q, original.FromAccountID, EventTransferReversed, result.Reversal)
+	})
+
+	return result, err
+}
+
+// mulDiv 计算 a * b / c 并向下取整，a、b 为非负数，c 为正数，
+// 中间结果用 128 位保存，商超出 int64 时返回 ErrInvalidReversalAmount
+func mulDiv(a, b, c int64) (int64, error) {
+	if a < 0 || b < 0 || c <= 0 {
+		return 0, fmt.Errorf("%d * %d / %d: %w", a, b, c, ErrInvalidReversalAmount)
+	}
+
+	hi, lo := bits.Mul64(uint64(a), uint64(b))
+	// hi >= c 时商超出 64 位，bits.Div64 会 panic
+	if hi >= uint64(c) {
+		return 0, fmt.Errorf("%d * %d / %d overflows: %w", a, b, c, ErrInvalidReversalAmount)
+	}
+	quo, _ := bits.Div64(hi, lo, uint64(c))
+	if quo > math.MaxInt64 {
+		return 0, fmt.Errorf("%d * %d / %d overflows: %w", a, b, c, ErrInvalidReversalAmount)
+	}
+	return int64(quo), nil
+}
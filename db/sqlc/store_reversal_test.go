@@ -0,0 +1,205 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReverseTransferTx(t *testing.T) {
+	store := NewStore(testDB)
+	banker := createRandomUser(t)
+
+	account1 := createFundedAccount(t, 100)
+	account2 := createSameCurrencyAccount(t, account1, 0)
+
+	transferred, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        50,
+	})
+	require.NoError(t, err)
+	original := transferred.Transfer
+
+	// 部分冲正
+	result, err := store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID:  original.ID,
+		Amount:      20,
+		Reason:      "partial refund",
+		InitiatedBy: banker.Username,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, original.ID, result.Original.ID)
+	require.Equal(t, original.ID, result.Reversal.TransferID)
+	require.Equal(t, result.Transfer.ID, result.Reversal.ReversalTransferID)
+	require.Equal(t, int64(20), result.Reversal.Amount)
+	require.Equal(t, "partial refund", result.Reversal.Reason)
+	require.Equal(t, banker.Username, result.Reversal.InitiatedBy)
+
+	require.Equal(t, account2.ID, result.Transfer.FromAccountID)
+	require.Equal(t, account1.ID, result.Transfer.ToAccountID)
+	require.Equal(t, int64(20), result.Transfer.Amount)
+	require.Equal(t, int64(30), result.FromAccount.Balance)
+	require.Equal(t, int64(70), result.ToAccount.Balance)
+
+	// 超过剩余金额
+	_, err = store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID:  original.ID,
+		Amount:      31,
+		InitiatedBy: banker.Username,
+	})
+	require.True(t, errors.Is(err, ErrInvalidReversalAmount))
+
+	// 冲正产生的转账不能再冲正
+	_, err = store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID:  result.Transfer.ID,
+		InitiatedBy: banker.Username,
+	})
+	require.True(t, errors.Is(err, ErrReverseReversal))
+
+	// 不填金额时冲正全部剩余金额
+	result, err = store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID:  original.ID,
+		InitiatedBy: banker.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(30), result.Reversal.Amount)
+	require.Equal(t, int64(100), result.ToAccount.Balance)
+
+	_, err = store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID:  original.ID,
+		InitiatedBy: banker.Username,
+	})
+	require.True(t, errors.Is(err, ErrTransferFullyReversed))
+
+	reversals, err := testQueries.ListTransferReversals(context.Background(), original.ID)
+	require.NoError(t, err)
+	require.Len(t, reversals, 2)
+}
+
+func TestReverseTransferTxCrossCurrency(t *testing.T) {
+	store := NewStore(testDB)
+	banker := createRandomUser(t)
+
+	account1 := createFundedAccount(t, 1000)
+	account2 := createFundedAccount(t, 0)
+	for account2.Currency == account1.Currency {
+		account2 = createFundedAccount(t, 0)
+	}
+
+	fxBalance := func(currency string) int64 {
+		account, err := testQueries.GetSystemAccount(context.Background(), GetSystemAccountParams{
+			Owner:    SystemFXOwner,
+			Currency: currency,
+		})
+		if err == sql.ErrNoRows {
+			return 0
+		}
+		require.NoError(t, err)
+		return account.Balance
+	}
+
+	transferred, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        100,
+		ToAmount:      80,
+		ExchangeRate:  0.8,
+	})
+	require.NoError(t, err)
+	original := transferred.Transfer
+	fxFrom, fxTo := fxBalance(account1.Currency), fxBalance(account2.Currency)
+
+	// 按原汇率冲正，原付款方全额收回，汇率变动由换汇账户承担
+	result, err := store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID:  original.ID,
+		Amount:      50,
+		InitiatedBy: banker.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(40), result.Transfer.Amount)
+	require.Equal(t, int64(50), result.Transfer.ToAmount)
+	require.InDelta(t, 1.25, result.Transfer.ExchangeRate, 1e-9)
+	require.Equal(t, int64(40), result.FromAccount.Balance)
+	require.Equal(t, int64(950), result.ToAccount.Balance)
+
+	require.Equal(t, fxFrom-50, fxBalance(account1.Currency))
+	require.Equal(t, fxTo+40, fxBalance(account2.Currency))
+}
+
+func TestReverseTransferTxConcurrent(t *testing.T) {
+	store := NewStore(testDB)
+	banker := createRandomUser(t)
+
+	account1 := createFundedAccount(t, 100)
+	account2 := createSameCurrencyAccount(t, account1, 0)
+
+	transferred, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        50,
+	})
+	require.NoError(t, err)
+
+	// 同时发起多次全额冲正，只有一次成功
+	n := 5
+	errs := make(chan error)
+	for i := 0; i < n; i++ {
+		go func() {
+			_, err := store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+				TransferID:  transferred.Transfer.ID,
+				InitiatedBy: banker.Username,
+			})
+			errs <- err
+		}()
+	}
+
+	succeeded := 0
+	for i := 0; i < n; i++ {
+		err := <-errs
+		if err == nil {
+			succeeded++
+			continue
+		}
+		require.True(t, errors.Is(err, ErrTransferFullyReversed))
+	}
+	require.Equal(t, 1, succeeded)
+
+	reversed, err := testQueries.GetReversedAmount(context.Background(), transferred.Transfer.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(50), reversed)
+}
+
+func TestMulDiv(t *testing.T) {
+	testCases := []struct {
+		name     string
+		a, b, c  int64
+		result   int64
+		overflow bool
+	}{
+		{name: "Exact", a: 50, b: 80, c: 100, result: 40},
+		{name: "RoundDown", a: 1, b: 2, c: 3, result: 0},
+		// a * b 超出 int64，但商在范围内
+		{name: "LargeProduct", a: math.MaxInt64, b: 3, c: 4, result: 6917529027641081855},
+		{name: "Overflow", a: math.MaxInt64, b: 2, c: 1, overflow: true},
+		{name: "QuotientOverflow", a: math.MaxInt64, b: 3, c: 2, overflow: true},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := mulDiv(tc.a, tc.b, tc.c)
+			if tc.overflow {
+				require.True(t, errors.Is(err, ErrInvalidReversalAmount))
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.result, result)
+		})
+	}
+}
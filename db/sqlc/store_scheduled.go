@@ -0,0 +1,114 @@
+package db
+
+import (
+	"context"
+	"errors"
+)
+
+// 定时转账的执行频率
+const (
+	FrequencyOnce    = "once"
+	FrequencyDaily   = "daily"
+	FrequencyWeekly  = "weekly"
+	FrequencyMonthly = "monthly"
+)
+
+// 定时转账的状态，只有 active 的会被调度器执行
+const (
+	ScheduledTransferActive    = "active"
+	ScheduledTransferPaused    = "paused"
+	ScheduledTransferCompleted = "completed"
+	ScheduledTransferCancelled = "cancelled"
+)
+
+// 定时转账每次执行的结果
+const (
+	ScheduledRunSucceeded = "succeeded"
+	// 余额不足时跳过本期
+	ScheduledRunSkipped = "skipped"
+	ScheduledRunFailed  = "failed"
+)
+
+// ErrScheduledTransferChanged 领取后定时转账被修改、暂停或被重新领取，本次不执行
+var ErrScheduledTransferChanged = errors.New("scheduled transfer changed since it was claimed")
+
+// ScheduledTransferRunRecord 一次执行的记录和下一次执行的安排
+type ScheduledTransferRunRecord struct {
+	Run CreateScheduledTransferRunParams `json:"run"`
+	// Completed 为 true 时定时转账不再执行，忽略 Reschedule
+	Completed  bool                              `json:"completed"`
+	Reschedule RescheduleScheduledTransferParams `json:"reschedule"`
+}
+
+type ExecuteScheduledTransferTxParams struct {
+	// ScheduledTransfer 调度器领取到的定时转账
+	ScheduledTransfer ScheduledTransfer `json:"scheduled_transfer"`
+	Transfer          TransferTxParams  `json:"transfer"`
+	// Record 根据转账结果决定执行记录和下一次执行，err 为转账失败的原因
+	Record func(result TransferTxResult, err error) ScheduledTransferRunRecord `json:"-"`
+}
+
+type ExecuteScheduledTransferTxResult struct {
+	// Transfer 转账失败时为空
+	Transfer          TransferTxResult     `json:"transfer"`
+	Run               ScheduledTransferRun `json:"run"`
+	ScheduledTransfer ScheduledTransfer    `json:"scheduled_transfer"`
+}
+
+// ExecuteScheduledTransferTx 在一个事务里执行定时转账、写入执行记录并安排下一次执行，
+// 转账不会在执行记录写入失败时单独生效，避免同一期被重复执行。
+// 转账失败时只回滚转账本身，执行记录照常写入。
+// 定时转账在领取后被修改过时返回 ErrScheduledTransferChanged，不做任何改动
+func (store *SQLStore) ExecuteScheduledTransferTx(ctx context.Context, arg ExecuteScheduledTransferTxParams) (ExecuteScheduledTransferTxResult, error) {
+	var result ExecuteScheduledTransferTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		result = ExecuteScheduledTransferTxResult{}
+
+		claimed := arg.ScheduledTransfer
+		current, err := q.GetScheduledTransferForUpdate(ctx, claimed.ID)
+		if err != nil {
+			return err
+		}
+		if current.Status != ScheduledTransferActive ||
+			current.Amount != claimed.Amount ||
+			current.Frequency != claimed.Frequency ||
+			!current.StartAt.Equal(claimed.StartAt) ||
+			!current.NextRunAt.Equal(claimed.NextRunAt) ||
+			!current.RunAfter.Equal(claimed.RunAfter) {
+			return ErrScheduledTransferChanged
+		}
+
+		transferErr := savepoint(ctx, q, "scheduled_transfer", func() error {
+			var err error
+			result.Transfer, err = transfer(ctx, q, arg.Transfer)
+			return err
+		})
+		if transferErr != nil {
+			result.Transfer = TransferTxResult{}
+		}
+
+		record := arg.Record(result.Transfer, transferErr)
+		record.Run.ScheduledTransferID = claimed.ID
+		record.Run.Amount = arg.Transfer.Amount
+		result.Run, err = q.CreateScheduledTransferRun(ctx, record.Run)
+		if err != nil {
+			return err
+		}
+
+		if record.Completed {
+			result.ScheduledTransfer, err = q.UpdateScheduledTransferStatus(ctx, UpdateScheduledTransferStatusParams{
+				ID:     claimed.ID,
+				Status: ScheduledTransferCompleted,
+			})
+			return err
+		}
+
+		reschedule := record.Reschedule
+		reschedule.ID = claimed.ID
+		result.ScheduledTransfer, err = q.RescheduleScheduledTransfer(ctx, reschedule)
+		return err
+	})
+
+	return result, err
+}
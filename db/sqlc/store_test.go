@@ -2,16 +2,30 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 )
 
+func createFundedAccount(t *testing.T, balance int64) Account {
+	account := createRandomAccount(t)
+
+	account, err := testQueries.UpdateAccount(context.Background(), UpdateAccountParams{
+		ID:      account.ID,
+		Balance: balance,
+	})
+	require.NoError(t, err)
+	require.Equal(t, balance, account.Balance)
+	return account
+}
+
 func TestTransferTx(t *testing.T) {
 	store := NewStore(testDB)
 
-	account1 := createRandomAccount(t)
-	account2 := createRandomAccount(t)
+	account1 := createFundedAccount(t, 1000)
+	account2 := createFundedAccount(t, 1000)
 
 	n := 5
 	amount := int64(10)
@@ -71,6 +85,12 @@ func TestTransferTx(t *testing.T) {
 		_, err = store.GetEntry(context.Background(), toEntry.ID)
 		require.NoError(t, err)
 
+		// 两条分录属于同一凭证并关联到转账
+		require.Equal(t, transfer.ID, fromEntry.TransferID.Int64)
+		require.Equal(t, transfer.ID, toEntry.TransferID.Int64)
+		require.Equal(t, result.Journal.ID, fromEntry.JournalID.Int64)
+		require.Equal(t, result.Journal.ID, toEntry.JournalID.Int64)
+
 		fromAccount := result.FromAccount
 		require.NotEmpty(t, fromAccount)
 		require.Equal(t, fromAccount.ID, account1.ID)
@@ -107,8 +127,8 @@ func TestTransferTx(t *testing.T) {
 func TestTransferTxDeadlock(t *testing.T) {
 	store := NewStore(testDB)
 
-	account1 := createRandomAccount(t)
-	account2 := createRandomAccount(t)
+	account1 := createFundedAccount(t, 1000)
+	account2 := createFundedAccount(t, 1000)
 
 	n := 10
 	amount := int64(10)
@@ -151,3 +171,151 @@ func TestTransferTxDeadlock(t *testing.T) {
 	require.Equal(t, updateAccount2.Balance, account2.Balance)
 
 }
+
+func TestTransferTxInsufficientFunds(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createFundedAccount(t, 10)
+	account2 := createFundedAccount(t, 10)
+
+	_, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        11,
+	})
+	require.Error(t, err)
+	require.True(t, errors.Is(err, ErrInsufficientFunds))
+
+	// 转账失败时余额不变
+	updateAccount1, err := testQueries.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance, updateAccount1.Balance)
+
+	updateAccount2, err := testQueries.GetAccount(context.Background(), account2.ID)
+	require.NoError(t, err)
+	require.Equal(t, account2.Balance, updateAccount2.Balance)
+}
+
+func TestTransferTxOverdraftLimit(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createFundedAccount(t, 10)
+	account2 := createFundedAccount(t, 10)
+
+	account1, err := testQueries.UpdateAccountOverdraftLimit(context.Background(), UpdateAccountOverdraftLimitParams{
+		ID:             account1.ID,
+		OverdraftLimit: 20,
+	})
+	require.NoError(t, err)
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        30,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(-20), result.FromAccount.Balance)
+	require.Equal(t, int64(40), result.ToAccount.Balance)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        1,
+	})
+	require.True(t, errors.Is(err, ErrInsufficientFunds))
+}
+
+func TestTransferTxCrossCurrency(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createFundedAccount(t, 1000)
+	account2 := createFundedAccount(t, 1000)
+	for account2.Currency == account1.Currency {
+		account2 = createFundedAccount(t, 1000)
+	}
+
+	arg := TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	}
+
+	// 缺少报价时拒绝跨币种转账
+	_, err := store.TransferTx(context.Background(), arg)
+	require.True(t, errors.Is(err, ErrCurrencyMismatch))
+
+	arg.ToAmount = 8
+	arg.ExchangeRate = 0.8
+	result, err := store.TransferTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	require.Equal(t, arg.Amount, result.Transfer.Amount)
+	require.Equal(t, arg.ToAmount, result.Transfer.ToAmount)
+	require.Equal(t, arg.ExchangeRate, result.Transfer.ExchangeRate)
+
+	require.Equal(t, -arg.Amount, result.FromEntry.Amount)
+	require.Equal(t, arg.ToAmount, result.ToEntry.Amount)
+	require.Equal(t, account1.Balance-arg.Amount, result.FromAccount.Balance)
+	require.Equal(t, account2.Balance+arg.ToAmount, result.ToAccount.Balance)
+
+	fxAccount, err := testQueries.GetSystemAccount(context.Background(), GetSystemAccountParams{
+		Owner:    SystemFXOwner,
+		Currency: account1.Currency,
+	})
+	require.NoError(t, err)
+	require.Equal(t, account1.Currency, fxAccount.Currency)
+
+	// 跨币种转账有四条分录，都关联到同一转账
+	entries, err := testQueries.ListTransferEntries(context.Background(), sql.NullInt64{Int64: result.Transfer.ID, Valid: true})
+	require.NoError(t, err)
+	require.Len(t, entries, 4)
+	for _, entry := range entries {
+		require.Equal(t, result.Journal.ID, entry.JournalID.Int64)
+	}
+}
+
+func TestTransferTxWithFee(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createFundedAccount(t, 100)
+	account2 := createSameCurrencyAccount(t, account1, 0)
+
+	arg := TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        50,
+		Fee: TransferFee{
+			Flat:       2,
+			Percentage: 1,
+			Amount:     3,
+		},
+	}
+	result, err := store.TransferTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	require.Equal(t, arg.Fee.Amount, result.Transfer.Fee)
+	require.Equal(t, arg.Fee, result.Fee)
+	require.Equal(t, account1.ID, result.FeeEntry.AccountID)
+	require.Equal(t, -arg.Fee.Amount, result.FeeEntry.Amount)
+	require.Equal(t, result.Transfer.ID, result.FeeEntry.TransferID.Int64)
+
+	require.Equal(t, int64(47), result.FromAccount.Balance)
+	require.Equal(t, int64(50), result.ToAccount.Balance)
+
+	feeAccount, err := testQueries.GetSystemAccount(context.Background(), GetSystemAccountParams{
+		Owner:    SystemFeeOwner,
+		Currency: account1.Currency,
+	})
+	require.NoError(t, err)
+
+	entries, err := testQueries.ListTransferEntries(context.Background(), sql.NullInt64{Int64: result.Transfer.ID, Valid: true})
+	require.NoError(t, err)
+	require.Len(t, entries, 4)
+	require.Equal(t, feeAccount.ID, entries[3].AccountID)
+	require.Equal(t, arg.Fee.Amount, entries[3].Amount)
+
+	// 金额加手续费超过余额
+	arg.Amount = 46
+	_, err = store.TransferTx(context.Background(), arg)
+	require.True(t, errors.Is(err, ErrInsufficientFunds))
+}
@@ -0,0 +1,45 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+// 系统账户的所有者，每种货币各对应一个账户，用户名包含下划线，
+// 不会与通过 API 注册的用户冲突
+const (
+	// SystemCashOwner 现金清算账户，存取款时与用户账户记一借一贷
+	SystemCashOwner = "system_cash"
+	// SystemFXOwner 换汇账户，跨币种转账的点差留在该账户
+	SystemFXOwner = "system_fx"
+	// SystemFeeOwner 手续费收入账户
+	SystemFeeOwner = "system_fee"
+	// SystemInterestOwner 利息支出账户，储蓄账户的利息从该账户付出
+	SystemInterestOwner = "system_interest"
+)
+
+// isSystemOwner 判断账户是否属于银行自身，系统账户允许余额为负
+func isSystemOwner(owner string) bool {
+	return owner == SystemCashOwner || owner == SystemFXOwner || owner == SystemFeeOwner || owner == SystemInterestOwner
+}
+
+// systemAccount 返回指定所有者和货币的系统账户，不存在时创建
+func systemAccount(ctx context.Context, q *Queries, owner string, currency string) (Account, error) {
+	arg := GetSystemAccountParams{
+		Owner:    owner,
+		Currency: currency,
+	}
+	account, err := q.GetSystemAccount(ctx, arg)
+	if err != sql.ErrNoRows {
+		return account, err
+	}
+
+	err = q.CreateSystemAccount(ctx, CreateSystemAccountParams{
+		Owner:    owner,
+		Currency: currency,
+	})
+	if err != nil {
+		return account, err
+	}
+	return q.GetSystemAccount(ctx, arg)
+}
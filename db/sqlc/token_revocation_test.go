@@ -0,0 +1,61 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRevokedToken(t *testing.T) {
+	user := createRandomUser(t)
+	id := uuid.New()
+
+	revoked, err := testQueries.IsTokenRevoked(context.Background(), id)
+	require.NoError(t, err)
+	require.False(t, revoked)
+
+	arg := CreateRevokedTokenParams{
+		ID:        id,
+		Username:  user.Username,
+		ExpiresAt: time.Now().Add(time.Minute),
+	}
+	err = testQueries.CreateRevokedToken(context.Background(), arg)
+	require.NoError(t, err)
+
+	// 重复作废不报错
+	err = testQueries.CreateRevokedToken(context.Background(), arg)
+	require.NoError(t, err)
+
+	revoked, err = testQueries.IsTokenRevoked(context.Background(), id)
+	require.NoError(t, err)
+	require.True(t, revoked)
+}
+
+func TestUpsertUserTokenRevocation(t *testing.T) {
+	user := createRandomUser(t)
+
+	_, err := testQueries.GetUserTokenRevocation(context.Background(), user.Username)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+
+	before := time.Now()
+	err = testQueries.UpsertUserTokenRevocation(context.Background(), UpsertUserTokenRevocationParams{
+		Username:      user.Username,
+		RevokedBefore: before,
+	})
+	require.NoError(t, err)
+
+	// 时间只会往后推
+	err = testQueries.UpsertUserTokenRevocation(context.Background(), UpsertUserTokenRevocationParams{
+		Username:      user.Username,
+		RevokedBefore: before.Add(-time.Hour),
+	})
+	require.NoError(t, err)
+
+	revocation, err := testQueries.GetUserTokenRevocation(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.WithinDuration(t, before, revocation.RevokedBefore, time.Second)
+}
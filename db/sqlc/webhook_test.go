@@ -0,0 +1,144 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xiusl/bank/util"
+)
+
+func createRandomWebhookEndpoint(t *testing.T, owner string, eventTypes ...string) WebhookEndpoint {
+	arg := CreateWebhookEndpointParams{
+		Owner:      owner,
+		Url:        "https://example.com/" + util.RandomString(6),
+		Secret:     "whsec_" + util.RandomString(32),
+		EventTypes: eventTypes,
+	}
+
+	endpoint, err := testQueries.CreateWebhookEndpoint(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Owner, endpoint.Owner)
+	require.Equal(t, arg.Url, endpoint.Url)
+	require.Equal(t, arg.Secret, endpoint.Secret)
+	require.Equal(t, arg.EventTypes, endpoint.EventTypes)
+	require.True(t, endpoint.Active)
+	return endpoint
+}
+
+func listDeliveries(t *testing.T, endpointID int64) []WebhookDelivery {
+	deliveries, err := testQueries.ListWebhookDeliveries(context.Background(), ListWebhookDeliveriesParams{
+		EndpointID: endpointID,
+		Limit:      100,
+	})
+	require.NoError(t, err)
+	return deliveries
+}
+
+func TestCreateWebhookDeliveries(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createFundedAccount(t, 100)
+	account2 := createSameCurrencyAccount(t, account1, 0)
+
+	transfers := createRandomWebhookEndpoint(t, account1.Owner, EventTransferCreated)
+	balances := createRandomWebhookEndpoint(t, account1.Owner, EventBalanceChanged, EventTransferCreated)
+	inactive := createRandomWebhookEndpoint(t, account1.Owner, EventTransferCreated)
+	_, err := testQueries.DeactivateWebhookEndpoint(context.Background(), inactive.ID)
+	require.NoError(t, err)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+
+	deliveries := listDeliveries(t, transfers.ID)
+	require.Len(t, deliveries, 1)
+	require.Equal(t, WebhookDeliveryPending, deliveries[0].Status)
+
+	event, err := testQueries.GetEvent(context.Background(), deliveries[0].EventID)
+	require.NoError(t, err)
+	require.Equal(t, EventTransferCreated, event.Type)
+	require.Equal(t, account1.ID, event.AccountID)
+
+	// 只收到 account1 自己的余额变动，收款账户属于其他用户
+	require.Len(t, listDeliveries(t, balances.ID), 2)
+	require.Empty(t, listDeliveries(t, inactive.ID))
+}
+
+func TestWebhookDeliveryLifecycle(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+	endpoint := createRandomWebhookEndpoint(t, user.Username, EventAccountCreated)
+
+	_, err := store.CreateAccountTx(context.Background(), CreateAccountParams{
+		Owner:       user.Username,
+		Currency:    util.RandomCurrency(),
+		AccountType: AccountTypeChecking,
+	})
+	require.NoError(t, err)
+
+	deliveries := listDeliveries(t, endpoint.ID)
+	require.Len(t, deliveries, 1)
+	delivery := deliveries[0]
+
+	// 领取后在租约到期前不会被再次领取
+	now := time.Now().Add(time.Minute)
+	claimed, err := testQueries.ClaimDueWebhookDeliveries(context.Background(), ClaimDueWebhookDeliveriesParams{
+		LeaseUntil: now.Add(time.Hour),
+		Now:        now,
+		BatchSize:  1000,
+	})
+	require.NoError(t, err)
+	require.Contains(t, deliveryIDs(claimed), delivery.ID)
+
+	claimed, err = testQueries.ClaimDueWebhookDeliveries(context.Background(), ClaimDueWebhookDeliveriesParams{
+		LeaseUntil: now.Add(time.Hour),
+		Now:        now,
+		BatchSize:  1000,
+	})
+	require.NoError(t, err)
+	require.NotContains(t, deliveryIDs(claimed), delivery.ID)
+
+	failed, err := testQueries.RecordWebhookDeliveryAttempt(context.Background(), RecordWebhookDeliveryAttemptParams{
+		ID:            delivery.ID,
+		Status:        WebhookDeliveryFailed,
+		ResponseCode:  500,
+		LastError:     "internal error",
+		NextAttemptAt: now,
+	})
+	require.NoError(t, err)
+	require.Equal(t, WebhookDeliveryFailed, failed.Status)
+	require.Equal(t, int32(1), failed.Attempts)
+	require.Equal(t, int32(500), failed.ResponseCode)
+
+	replayed, err := testQueries.ReplayWebhookDelivery(context.Background(), delivery.ID)
+	require.NoError(t, err)
+	require.Equal(t, WebhookDeliveryPending, replayed.Status)
+	require.Zero(t, replayed.Attempts)
+	require.Equal(t, int32(500), replayed.ResponseCode)
+
+	succeeded, err := testQueries.RecordWebhookDeliveryAttempt(context.Background(), RecordWebhookDeliveryAttemptParams{
+		ID:            delivery.ID,
+		Status:        WebhookDeliverySucceeded,
+		ResponseCode:  200,
+		NextAttemptAt: now,
+		DeliveredAt:   sql.NullTime{Time: now, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, WebhookDeliverySucceeded, succeeded.Status)
+	require.True(t, succeeded.DeliveredAt.Valid)
+	require.Empty(t, succeeded.LastError)
+}
+
+func deliveryIDs(deliveries []WebhookDelivery) []int64 {
+	ids := make([]int64, len(deliveries))
+	for i, delivery := range deliveries {
+		ids[i] = delivery.ID
+	}
+	return ids
+}
@@ -0,0 +1,97 @@
+package fee
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"strings"
+)
+
+// Rule 一种货币的收费标准：固定费用加按比例收取的费用，再限制在 [Min, Max] 之间
+type Rule struct {
+	Flat int64 `json:"flat"`
+	// 按转账金额收取的比例，例如 0.001 表示 0.1%
+	Percent float64 `json:"percent"`
+	Min     int64   `json:"min"`
+	// 0 表示不封顶
+	Max int64 `json:"max"`
+}
+
+// Breakdown 一笔转账的手续费明细，Amount 是按上下限调整后实际收取的金额
+type Breakdown struct {
+	Currency   string `json:"currency"`
+	Flat       int64  `json:"flat"`
+	Percentage int64  `json:"percentage"`
+	Amount     int64  `json:"amount"`
+}
+
+// Schedule 按货币区分的收费表，没有配置的货币不收费
+type Schedule struct {
+	rules map[string]Rule
+}
+
+// NewSchedule 创建收费表，rules 的 key 为货币代码
+func NewSchedule(rules map[string]Rule) (*Schedule, error) {
+	schedule := &Schedule{
+		rules: make(map[string]Rule, len(rules)),
+	}
+	for currency, rule := range rules {
+		if rule.Flat < 0 || rule.Min < 0 || rule.Max < 0 {
+			return nil, fmt.Errorf("invalid fee rule %s: negative amount", currency)
+		}
+		if rule.Percent < 0 || rule.Percent >= 1 {
+			return nil, fmt.Errorf("invalid fee rule %s: percent %v", currency, rule.Percent)
+		}
+		if rule.Max > 0 && rule.Max < rule.Min {
+			return nil, fmt.Errorf("invalid fee rule %s: max %d is less than min %d", currency, rule.Max, rule.Min)
+		}
+		schedule.rules[strings.ToUpper(currency)] = rule
+	}
+	return schedule, nil
+}
+
+// NewFileSchedule 从 JSON 文件加载收费表，例如 {"USD": {"flat": 10, "percent": 0.001, "max": 500}}
+func NewFileSchedule(path string) (*Schedule, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read fee schedule: %w", err)
+	}
+
+	var rules map[string]Rule
+	if err = json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("cannot parse fee schedule: %w", err)
+	}
+	return NewSchedule(rules)
+}
+
+// LoadSchedule 配置了收费表文件时从文件加载，path 为空时返回不收费的收费表
+func LoadSchedule(path string) (*Schedule, error) {
+	if len(path) == 0 {
+		return NewSchedule(nil)
+	}
+	return NewFileSchedule(path)
+}
+
+// Calculate 计算转出 amount 时的手续费，按比例的部分四舍五入到最小货币单位
+func (schedule *Schedule) Calculate(currency string, amount int64) Breakdown {
+	breakdown := Breakdown{
+		Currency: currency,
+	}
+
+	rule, ok := schedule.rules[strings.ToUpper(currency)]
+	if !ok {
+		return breakdown
+	}
+
+	breakdown.Flat = rule.Flat
+	breakdown.Percentage = int64(math.Round(float64(amount) * rule.Percent))
+	breakdown.Amount = breakdown.Flat + breakdown.Percentage
+	if breakdown.Amount < rule.Min {
+		breakdown.Amount = rule.Min
+	}
+	if rule.Max > 0 && breakdown.Amount > rule.Max {
+		breakdown.Amount = rule.Max
+	}
+	return breakdown
+}
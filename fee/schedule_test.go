@@ -0,0 +1,87 @@
+package fee
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xiusl/bank/util"
+)
+
+func TestCalculate(t *testing.T) {
+	schedule, err := NewSchedule(map[string]Rule{
+		util.USD: {Flat: 10, Percent: 0.01, Min: 15, Max: 100},
+	})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		currency string
+		amount   int64
+		expected Breakdown
+	}{
+		{
+			name:     "FlatAndPercentage",
+			currency: util.USD,
+			amount:   1000,
+			expected: Breakdown{Currency: util.USD, Flat: 10, Percentage: 10, Amount: 20},
+		},
+		{
+			name:     "Min",
+			currency: util.USD,
+			amount:   100,
+			expected: Breakdown{Currency: util.USD, Flat: 10, Percentage: 1, Amount: 15},
+		},
+		{
+			name:     "Max",
+			currency: util.USD,
+			amount:   100000,
+			expected: Breakdown{Currency: util.USD, Flat: 10, Percentage: 1000, Amount: 100},
+		},
+		{
+			name:     "NoRule",
+			currency: util.EUR,
+			amount:   1000,
+			expected: Breakdown{Currency: util.EUR},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, schedule.Calculate(tc.currency, tc.amount))
+		})
+	}
+}
+
+func TestInvalidSchedule(t *testing.T) {
+	rules := []Rule{
+		{Flat: -1},
+		{Percent: 1},
+		{Min: 20, Max: 10},
+	}
+	for _, rule := range rules {
+		schedule, err := NewSchedule(map[string]Rule{util.USD: rule})
+		require.Error(t, err)
+		require.Nil(t, schedule)
+	}
+}
+
+func TestFileSchedule(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fees")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "fees.json")
+	err = ioutil.WriteFile(path, []byte(`{"usd": {"flat": 5, "percent": 0.002}}`), 0600)
+	require.NoError(t, err)
+
+	schedule, err := NewFileSchedule(path)
+	require.NoError(t, err)
+	require.Equal(t, int64(7), schedule.Calculate(util.USD, 1000).Amount)
+
+	_, err = NewFileSchedule(filepath.Join(dir, "missing.json"))
+	require.Error(t, err)
+}
@@ -0,0 +1,60 @@
+package fx
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/xiusl/bank/money"
+)
+
+// Quote 一次换汇的报价，Rate 是扣除点差后给客户的汇率
+type Quote struct {
+	From     string  `json:"from"`
+	To       string  `json:"to"`
+	Rate     float64 `json:"rate"`
+	Amount   int64   `json:"amount"`
+	ToAmount int64   `json:"to_amount"`
+}
+
+// NewQuote 按中间汇率扣除点差 spread（例如 0.005 表示 0.5%）计算报价。
+// 汇率按货币的主单位计算，amount 和 ToAmount 是最小货币单位，换算时按两种货币的小数位数调整，
+// 例如 USD→JPY 汇率 150 时 100 美分兑换 150 日元；兑换后的金额向下取整，差额留在银行的换汇账户
+func NewQuote(ctx context.Context, provider RateProvider, from string, to string, amount int64, spread float64) (Quote, error) {
+	quote := Quote{
+		From:   from,
+		To:     to,
+		Amount: amount,
+	}
+
+	if spread < 0 || spread >= 1 {
+		return quote, fmt.Errorf("invalid exchange spread: %v", spread)
+	}
+
+	fromCurrency, ok := money.LookupCurrency(from)
+	if !ok {
+		return quote, fmt.Errorf("unknown currency %s", from)
+	}
+	toCurrency, ok := money.LookupCurrency(to)
+	if !ok {
+		return quote, fmt.Errorf("unknown currency %s", to)
+	}
+
+	rate, err := provider.Rate(ctx, from, to)
+	if err != nil {
+		return quote, err
+	}
+
+	quote.Rate = rate * (1 - spread)
+	converted := float64(amount) * quote.Rate
+	if exponent := toCurrency.Exponent - fromCurrency.Exponent; exponent >= 0 {
+		converted *= math.Pow10(exponent)
+	} else {
+		converted /= math.Pow10(-exponent)
+	}
+	quote.ToAmount = int64(math.Floor(converted))
+	if quote.ToAmount <= 0 {
+		return quote, ErrAmountTooSmall
+	}
+	return quote, nil
+}
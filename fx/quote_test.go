@@ -0,0 +1,77 @@
+package fx
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xiusl/bank/util"
+)
+
+func TestNewQuote(t *testing.T) {
+	provider, err := NewStaticRateProvider(map[string]float64{
+		"USD/EUR": 0.8,
+	})
+	require.NoError(t, err)
+
+	quote, err := NewQuote(context.Background(), provider, util.USD, util.EUR, 1000, 0.01)
+	require.NoError(t, err)
+	require.Equal(t, util.USD, quote.From)
+	require.Equal(t, util.EUR, quote.To)
+	require.Equal(t, int64(1000), quote.Amount)
+	require.InDelta(t, 0.792, quote.Rate, 1e-9)
+	require.Equal(t, int64(792), quote.ToAmount)
+
+	// 兑换金额向下取整
+	quote, err = NewQuote(context.Background(), provider, util.USD, util.EUR, 3, 0)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), quote.ToAmount)
+}
+
+func TestNewQuoteExponents(t *testing.T) {
+	provider, err := NewStaticRateProvider(map[string]float64{
+		"USD/JPY": 150,
+		"JPY/USD": 0.0067,
+		"USD/KWD": 0.3,
+	})
+	require.NoError(t, err)
+
+	// 100 美分（1 美元）兑换 150 日元
+	quote, err := NewQuote(context.Background(), provider, util.USD, "JPY", 100, 0)
+	require.NoError(t, err)
+	require.Equal(t, int64(150), quote.ToAmount)
+
+	// 15000 日元兑换 100.5 美元，即 10050 美分
+	quote, err = NewQuote(context.Background(), provider, "JPY", util.USD, 15000, 0)
+	require.NoError(t, err)
+	require.Equal(t, int64(10050), quote.ToAmount)
+
+	// 不足 1 美分时拒绝
+	_, err = NewQuote(context.Background(), provider, "JPY", util.USD, 1, 0)
+	require.True(t, errors.Is(err, ErrAmountTooSmall))
+
+	// 1000 美分（10 美元）兑换 3 第纳尔，即 3000 fils
+	quote, err = NewQuote(context.Background(), provider, util.USD, "KWD", 1000, 0)
+	require.NoError(t, err)
+	require.Equal(t, int64(3000), quote.ToAmount)
+}
+
+func TestNewQuoteErrors(t *testing.T) {
+	provider, err := NewStaticRateProvider(map[string]float64{
+		"USD/EUR": 0.8,
+	})
+	require.NoError(t, err)
+
+	_, err = NewQuote(context.Background(), provider, util.USD, "JPY", 1000, 0)
+	require.True(t, errors.Is(err, ErrRateNotFound))
+
+	_, err = NewQuote(context.Background(), provider, util.USD, util.EUR, 1, 0)
+	require.True(t, errors.Is(err, ErrAmountTooSmall))
+
+	_, err = NewQuote(context.Background(), provider, util.USD, util.EUR, 1000, 1)
+	require.Error(t, err)
+
+	_, err = NewQuote(context.Background(), provider, util.USD, "XXX", 1000, 0)
+	require.Error(t, err)
+}
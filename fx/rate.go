@@ -0,0 +1,23 @@
+package fx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrRateNotFound   = errors.New("exchange rate not found")
+	ErrAmountTooSmall = errors.New("converted amount is too small")
+)
+
+// RateProvider 提供货币之间的中间汇率：1 单位 from 货币可以兑换 rate 单位 to 货币
+type RateProvider interface {
+	Rate(ctx context.Context, from string, to string) (float64, error)
+}
+
+// pair 货币对的表示形式，例如 USD/EUR
+func pair(from string, to string) string {
+	return fmt.Sprintf("%s/%s", strings.ToUpper(from), strings.ToUpper(to))
+}
@@ -0,0 +1,55 @@
+package fx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+)
+
+// StaticRateProvider 使用固定汇率表的 RateProvider，
+// 只配置了反方向汇率的货币对使用其倒数
+type StaticRateProvider struct {
+	rates map[string]float64
+}
+
+// NewStaticRateProvider 创建固定汇率的 RateProvider，rates 的 key 形如 USD/EUR
+func NewStaticRateProvider(rates map[string]float64) (*StaticRateProvider, error) {
+	provider := &StaticRateProvider{
+		rates: make(map[string]float64, len(rates)),
+	}
+	for key, rate := range rates {
+		if rate <= 0 {
+			return nil, fmt.Errorf("invalid exchange rate %s: %v", key, rate)
+		}
+		provider.rates[key] = rate
+	}
+	return provider, nil
+}
+
+// NewFileRateProvider 从 JSON 文件加载汇率表，例如 {"USD/EUR": 0.92}
+func NewFileRateProvider(path string) (*StaticRateProvider, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read exchange rates: %w", err)
+	}
+
+	var rates map[string]float64
+	if err = json.Unmarshal(data, &rates); err != nil {
+		return nil, fmt.Errorf("cannot parse exchange rates: %w", err)
+	}
+	return NewStaticRateProvider(rates)
+}
+
+func (provider *StaticRateProvider) Rate(ctx context.Context, from string, to string) (float64, error) {
+	if from == to {
+		return 1, nil
+	}
+	if rate, ok := provider.rates[pair(from, to)]; ok {
+		return rate, nil
+	}
+	if rate, ok := provider.rates[pair(to, from)]; ok {
+		return 1 / rate, nil
+	}
+	return 0, fmt.Errorf("%s: %w", pair(from, to), ErrRateNotFound)
+}
@@ -0,0 +1,64 @@
+package fx
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xiusl/bank/util"
+)
+
+func TestStaticRateProvider(t *testing.T) {
+	provider, err := NewStaticRateProvider(map[string]float64{
+		"USD/EUR": 0.8,
+	})
+	require.NoError(t, err)
+
+	rate, err := provider.Rate(context.Background(), util.USD, util.EUR)
+	require.NoError(t, err)
+	require.Equal(t, 0.8, rate)
+
+	rate, err = provider.Rate(context.Background(), util.EUR, util.USD)
+	require.NoError(t, err)
+	require.Equal(t, 1.25, rate)
+
+	rate, err = provider.Rate(context.Background(), util.USD, util.USD)
+	require.NoError(t, err)
+	require.Equal(t, 1.0, rate)
+
+	rate, err = provider.Rate(context.Background(), util.USD, "JPY")
+	require.True(t, errors.Is(err, ErrRateNotFound))
+	require.Zero(t, rate)
+}
+
+func TestInvalidStaticRate(t *testing.T) {
+	provider, err := NewStaticRateProvider(map[string]float64{
+		"USD/EUR": 0,
+	})
+	require.Error(t, err)
+	require.Nil(t, provider)
+}
+
+func TestFileRateProvider(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rates")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "rates.json")
+	err = ioutil.WriteFile(path, []byte(`{"EUR/USD": 1.1}`), 0600)
+	require.NoError(t, err)
+
+	provider, err := NewFileRateProvider(path)
+	require.NoError(t, err)
+
+	rate, err := provider.Rate(context.Background(), util.EUR, util.USD)
+	require.NoError(t, err)
+	require.Equal(t, 1.1, rate)
+
+	_, err = NewFileRateProvider(filepath.Join(dir, "missing.json"))
+	require.Error(t, err)
+}
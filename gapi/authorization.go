@@ -0,0 +1,65 @@
+package gapi
+
+import (
+	"context"
+	"strings"
+
+	"github.com/xiusl/bank/token"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	authorizationHeaderKey  = "authorization"
+	authorizationTypeBearer = "bearer"
+)
+
+// authorizeUser 校验 metadata 中的 authorization: bearer <access token>，
+// 与 HTTP 服务的 authMiddleware 规则相同
+func (server *Server) authorizeUser(ctx context.Context) (*token.Payload, error) {
+	payload, err := server.verifyBearerToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	revoked, err := server.revocations.IsRevoked(ctx, payload)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%s", err)
+	}
+	if revoked {
+		return nil, status.Error(codes.Unauthenticated, "token has been revoked")
+	}
+
+	return payload, nil
+}
+
+// verifyBearerToken 解析并校验 access token，不检查是否已被作废
+func (server *Server) verifyBearerToken(ctx context.Context) (*token.Payload, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "missing metadata")
+	}
+
+	values := md.Get(authorizationHeaderKey)
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "authorization header is not provided")
+	}
+
+	fields := strings.Fields(values[0])
+	if len(fields) < 2 {
+		return nil, status.Error(codes.Unauthenticated, "invalid authorization header format")
+	}
+
+	authorizationType := strings.ToLower(fields[0])
+	if authorizationType != authorizationTypeBearer {
+		return nil, status.Errorf(codes.Unauthenticated, "unsupported authorization type %s", authorizationType)
+	}
+
+	payload, err := server.tokenMaker.VerifyToken(fields[1], token.AccessToken)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "token error")
+	}
+
+	return payload, nil
+}
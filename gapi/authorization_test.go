@@ -0,0 +1,138 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/xiusl/bank/db/mock"
+	"github.com/xiusl/bank/token"
+	"github.com/xiusl/bank/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestAuthorizeUser(t *testing.T) {
+	username := util.RandomOwner()
+
+	testCases := []struct {
+		name       string
+		buildCtx   func(t *testing.T, server *Server) context.Context
+		checkError func(t *testing.T, err error)
+	}{
+		{
+			name: "OK",
+			buildCtx: func(t *testing.T, server *Server) context.Context {
+				return newContextWithBearerToken(t, server.tokenMaker, username, util.DepositorRole, time.Minute)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "NoMetadata",
+			buildCtx: func(t *testing.T, server *Server) context.Context {
+				return context.Background()
+			},
+			checkError: func(t *testing.T, err error) {
+				requireStatusCode(t, codes.Unauthenticated, err)
+			},
+		},
+		{
+			name: "NoAuthorization",
+			buildCtx: func(t *testing.T, server *Server) context.Context {
+				return metadata.NewIncomingContext(context.Background(), metadata.MD{})
+			},
+			checkError: func(t *testing.T, err error) {
+				requireStatusCode(t, codes.Unauthenticated, err)
+			},
+		},
+		{
+			name: "UnsupportedAuthorization",
+			buildCtx: func(t *testing.T, server *Server) context.Context {
+				accessToken, _, err := server.tokenMaker.CreateToken(username, util.DepositorRole, token.AccessToken, time.Minute)
+				require.NoError(t, err)
+				md := metadata.Pairs(authorizationHeaderKey, "basic "+accessToken)
+				return metadata.NewIncomingContext(context.Background(), md)
+			},
+			checkError: func(t *testing.T, err error) {
+				requireStatusCode(t, codes.Unauthenticated, err)
+			},
+		},
+		{
+			name: "InvalidAuthorizationFormat",
+			buildCtx: func(t *testing.T, server *Server) context.Context {
+				md := metadata.Pairs(authorizationHeaderKey, authorizationTypeBearer)
+				return metadata.NewIncomingContext(context.Background(), md)
+			},
+			checkError: func(t *testing.T, err error) {
+				requireStatusCode(t, codes.Unauthenticated, err)
+			},
+		},
+		{
+			name: "ExpiredToken",
+			buildCtx: func(t *testing.T, server *Server) context.Context {
+				return newContextWithBearerToken(t, server.tokenMaker, username, util.DepositorRole, -time.Minute)
+			},
+			checkError: func(t *testing.T, err error) {
+				requireStatusCode(t, codes.Unauthenticated, err)
+			},
+		},
+		{
+			name: "RefreshToken",
+			buildCtx: func(t *testing.T, server *Server) context.Context {
+				refreshToken, _, err := server.tokenMaker.CreateToken(username, util.DepositorRole, token.RefreshToken, time.Hour)
+				require.NoError(t, err)
+				md := metadata.Pairs(authorizationHeaderKey, authorizationTypeBearer+" "+refreshToken)
+				return metadata.NewIncomingContext(context.Background(), md)
+			},
+			checkError: func(t *testing.T, err error) {
+				requireStatusCode(t, codes.Unauthenticated, err)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			server := newTestServer(t, store)
+
+			payload, err := server.authorizeUser(tc.buildCtx(t, server))
+			tc.checkError(t, err)
+			if err == nil {
+				require.Equal(t, username, payload.Username)
+			}
+		})
+	}
+}
+
+func TestAuthorizeUserRevokedToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	server := newTestServer(t, store)
+
+	username := util.RandomOwner()
+	accessToken, payload, err := server.tokenMaker.CreateToken(username, util.DepositorRole, token.AccessToken, time.Minute)
+	require.NoError(t, err)
+
+	md := metadata.Pairs(authorizationHeaderKey, authorizationTypeBearer+" "+accessToken)
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	_, err = server.authorizeUser(ctx)
+	require.NoError(t, err)
+
+	err = server.revocations.Revoke(ctx, payload.ID, payload.Username, payload.ExpiredAt)
+	require.NoError(t, err)
+
+	_, err = server.authorizeUser(ctx)
+	requireStatusCode(t, codes.Unauthenticated, err)
+}
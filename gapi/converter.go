@@ -0,0 +1,54 @@
+package gapi
+
+import (
+	db "github.com/xiusl/bank/db/sqlc"
+	"github.com/xiusl/bank/money"
+	"github.com/xiusl/bank/pb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func convertUser(user db.User) *pb.User {
+	return &pb.User{
+		Username:          user.Username,
+		Role:              user.Role,
+		FullName:          user.FullName,
+		Email:             user.Email,
+		PasswordChangedAt: timestamppb.New(user.PasswordChangedAt),
+		CreatedAt:         timestamppb.New(user.CreatedAt),
+	}
+}
+
+func convertMoney(m money.Money) *pb.Money {
+	return &pb.Money{
+		Amount:   m.Decimal(),
+		Currency: m.Currency,
+	}
+}
+
+func convertAccount(account db.Account) *pb.Account {
+	return &pb.Account{
+		Id:             account.ID,
+		Owner:          account.Owner,
+		Currency:       account.Currency,
+		AccountType:    account.AccountType,
+		Nickname:       account.Nickname,
+		Balance:        convertMoney(money.New(account.Balance, account.Currency)),
+		OverdraftLimit: convertMoney(money.New(account.OverdraftLimit, account.Currency)),
+		Status:         account.Status,
+		CreatedAt:      timestamppb.New(account.CreatedAt),
+	}
+}
+
+// convertTransfer 金额按两端账户的货币格式化，手续费以转出货币计
+func convertTransfer(transfer db.Transfer, fromCurrency string, toCurrency string) *pb.Transfer {
+	return &pb.Transfer{
+		Id:            transfer.ID,
+		FromAccountId: transfer.FromAccountID,
+		ToAccountId:   transfer.ToAccountID,
+		Amount:        convertMoney(money.New(transfer.Amount, fromCurrency)),
+		ToAmount:      convertMoney(money.New(transfer.ToAmount, toCurrency)),
+		ExchangeRate:  transfer.ExchangeRate,
+		Fee:           convertMoney(money.New(transfer.Fee, fromCurrency)),
+		CreatedAt:     timestamppb.New(transfer.CreatedAt),
+	}
+}
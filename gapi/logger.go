@@ -0,0 +1,52 @@
+package gapi
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/xiusl/bank/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// grpcLogger 与 HTTP 服务的 requestLogger 相同：沿用 metadata 中合法的 x-request-id，否则生成新的，
+// 放入 ctx 并在响应 header 中返回；调用结束后输出一行 JSON 日志，服务端错误以 error 级别记录
+func (server *Server) grpcLogger(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+
+	var requestID string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(logger.RequestIDHeader); len(values) > 0 {
+			requestID = values[0]
+		}
+	}
+	if !logger.ValidRequestID(requestID) {
+		requestID = logger.NewRequestID()
+	}
+	ctx = logger.WithRequestID(ctx, requestID)
+	grpc.SetHeader(ctx, metadata.Pairs(strings.ToLower(logger.RequestIDHeader), requestID))
+
+	result, err := handler(ctx, req)
+
+	code := status.Code(err)
+	fields := logger.Fields{
+		"method":     info.FullMethod,
+		"status":     code.String(),
+		"latency_ms": float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if payload, err := server.verifyBearerToken(ctx); err == nil {
+		fields["username"] = payload.Username
+	}
+
+	switch code {
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable:
+		fields["error"] = status.Convert(err).Message()
+		logger.Error(ctx, "rpc failed", fields)
+	default:
+		logger.Info(ctx, "rpc", fields)
+	}
+	return result, err
+}
@@ -0,0 +1,109 @@
+package gapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/xiusl/bank/db/mock"
+	"github.com/xiusl/bank/logger"
+	"github.com/xiusl/bank/util"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestGrpcLogger(t *testing.T) {
+	username := util.RandomOwner()
+	info := &grpc.UnaryServerInfo{FullMethod: "/pb.Bank/GetAccount"}
+
+	testCases := []struct {
+		name       string
+		buildCtx   func(t *testing.T, server *Server) context.Context
+		handlerErr error
+		checkEntry func(t *testing.T, handlerRequestID string, entry map[string]interface{})
+	}{
+		{
+			name: "OK",
+			buildCtx: func(t *testing.T, server *Server) context.Context {
+				ctx := newContextWithBearerToken(t, server.tokenMaker, username, util.DepositorRole, time.Minute)
+				md, _ := metadata.FromIncomingContext(ctx)
+				md = metadata.Join(md, metadata.Pairs("x-request-id", "client-request-1"))
+				return metadata.NewIncomingContext(ctx, md)
+			},
+			checkEntry: func(t *testing.T, handlerRequestID string, entry map[string]interface{}) {
+				require.Equal(t, "client-request-1", handlerRequestID)
+				require.Equal(t, logger.LevelInfo, entry["level"])
+				require.Equal(t, "client-request-1", entry["request_id"])
+				require.Equal(t, info.FullMethod, entry["method"])
+				require.Equal(t, codes.OK.String(), entry["status"])
+				require.Equal(t, username, entry["username"])
+				require.NotContains(t, entry, "error")
+			},
+		},
+		{
+			name: "GenerateRequestID",
+			buildCtx: func(t *testing.T, server *Server) context.Context {
+				return context.Background()
+			},
+			handlerErr: status.Error(codes.NotFound, "sql: no rows in result set"),
+			checkEntry: func(t *testing.T, handlerRequestID string, entry map[string]interface{}) {
+				require.True(t, logger.ValidRequestID(handlerRequestID))
+				require.Equal(t, handlerRequestID, entry["request_id"])
+				require.Equal(t, logger.LevelInfo, entry["level"])
+				require.Equal(t, codes.NotFound.String(), entry["status"])
+				require.NotContains(t, entry, "username")
+			},
+		},
+		{
+			name: "InternalError",
+			buildCtx: func(t *testing.T, server *Server) context.Context {
+				return context.Background()
+			},
+			handlerErr: status.Error(codes.Internal, "connection refused"),
+			checkEntry: func(t *testing.T, handlerRequestID string, entry map[string]interface{}) {
+				require.Equal(t, logger.LevelError, entry["level"])
+				require.Equal(t, codes.Internal.String(), entry["status"])
+				require.Equal(t, "connection refused", entry["error"])
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			var buf bytes.Buffer
+			logger.SetOutput(&buf)
+			defer logger.SetOutput(ioutil.Discard)
+
+			server := newTestServer(t, mockdb.NewMockStore(ctrl))
+
+			var handlerRequestID string
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				handlerRequestID = logger.RequestID(ctx)
+				return nil, tc.handlerErr
+			}
+
+			_, err := server.grpcLogger(tc.buildCtx(t, server), nil, info, handler)
+			require.Equal(t, tc.handlerErr, err)
+
+			lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+			require.Len(t, lines, 1)
+			var entry map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(lines[0]), &entry))
+
+			tc.checkEntry(t, handlerRequestID, entry)
+		})
+	}
+}
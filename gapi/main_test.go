@@ -0,0 +1,91 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xiusl/bank/currency"
+	db "github.com/xiusl/bank/db/sqlc"
+	"github.com/xiusl/bank/fx"
+	"github.com/xiusl/bank/logger"
+	"github.com/xiusl/bank/token"
+	"github.com/xiusl/bank/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func newTestServer(t *testing.T, store db.Store) *Server {
+	config := util.Config{
+		TokenSymmertricKey:   util.RandomString(32),
+		AccessTokenDuration:  time.Minute,
+		RefreshTokenDuration: time.Hour,
+	}
+
+	rates, err := fx.NewStaticRateProvider(nil)
+	require.NoError(t, err)
+
+	// 测试中不访问货币表，直接启用 USD 和 EUR
+	currencies := currency.NewRegistry(store, 0)
+	currencies.Set(db.Currency{Code: util.USD, Exponent: 2, Enabled: true})
+	currencies.Set(db.Currency{Code: util.EUR, Exponent: 2, Enabled: true})
+
+	server, err := NewServer(config, store, token.NewMemoryRevocationStore(), rates, currencies)
+	require.NoError(t, err)
+	return server
+}
+
+// newContextWithBearerToken 为 username 签发 access token 并放入请求的 metadata
+func newContextWithBearerToken(t *testing.T, tokenMaker token.Maker, username string, role string, duration time.Duration) context.Context {
+	accessToken, _, err := tokenMaker.CreateToken(username, role, token.AccessToken, duration)
+	require.NoError(t, err)
+
+	md := metadata.MD{
+		authorizationHeaderKey: []string{fmt.Sprintf("%s %s", authorizationTypeBearer, accessToken)},
+	}
+	return metadata.NewIncomingContext(context.Background(), md)
+}
+
+func requireStatusCode(t *testing.T, code codes.Code, err error) {
+	require.Error(t, err)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, code, st.Code())
+}
+
+func randomUser(t *testing.T) (user db.User, password string) {
+	password = util.RandomString(6)
+	hashedPassword, err := util.HashPassword(password)
+	require.NoError(t, err)
+
+	user = db.User{
+		Username:       util.RandomOwner(),
+		FullName:       util.RandomOwner(),
+		HashedPassword: hashedPassword,
+		Email:          util.RandomEmail(),
+		Role:           util.DepositorRole,
+	}
+	return
+}
+
+func randomAccount(owner string, currency string) db.Account {
+	return db.Account{
+		ID:          util.RandomInt(1, 1000),
+		Owner:       owner,
+		Balance:     util.RandomMoney(),
+		Currency:    currency,
+		AccountType: db.AccountTypeChecking,
+		Status:      db.AccountStatusActive,
+	}
+}
+
+func TestMain(m *testing.M) {
+	logger.SetOutput(ioutil.Discard)
+
+	os.Exit(m.Run())
+}
@@ -0,0 +1,131 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+	db "github.com/xiusl/bank/db/sqlc"
+	"github.com/xiusl/bank/money"
+	"github.com/xiusl/bank/pb"
+	"github.com/xiusl/bank/policy"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CreateAccount 为当前登录用户开户，不传 account_type 时开活期账户
+func (server *Server) CreateAccount(ctx context.Context, req *pb.CreateAccountRequest) (*pb.CreateAccountResponse, error) {
+	payload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = server.validateFields(
+		field{"currency", req.GetCurrency(), "required,currency"},
+		field{"account_type", req.GetAccountType(), "omitempty,oneof=checking savings"},
+		field{"nickname", req.GetNickname(), "max=64"},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	accountType := req.GetAccountType()
+	if accountType == "" {
+		accountType = db.AccountTypeChecking
+	}
+
+	arg := db.CreateAccountParams{
+		Owner:       payload.Username,
+		Currency:    req.GetCurrency(),
+		Balance:     0,
+		AccountType: accountType,
+		Nickname:    req.GetNickname(),
+	}
+
+	account, err := server.store.CreateAccountTx(ctx, arg)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case "unique_violation":
+				return nil, status.Errorf(codes.AlreadyExists, "%s", err)
+			}
+		}
+		return nil, status.Errorf(codes.Internal, "%s", err)
+	}
+
+	return &pb.CreateAccountResponse{Account: convertAccount(account)}, nil
+}
+
+// GetAccount 查询账户，同时返回扣除预授权后的可用余额
+func (server *Server) GetAccount(ctx context.Context, req *pb.GetAccountRequest) (*pb.GetAccountResponse, error) {
+	payload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = server.validateFields(field{"id", req.GetId(), "required,min=1"})
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := server.store.GetAccount(ctx, req.GetId())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "%s", err)
+		}
+		return nil, status.Errorf(codes.Internal, "%s", err)
+	}
+
+	if !policy.Allows(payload, account, policy.ViewAccount) {
+		return nil, status.Error(codes.PermissionDenied, "permission denied")
+	}
+
+	held, err := server.store.GetHeldAmount(ctx, account.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%s", err)
+	}
+
+	balance := money.New(account.Balance, account.Currency)
+	available, err := balance.Sub(money.New(held, account.Currency))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%s", err)
+	}
+
+	rsp := convertAccount(account)
+	rsp.AvailableBalance = convertMoney(available)
+	return &pb.GetAccountResponse{Account: rsp}, nil
+}
+
+// ListAccounts 分页列出当前登录用户的账户
+func (server *Server) ListAccounts(ctx context.Context, req *pb.ListAccountsRequest) (*pb.ListAccountsResponse, error) {
+	payload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = server.validateFields(
+		field{"page_id", req.GetPageId(), "required,min=1"},
+		field{"page_size", req.GetPageSize(), "required,min=5,max=10"},
+		field{"account_type", req.GetAccountType(), "omitempty,oneof=checking savings"},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	arg := db.ListAccountsParams{
+		Owner:       payload.Username,
+		AccountType: req.GetAccountType(),
+		Limit:       req.GetPageSize(),
+		Offset:      (req.GetPageId() - 1) * req.GetPageSize(),
+	}
+	accounts, err := server.store.ListAccounts(ctx, arg)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%s", err)
+	}
+
+	rsp := &pb.ListAccountsResponse{Accounts: make([]*pb.Account, len(accounts))}
+	for i, account := range accounts {
+		rsp.Accounts[i] = convertAccount(account)
+	}
+	return rsp, nil
+}
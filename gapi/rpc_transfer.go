@@ -0,0 +1,121 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	db "github.com/xiusl/bank/db/sqlc"
+	"github.com/xiusl/bank/fx"
+	"github.com/xiusl/bank/money"
+	"github.com/xiusl/bank/pb"
+	"github.com/xiusl/bank/policy"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CreateTransfer 从当前登录用户的账户转账，规则与 HTTP 的 POST /transfers 相同
+func (server *Server) CreateTransfer(ctx context.Context, req *pb.CreateTransferRequest) (*pb.CreateTransferResponse, error) {
+	payload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = server.validateFields(
+		field{"from_account_id", req.GetFromAccountId(), "required,min=1"},
+		field{"to_account_id", req.GetToAccountId(), "required,min=1"},
+		field{"amount.currency", req.GetAmount().GetCurrency(), "required,currency"},
+		field{"to_currency", req.GetToCurrency(), "omitempty,currency"},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	amount, err := money.Parse(req.GetAmount().GetAmount(), req.GetAmount().GetCurrency())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", err)
+	}
+	if !amount.IsPositive() {
+		return nil, status.Error(codes.InvalidArgument, "amount must be positive")
+	}
+
+	currency := amount.Currency
+	fromAccount, err := server.validAccount(ctx, req.GetFromAccountId(), currency)
+	if err != nil {
+		return nil, err
+	}
+
+	if !policy.Allows(payload, fromAccount, policy.OperateAccount) {
+		return nil, status.Error(codes.PermissionDenied, "account doesn't belong to the authenticated user")
+	}
+
+	toCurrency := req.GetToCurrency()
+	if len(toCurrency) == 0 {
+		toCurrency = currency
+	}
+
+	toAccount, err := server.validAccount(ctx, req.GetToAccountId(), toCurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	arg := db.TransferTxParams{
+		FromAccountID: req.GetFromAccountId(),
+		ToAccountID:   req.GetToAccountId(),
+		Amount:        amount.Amount,
+	}
+
+	if toCurrency != currency {
+		quote, err := fx.NewQuote(ctx, server.rates, currency, toCurrency, amount.Amount, server.config.FXSpread)
+		if err != nil {
+			if errors.Is(err, fx.ErrRateNotFound) || errors.Is(err, fx.ErrAmountTooSmall) {
+				return nil, status.Errorf(codes.FailedPrecondition, "%s", err)
+			}
+			return nil, status.Errorf(codes.Internal, "%s", err)
+		}
+		arg.ToAmount = quote.ToAmount
+		arg.ExchangeRate = quote.Rate
+	}
+
+	// 手续费按转出货币收取
+	breakdown := server.fees.Calculate(currency, amount.Amount)
+	arg.Fee = db.TransferFee{
+		Flat:       breakdown.Flat,
+		Percentage: breakdown.Percentage,
+		Amount:     breakdown.Amount,
+	}
+
+	result, err := server.store.TransferTx(ctx, arg)
+	if err != nil {
+		if errors.Is(err, db.ErrInsufficientFunds) || errors.Is(err, db.ErrAccountNotActive) {
+			return nil, status.Errorf(codes.FailedPrecondition, "%s", err)
+		}
+		if errors.Is(err, db.ErrCurrencyMismatch) {
+			return nil, status.Errorf(codes.InvalidArgument, "%s", err)
+		}
+		return nil, status.Errorf(codes.Internal, "%s", err)
+	}
+
+	rsp := &pb.CreateTransferResponse{
+		Transfer:    convertTransfer(result.Transfer, fromAccount.Currency, toAccount.Currency),
+		FromAccount: convertAccount(result.FromAccount),
+	}
+	return rsp, nil
+}
+
+// validAccount 获取账户并检查账户货币与请求的货币一致
+func (server *Server) validAccount(ctx context.Context, accountID int64, currency string) (db.Account, error) {
+	account, err := server.store.GetAccount(ctx, accountID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return account, status.Errorf(codes.NotFound, "account [%d] not found", accountID)
+		}
+		return account, status.Errorf(codes.Internal, "%s", err)
+	}
+
+	if account.Currency != currency {
+		return account, status.Errorf(codes.InvalidArgument, "account [%d] currency mismatch:%s vs %s", accountID, account.Currency, currency)
+	}
+
+	return account, nil
+}
@@ -0,0 +1,108 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/xiusl/bank/currency"
+	db "github.com/xiusl/bank/db/sqlc"
+	"github.com/xiusl/bank/fee"
+	"github.com/xiusl/bank/fx"
+	"github.com/xiusl/bank/pb"
+	"github.com/xiusl/bank/token"
+	"github.com/xiusl/bank/util"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+// Server gRPC 服务，与 HTTP 服务使用同一个数据库和同样的 token，
+// 两边签发的 access token 可以互相使用
+type Server struct {
+	pb.UnimplementedBankServer
+	config      util.Config
+	store       db.Store
+	tokenMaker  token.Maker
+	revocations token.RevocationStore
+	rates       fx.RateProvider
+	fees        *fee.Schedule
+	currencies  *currency.Registry
+	validate    *validator.Validate
+}
+
+// NewServer 创建 gRPC 服务，作废列表、汇率和货币表与 HTTP 服务共用同一份
+func NewServer(
+	config util.Config,
+	store db.Store,
+	revocations token.RevocationStore,
+	rates fx.RateProvider,
+	currencies *currency.Registry,
+) (*Server, error) {
+	tokenMaker, err := token.NewPasetoMaker(config.TokenSymmertricKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create token maker: %w", err)
+	}
+	fees, err := fee.LoadSchedule(config.TransferFeesFile)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create fee schedule: %w", err)
+	}
+	server := &Server{
+		config:      config,
+		store:       store,
+		tokenMaker:  tokenMaker,
+		revocations: revocations,
+		rates:       rates,
+		fees:        fees,
+		currencies:  currencies,
+		validate:    validator.New(),
+	}
+
+	server.validate.RegisterValidation("currency", currencies.Validator())
+
+	return server, nil
+}
+
+// Start 在 address 上提供 gRPC 服务，并开启服务反射方便调试；货币表由调用方加载和刷新。
+// ctx 取消后不再接受新调用，等待处理中的调用完成，超过 ShutdownTimeout 时强制关闭连接
+func (server *Server) Start(ctx context.Context, address string) error {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return fmt.Errorf("cannot listen on %s: %w", address, err)
+	}
+
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(server.grpcLogger))
+	pb.RegisterBankServer(grpcServer, server)
+	reflection.Register(grpcServer)
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- grpcServer.Serve(listener)
+	}()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	if server.config.ShutdownTimeout > 0 {
+		timer := time.NewTimer(server.config.ShutdownTimeout)
+		defer timer.Stop()
+
+		select {
+		case <-stopped:
+		case <-timer.C:
+			grpcServer.Stop()
+		}
+	}
+	<-stopped
+	return nil
+}
@@ -0,0 +1,42 @@
+package gapi
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// field 请求中的一个字段和它的校验规则，规则与 HTTP 接口的 binding tag 相同
+type field struct {
+	name  string
+	value interface{}
+	tag   string
+}
+
+// validateFields 逐个校验字段，有字段不合法时返回 InvalidArgument，列出所有不合法的字段
+func (server *Server) validateFields(fields ...field) error {
+	var violations []string
+	for _, f := range fields {
+		err := server.validate.Var(f.value, f.tag)
+		if err == nil {
+			continue
+		}
+
+		var errs validator.ValidationErrors
+		if !errors.As(err, &errs) {
+			return status.Errorf(codes.Internal, "%s", err)
+		}
+		for _, e := range errs {
+			violations = append(violations, fmt.Sprintf("%s failed on the '%s' rule", f.name, e.Tag()))
+		}
+	}
+
+	if len(violations) > 0 {
+		return status.Errorf(codes.InvalidArgument, "invalid request: %s", strings.Join(violations, "; "))
+	}
+	return nil
+}
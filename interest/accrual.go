@@ -0,0 +1,178 @@
+// Package interest 按日计提储蓄等账户的利息，并在月末把当月计提的利息从利息支出账户入账
+package interest
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+
+	db "github.com/xiusl/bank/db/sqlc"
+)
+
+// batchSize 每次查询计息账户的数量
+const batchSize = 100
+
+// Report 一个计息日的执行结果
+type Report struct {
+	Date time.Time `json:"date"`
+	// 本次新增的计提记录数，已计提过的账户不重复计算
+	Accrued int `json:"accrued"`
+	// 月末入账的账户数和按货币合计的入账金额，非月末时为空
+	Posted  int              `json:"posted"`
+	Amounts map[string]int64 `json:"amounts"`
+}
+
+// Accruer 计提并入账利息。同一账户同一天只计提一次，重复执行或补跑历史日期都是安全的
+type Accruer struct {
+	store db.Store
+	rates *RateTable
+}
+
+func NewAccruer(store db.Store, rates *RateTable) *Accruer {
+	return &Accruer{
+		store: store,
+		rates: rates,
+	}
+}
+
+// Run 按 date 当天（UTC）日终余额计提利息，date 为月末时再把截至当天的计提利息入账
+func (accruer *Accruer) Run(ctx context.Context, date time.Time) (Report, error) {
+	date = Day(date)
+	report := Report{
+		Date:    date,
+		Amounts: map[string]int64{},
+	}
+
+	var err error
+	report.Accrued, err = accruer.Accrue(ctx, date)
+	if err != nil {
+		return report, err
+	}
+
+	if !IsMonthEnd(date) {
+		return report, nil
+	}
+	report.Posted, err = accruer.Post(ctx, date, report.Amounts)
+	return report, err
+}
+
+// Accrue 为 date 当天已开户且处于正常状态的计息账户计提一天的利息，返回新增的计提记录数。
+// 余额和账户状态都按当天日终计算，补跑历史日期时不受之后的交易和冻结、销户影响。
+// 状态取日终前最后一次 account.status_changed 事件；之前没有事件时由之后第一次变更反推
+// （变更为 active 说明之前是冻结或销户），都没有则使用当前状态
+func (accruer *Accruer) Accrue(ctx context.Context, date time.Time) (int, error) {
+	endOfDay := Day(date).AddDate(0, 0, 1)
+
+	accrued := 0
+	for _, accountType := range accruer.rates.AccountTypes() {
+		var afterID int64
+		for {
+			accounts, err := accruer.store.ListInterestAccounts(ctx, db.ListInterestAccountsParams{
+				AccountType:   accountType,
+				CreatedBefore: endOfDay,
+				AfterID:       afterID,
+				BatchSize:     batchSize,
+			})
+			if err != nil {
+				return accrued, err
+			}
+
+			for _, account := range accounts {
+				ok, err := accruer.accrue(ctx, account, date, endOfDay)
+				if err != nil {
+					return accrued, err
+				}
+				if ok {
+					accrued++
+				}
+			}
+
+			if len(accounts) < batchSize {
+				break
+			}
+			afterID = accounts[len(accounts)-1].ID
+		}
+	}
+	return accrued, nil
+}
+
+// accrue 计提一个账户一天的利息，没有适用利率、利息为零或当天已计提时返回 false
+func (accruer *Accruer) accrue(ctx context.Context, account db.Account, date time.Time, endOfDay time.Time) (bool, error) {
+	if !accruer.rates.Covers(account.AccountType, account.Currency) {
+		return false, nil
+	}
+
+	balance, err := accruer.store.GetAccountBalanceAt(ctx, db.GetAccountBalanceAtParams{
+		At:        endOfDay,
+		AccountID: account.ID,
+	})
+	if err != nil {
+		return false, err
+	}
+	if balance <= 0 {
+		return false, nil
+	}
+
+	rate, ok := accruer.rates.Rate(account.AccountType, account.Currency, balance)
+	if !ok {
+		return false, nil
+	}
+	micros := DailyInterest(balance, rate)
+	if micros <= 0 {
+		return false, nil
+	}
+
+	_, err = accruer.store.CreateInterestAccrual(ctx, db.CreateInterestAccrualParams{
+		AccountID:    account.ID,
+		AccrualDate:  date,
+		Balance:      balance,
+		Rate:         rate,
+		AmountMicros: micros,
+	})
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// Post 把截至 through 尚未入账的计提利息按账户入账，返回入账的账户数，并把入账金额按货币累加到 amounts。
+// 账户已冻结或销户时跳过，计提记录保留到账户恢复后的下一次入账
+func (accruer *Accruer) Post(ctx context.Context, through time.Time, amounts map[string]int64) (int, error) {
+	accountIDs, err := accruer.store.ListUnpostedInterestAccounts(ctx, Day(through))
+	if err != nil {
+		return 0, err
+	}
+
+	posted := 0
+	for _, accountID := range accountIDs {
+		result, err := accruer.store.PostInterestTx(ctx, db.PostInterestTxParams{
+			AccountID: accountID,
+			Through:   Day(through),
+		})
+		if errors.Is(err, db.ErrAccountNotActive) {
+			log.Printf("skip posting interest to account [%d]: %v", accountID, err)
+			continue
+		}
+		if err != nil {
+			return posted, err
+		}
+		if result.Amount > 0 {
+			posted++
+			amounts[result.Account.Currency] += result.Amount
+		}
+	}
+	return posted, nil
+}
+
+// Day 返回 t 所在日期（UTC）的零点
+func Day(t time.Time) time.Time {
+	year, month, day := t.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// IsMonthEnd 判断 date 是否为当月最后一天
+func IsMonthEnd(date time.Time) bool {
+	return Day(date).AddDate(0, 0, 1).Day() == 1
+}
@@ -0,0 +1,206 @@
+package interest
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/xiusl/bank/db/mock"
+	db "github.com/xiusl/bank/db/sqlc"
+	"github.com/xiusl/bank/util"
+)
+
+func newTestRateTable(t *testing.T) *RateTable {
+	table, err := NewRateTable(map[string]map[string][]Tier{
+		db.AccountTypeSavings: {
+			util.USD: {{MinBalance: 0, Rate: 0.0365}},
+		},
+	})
+	require.NoError(t, err)
+	return table
+}
+
+func TestAccrue(t *testing.T) {
+	// 计息日按 UTC 日期计算，与具体时刻无关
+	now := time.Date(2021, 3, 15, 13, 30, 0, 0, time.UTC)
+	date := time.Date(2021, 3, 15, 0, 0, 0, 0, time.UTC)
+	endOfDay := date.AddDate(0, 0, 1)
+
+	accrued := db.Account{ID: 1, Currency: util.USD, AccountType: db.AccountTypeSavings}
+	uncovered := db.Account{ID: 2, Currency: util.EUR, AccountType: db.AccountTypeSavings}
+	empty := db.Account{ID: 3, Currency: util.USD, AccountType: db.AccountTypeSavings}
+	duplicate := db.Account{ID: 4, Currency: util.USD, AccountType: db.AccountTypeSavings}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ListInterestAccounts(gomock.Any(), gomock.Eq(db.ListInterestAccountsParams{
+			AccountType:   db.AccountTypeSavings,
+			CreatedBefore: endOfDay,
+			BatchSize:     batchSize,
+		})).
+		Times(1).
+		Return([]db.Account{accrued, uncovered, empty, duplicate}, nil)
+
+	store.EXPECT().
+		GetAccountBalanceAt(gomock.Any(), gomock.Eq(db.GetAccountBalanceAtParams{At: endOfDay, AccountID: accrued.ID})).
+		Times(1).
+		Return(int64(100000), nil)
+	store.EXPECT().
+		GetAccountBalanceAt(gomock.Any(), gomock.Eq(db.GetAccountBalanceAtParams{At: endOfDay, AccountID: uncovered.ID})).
+		Times(0)
+	store.EXPECT().
+		GetAccountBalanceAt(gomock.Any(), gomock.Eq(db.GetAccountBalanceAtParams{At: endOfDay, AccountID: empty.ID})).
+		Times(1).
+		Return(int64(0), nil)
+	store.EXPECT().
+		GetAccountBalanceAt(gomock.Any(), gomock.Eq(db.GetAccountBalanceAtParams{At: endOfDay, AccountID: duplicate.ID})).
+		Times(1).
+		Return(int64(100000), nil)
+
+	store.EXPECT().
+		CreateInterestAccrual(gomock.Any(), gomock.Eq(db.CreateInterestAccrualParams{
+			AccountID:    accrued.ID,
+			AccrualDate:  date,
+			Balance:      100000,
+			Rate:         0.0365,
+			AmountMicros: 10 * db.InterestMicrosPerUnit,
+		})).
+		Times(1).
+		Return(db.InterestAccrual{ID: 1}, nil)
+	store.EXPECT().
+		CreateInterestAccrual(gomock.Any(), gomock.Eq(db.CreateInterestAccrualParams{
+			AccountID:    duplicate.ID,
+			AccrualDate:  date,
+			Balance:      100000,
+			Rate:         0.0365,
+			AmountMicros: 10 * db.InterestMicrosPerUnit,
+		})).
+		Times(1).
+		Return(db.InterestAccrual{}, sql.ErrNoRows)
+
+	store.EXPECT().
+		ListUnpostedInterestAccounts(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	report, err := NewAccruer(store, newTestRateTable(t)).Run(context.Background(), now)
+	require.NoError(t, err)
+	require.Equal(t, date, report.Date)
+	require.Equal(t, 1, report.Accrued)
+	require.Zero(t, report.Posted)
+	require.Empty(t, report.Amounts)
+}
+
+func TestAccruePagination(t *testing.T) {
+	date := time.Date(2021, 3, 15, 0, 0, 0, 0, time.UTC)
+
+	accounts := make([]db.Account, batchSize)
+	for i := range accounts {
+		accounts[i] = db.Account{ID: int64(i + 1), Currency: util.EUR, AccountType: db.AccountTypeSavings}
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	gomock.InOrder(
+		store.EXPECT().
+			ListInterestAccounts(gomock.Any(), gomock.Eq(db.ListInterestAccountsParams{
+				AccountType:   db.AccountTypeSavings,
+				CreatedBefore: date.AddDate(0, 0, 1),
+				BatchSize:     batchSize,
+			})).
+			Times(1).
+			Return(accounts, nil),
+		store.EXPECT().
+			ListInterestAccounts(gomock.Any(), gomock.Eq(db.ListInterestAccountsParams{
+				AccountType:   db.AccountTypeSavings,
+				CreatedBefore: date.AddDate(0, 0, 1),
+				AfterID:       batchSize,
+				BatchSize:     batchSize,
+			})).
+			Times(1).
+			Return([]db.Account{}, nil),
+	)
+	store.EXPECT().
+		GetAccountBalanceAt(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	n, err := NewAccruer(store, newTestRateTable(t)).Accrue(context.Background(), date)
+	require.NoError(t, err)
+	require.Zero(t, n)
+}
+
+func TestPostAtMonthEnd(t *testing.T) {
+	date := time.Date(2021, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ListInterestAccounts(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return([]db.Account{}, nil)
+	store.EXPECT().
+		ListUnpostedInterestAccounts(gomock.Any(), gomock.Eq(date)).
+		Times(1).
+		Return([]int64{1, 2, 3}, nil)
+
+	store.EXPECT().
+		PostInterestTx(gomock.Any(), gomock.Eq(db.PostInterestTxParams{AccountID: 1, Through: date})).
+		Times(1).
+		Return(db.PostInterestTxResult{
+			Accruals: 31,
+			Amount:   310,
+			Account:  db.Account{ID: 1, Currency: util.USD},
+		}, nil)
+	store.EXPECT().
+		PostInterestTx(gomock.Any(), gomock.Eq(db.PostInterestTxParams{AccountID: 2, Through: date})).
+		Times(1).
+		Return(db.PostInterestTxResult{}, fmt.Errorf("account [2] status frozen: %w", db.ErrAccountNotActive))
+	store.EXPECT().
+		PostInterestTx(gomock.Any(), gomock.Eq(db.PostInterestTxParams{AccountID: 3, Through: date})).
+		Times(1).
+		Return(db.PostInterestTxResult{}, nil)
+
+	report, err := NewAccruer(store, newTestRateTable(t)).Run(context.Background(), date)
+	require.NoError(t, err)
+	require.Zero(t, report.Accrued)
+	require.Equal(t, 1, report.Posted)
+	require.Equal(t, map[string]int64{util.USD: 310}, report.Amounts)
+}
+
+func TestPostError(t *testing.T) {
+	date := time.Date(2021, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ListUnpostedInterestAccounts(gomock.Any(), gomock.Eq(date)).
+		Times(1).
+		Return([]int64{1}, nil)
+	store.EXPECT().
+		PostInterestTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(db.PostInterestTxResult{}, sql.ErrConnDone)
+
+	_, err := NewAccruer(store, newTestRateTable(t)).Post(context.Background(), date, map[string]int64{})
+	require.Equal(t, sql.ErrConnDone, err)
+}
+
+func TestIsMonthEnd(t *testing.T) {
+	require.True(t, IsMonthEnd(time.Date(2021, 2, 28, 23, 0, 0, 0, time.UTC)))
+	require.False(t, IsMonthEnd(time.Date(2020, 2, 28, 0, 0, 0, 0, time.UTC)))
+	require.True(t, IsMonthEnd(time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC)))
+	require.True(t, IsMonthEnd(time.Date(2021, 12, 31, 0, 0, 0, 0, time.UTC)))
+	require.False(t, IsMonthEnd(time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)))
+}
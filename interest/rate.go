@@ -0,0 +1,121 @@
+package interest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"sort"
+	"strings"
+
+	db "github.com/xiusl/bank/db/sqlc"
+)
+
+// daysPerYear 按实际天数/365 计息
+const daysPerYear = 365
+
+// Tier 余额达到 MinBalance（最小货币单位）时适用的年利率
+type Tier struct {
+	MinBalance int64 `json:"min_balance"`
+	// 年利率，例如 0.02 表示 2%
+	Rate float64 `json:"rate"`
+}
+
+// RateTable 按账户类型和货币区分的分档利率表，没有配置的账户类型和货币不计息
+type RateTable struct {
+	tiers map[string]map[string][]Tier
+}
+
+// NewRateTable 创建利率表，tiers 的 key 依次为账户类型和货币代码
+func NewRateTable(tiers map[string]map[string][]Tier) (*RateTable, error) {
+	table := &RateTable{
+		tiers: make(map[string]map[string][]Tier, len(tiers)),
+	}
+	for accountType, currencies := range tiers {
+		if accountType != db.AccountTypeChecking && accountType != db.AccountTypeSavings {
+			return nil, fmt.Errorf("invalid interest rates: unknown account type %s", accountType)
+		}
+
+		table.tiers[accountType] = make(map[string][]Tier, len(currencies))
+		for currency, list := range currencies {
+			if len(list) == 0 {
+				return nil, fmt.Errorf("invalid interest rates %s %s: no tiers", accountType, currency)
+			}
+
+			sorted := make([]Tier, len(list))
+			copy(sorted, list)
+			sort.Slice(sorted, func(i, j int) bool {
+				return sorted[i].MinBalance < sorted[j].MinBalance
+			})
+			for i, tier := range sorted {
+				if tier.MinBalance < 0 {
+					return nil, fmt.Errorf("invalid interest rates %s %s: negative min balance", accountType, currency)
+				}
+				if tier.Rate < 0 || tier.Rate >= 1 {
+					return nil, fmt.Errorf("invalid interest rates %s %s: rate %v", accountType, currency, tier.Rate)
+				}
+				if i > 0 && tier.MinBalance == sorted[i-1].MinBalance {
+					return nil, fmt.Errorf("invalid interest rates %s %s: duplicate min balance %d", accountType, currency, tier.MinBalance)
+				}
+			}
+			table.tiers[accountType][strings.ToUpper(currency)] = sorted
+		}
+	}
+	return table, nil
+}
+
+// NewFileRateTable 从 JSON 文件加载利率表，
+// 例如 {"savings": {"USD": [{"min_balance": 0, "rate": 0.01}, {"min_balance": 1000000, "rate": 0.02}]}}
+func NewFileRateTable(path string) (*RateTable, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read interest rates: %w", err)
+	}
+
+	var tiers map[string]map[string][]Tier
+	if err = json.Unmarshal(data, &tiers); err != nil {
+		return nil, fmt.Errorf("cannot parse interest rates: %w", err)
+	}
+	return NewRateTable(tiers)
+}
+
+// LoadRateTable 配置了利率表文件时从文件加载，path 为空时返回不计息的利率表
+func LoadRateTable(path string) (*RateTable, error) {
+	if len(path) == 0 {
+		return NewRateTable(nil)
+	}
+	return NewFileRateTable(path)
+}
+
+// AccountTypes 返回配置了利率的账户类型，按名称排序
+func (table *RateTable) AccountTypes() []string {
+	types := make([]string, 0, len(table.tiers))
+	for accountType := range table.tiers {
+		types = append(types, accountType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// Covers 判断是否为该账户类型和货币配置了利率
+func (table *RateTable) Covers(accountType string, currency string) bool {
+	_, ok := table.tiers[accountType][strings.ToUpper(currency)]
+	return ok
+}
+
+// Rate 返回余额适用的年利率：取 MinBalance 不超过余额的最高一档，整个余额按该档计息。
+// 没有配置或余额低于最低一档时返回 false
+func (table *RateTable) Rate(accountType string, currency string, balance int64) (float64, bool) {
+	tiers := table.tiers[accountType][strings.ToUpper(currency)]
+	for i := len(tiers) - 1; i >= 0; i-- {
+		if balance >= tiers[i].MinBalance {
+			return tiers[i].Rate, true
+		}
+	}
+	return 0, false
+}
+
+// DailyInterest 计算余额一天的利息，单位为百万分之一个最小货币单位，四舍五入
+func DailyInterest(balance int64, rate float64) int64 {
+	return int64(math.Round(float64(balance) * rate * db.InterestMicrosPerUnit / daysPerYear))
+}
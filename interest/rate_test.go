@@ -0,0 +1,143 @@
+package interest
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	db "github.com/xiusl/bank/db/sqlc"
+	"github.com/xiusl/bank/util"
+)
+
+func TestRate(t *testing.T) {
+	table, err := NewRateTable(map[string]map[string][]Tier{
+		db.AccountTypeSavings: {
+			util.USD: {
+				{MinBalance: 1000000, Rate: 0.03},
+				{MinBalance: 0, Rate: 0.01},
+				{MinBalance: 100000, Rate: 0.02},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name        string
+		accountType string
+		currency    string
+		balance     int64
+		rate        float64
+		ok          bool
+	}{
+		{
+			name:        "LowestTier",
+			accountType: db.AccountTypeSavings,
+			currency:    util.USD,
+			balance:     99999,
+			rate:        0.01,
+			ok:          true,
+		},
+		{
+			name:        "TierBoundary",
+			accountType: db.AccountTypeSavings,
+			currency:    util.USD,
+			balance:     100000,
+			rate:        0.02,
+			ok:          true,
+		},
+		{
+			name:        "HighestTier",
+			accountType: db.AccountTypeSavings,
+			currency:    util.USD,
+			balance:     5000000,
+			rate:        0.03,
+			ok:          true,
+		},
+		{
+			name:        "NoCurrency",
+			accountType: db.AccountTypeSavings,
+			currency:    util.EUR,
+			balance:     100000,
+		},
+		{
+			name:        "NoAccountType",
+			accountType: db.AccountTypeChecking,
+			currency:    util.USD,
+			balance:     100000,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			rate, ok := table.Rate(tc.accountType, tc.currency, tc.balance)
+			require.Equal(t, tc.ok, ok)
+			require.Equal(t, tc.rate, rate)
+		})
+	}
+
+	require.Equal(t, []string{db.AccountTypeSavings}, table.AccountTypes())
+	require.True(t, table.Covers(db.AccountTypeSavings, util.USD))
+	require.False(t, table.Covers(db.AccountTypeSavings, util.EUR))
+}
+
+func TestMinBalanceNotMet(t *testing.T) {
+	table, err := NewRateTable(map[string]map[string][]Tier{
+		db.AccountTypeSavings: {
+			util.USD: {{MinBalance: 10000, Rate: 0.02}},
+		},
+	})
+	require.NoError(t, err)
+
+	_, ok := table.Rate(db.AccountTypeSavings, util.USD, 9999)
+	require.False(t, ok)
+}
+
+func TestInvalidRateTable(t *testing.T) {
+	testCases := []map[string]map[string][]Tier{
+		{"brokerage": {util.USD: {{Rate: 0.01}}}},
+		{db.AccountTypeSavings: {util.USD: {}}},
+		{db.AccountTypeSavings: {util.USD: {{MinBalance: -1, Rate: 0.01}}}},
+		{db.AccountTypeSavings: {util.USD: {{Rate: -0.01}}}},
+		{db.AccountTypeSavings: {util.USD: {{Rate: 1}}}},
+		{db.AccountTypeSavings: {util.USD: {{Rate: 0.01}, {Rate: 0.02}}}},
+	}
+	for _, tiers := range testCases {
+		table, err := NewRateTable(tiers)
+		require.Error(t, err)
+		require.Nil(t, table)
+	}
+}
+
+func TestDailyInterest(t *testing.T) {
+	// 1000.00 按年利率 3.65% 每天的利息正好是 0.10
+	require.Equal(t, int64(10*db.InterestMicrosPerUnit), DailyInterest(100000, 0.0365))
+	// 1.00 按年利率 1% 每天的利息约为 0.0027 个最小单位，不足一个最小单位也要保留
+	require.Equal(t, int64(2740), DailyInterest(100, 0.01))
+	require.Zero(t, DailyInterest(0, 0.01))
+}
+
+func TestFileRateTable(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rates")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "rates.json")
+	err = ioutil.WriteFile(path, []byte(`{"savings": {"usd": [{"min_balance": 0, "rate": 0.02}]}}`), 0600)
+	require.NoError(t, err)
+
+	table, err := NewFileRateTable(path)
+	require.NoError(t, err)
+	rate, ok := table.Rate(db.AccountTypeSavings, util.USD, 100)
+	require.True(t, ok)
+	require.Equal(t, 0.02, rate)
+
+	_, err = NewFileRateTable(filepath.Join(dir, "missing.json"))
+	require.Error(t, err)
+
+	table, err = LoadRateTable("")
+	require.NoError(t, err)
+	require.Empty(t, table.AccountTypes())
+}
@@ -0,0 +1,81 @@
+package logger
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// 日志级别
+const (
+	LevelInfo  = "info"
+	LevelError = "error"
+)
+
+// Fields 日志的附加字段，error 类型的值按错误信息输出
+type Fields map[string]interface{}
+
+// Logger 结构化日志，每条日志输出一行 JSON，
+// 包含 time、level、msg，ctx 中有请求 ID 时还包含 request_id
+type Logger struct {
+	mu  sync.Mutex
+	out io.Writer
+}
+
+// New 创建输出到 out 的 Logger
+func New(out io.Writer) *Logger {
+	return &Logger{out: out}
+}
+
+var std = New(os.Stdout)
+
+// SetOutput 设置默认 Logger 的输出
+func SetOutput(out io.Writer) {
+	std.mu.Lock()
+	defer std.mu.Unlock()
+	std.out = out
+}
+
+// Info 用默认 Logger 输出 info 级别的日志
+func Info(ctx context.Context, msg string, fields Fields) {
+	std.Log(ctx, LevelInfo, msg, fields)
+}
+
+// Error 用默认 Logger 输出 error 级别的日志
+func Error(ctx context.Context, msg string, fields Fields) {
+	std.Log(ctx, LevelError, msg, fields)
+}
+
+// Log 输出一条日志，fields 中与固定字段同名的字段会被覆盖
+func (logger *Logger) Log(ctx context.Context, level string, msg string, fields Fields) {
+	entry := make(map[string]interface{}, len(fields)+4)
+	for key, value := range fields {
+		if err, ok := value.(error); ok {
+			value = err.Error()
+		}
+		entry[key] = value
+	}
+	entry["time"] = time.Now().UTC().Format(time.RFC3339Nano)
+	entry["level"] = level
+	entry["msg"] = msg
+	if requestID := RequestID(ctx); len(requestID) > 0 {
+		entry["request_id"] = requestID
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		data, _ = json.Marshal(map[string]interface{}{
+			"time":  entry["time"],
+			"level": LevelError,
+			"msg":   "cannot encode log entry",
+			"error": err.Error(),
+		})
+	}
+
+	logger.mu.Lock()
+	defer logger.mu.Unlock()
+	logger.out.Write(append(data, '\n'))
+}
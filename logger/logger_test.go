@@ -0,0 +1,103 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func decodeEntries(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	var entries []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var entry map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestLog(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf)
+
+	ctx := WithRequestID(context.Background(), "req-1")
+	logger.Log(ctx, LevelError, "request failed", Fields{
+		"status": 500,
+		"error":  errors.New("connection refused"),
+	})
+	logger.Log(context.Background(), LevelInfo, "request", nil)
+
+	entries := decodeEntries(t, &buf)
+	require.Len(t, entries, 2)
+
+	require.Equal(t, LevelError, entries[0]["level"])
+	require.Equal(t, "request failed", entries[0]["msg"])
+	require.Equal(t, "req-1", entries[0]["request_id"])
+	require.Equal(t, float64(500), entries[0]["status"])
+	require.Equal(t, "connection refused", entries[0]["error"])
+	require.NotEmpty(t, entries[0]["time"])
+
+	require.Equal(t, LevelInfo, entries[1]["level"])
+	require.NotContains(t, entries[1], "request_id")
+}
+
+func TestLogUnencodableField(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf)
+
+	logger.Log(context.Background(), LevelInfo, "request", Fields{"bad": make(chan int)})
+
+	entries := decodeEntries(t, &buf)
+	require.Len(t, entries, 1)
+	require.Equal(t, LevelError, entries[0]["level"])
+	require.Equal(t, "cannot encode log entry", entries[0]["msg"])
+}
+
+// stringKeyContext 模拟 gin 1.7 的 Context.Value，只能查找字符串键
+type stringKeyContext struct {
+	context.Context
+	keys map[string]interface{}
+}
+
+func (ctx stringKeyContext) Value(key interface{}) interface{} {
+	if s, ok := key.(string); ok {
+		return ctx.keys[s]
+	}
+	return nil
+}
+
+func TestRequestID(t *testing.T) {
+	require.Empty(t, RequestID(context.Background()))
+	require.Equal(t, "req-1", RequestID(WithRequestID(context.Background(), "req-1")))
+
+	ctx := stringKeyContext{
+		Context: context.Background(),
+		keys:    map[string]interface{}{RequestIDKey: "req-2"},
+	}
+	require.Equal(t, "req-2", RequestID(ctx))
+}
+
+func TestValidRequestID(t *testing.T) {
+	testCases := []struct {
+		requestID string
+		valid     bool
+	}{
+		{NewRequestID(), true},
+		{"abc_DEF-123.4", true},
+		{strings.Repeat("a", maxRequestIDLength), true},
+		{"", false},
+		{strings.Repeat("a", maxRequestIDLength+1), false},
+		{"a b", false},
+		{"*/ DROP TABLE accounts; /*", false},
+		{"请求", false},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, tc.valid, ValidRequestID(tc.requestID), tc.requestID)
+	}
+}
@@ -0,0 +1,60 @@
+package logger
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// RequestIDHeader 客户端可以通过该请求头传入请求 ID，响应中返回实际使用的请求 ID
+	RequestIDHeader = "X-Request-ID"
+	// RequestIDKey *gin.Context 中保存请求 ID 的键。
+	// gin 1.7 的 Context.Value 只查找字符串键，处理函数把 *gin.Context 传给 Store 时靠它取得请求 ID
+	RequestIDKey = "request_id"
+
+	maxRequestIDLength = 64
+)
+
+type requestIDContextKey struct{}
+
+// NewRequestID 生成新的请求 ID
+func NewRequestID() string {
+	return uuid.New().String()
+}
+
+// ValidRequestID 请求 ID 会写入日志和 SQL 注释，
+// 只接受不超过 64 个字符的字母、数字和 - _ .
+func ValidRequestID(requestID string) bool {
+	if len(requestID) == 0 || len(requestID) > maxRequestIDLength {
+		return false
+	}
+	for _, c := range requestID {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-', c == '_', c == '.':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// WithRequestID 返回带有请求 ID 的 context
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey{}, requestID)
+}
+
+// RequestID 取出 ctx 中的请求 ID，没有时返回空字符串
+func RequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if requestID, ok := ctx.Value(requestIDContextKey{}).(string); ok {
+		return requestID
+	}
+	if requestID, ok := ctx.Value(RequestIDKey).(string); ok {
+		return requestID
+	}
+	return ""
+}
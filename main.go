@@ -1,13 +1,31 @@
 package main
 
 import (
+	"context"
 	"database/sql"
+	"encoding/json"
+	"flag"
 	"log"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 
 	_ "github.com/lib/pq"
 	"github.com/xiusl/bank/api"
+	"github.com/xiusl/bank/currency"
 	db "github.com/xiusl/bank/db/sqlc"
+	"github.com/xiusl/bank/fee"
+	"github.com/xiusl/bank/fx"
+	"github.com/xiusl/bank/gapi"
+	"github.com/xiusl/bank/interest"
+	"github.com/xiusl/bank/outbox"
+	"github.com/xiusl/bank/reconcile"
+	"github.com/xiusl/bank/scheduler"
+	"github.com/xiusl/bank/token"
 	"github.com/xiusl/bank/util"
+	"github.com/xiusl/bank/webhook"
 )
 
 func main() {
@@ -22,13 +40,242 @@ func main() {
 	}
 
 	store := db.NewStore(conn)
-	server, err := api.NewServer(config, store)
+
+	if len(os.Args) > 1 && os.Args[1] == "reconcile" {
+		runReconcile(store)
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "accrue" {
+		runAccrue(config, store, os.Args[2:])
+		return
+	}
+
+	// 收到 SIGINT/SIGTERM 后取消 ctx：服务停止接受新请求并处理完进行中的请求，
+	// 后台任务随之退出，未完成的一轮回滚后留待下次启动重试
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var wg sync.WaitGroup
+
+	// 作废列表、汇率和货币表由 HTTP 和 gRPC 服务共用：
+	// 一边注销的 token 另一边也立即失效，管理员修改的货币两边同时生效
+	revocations := newRevocationStore(config, store)
+	rates, err := newRateProvider(config)
+	if err != nil {
+		log.Fatal("cannot create rate provider:", err)
+	}
+	currencies := currency.NewRegistry(store, config.CurrencyRefreshInterval)
+	if err := currencies.Refresh(ctx); err != nil {
+		log.Fatal("cannot load currencies:", err)
+	}
+	if config.CurrencyRefreshInterval > 0 {
+		runInBackground(&wg, func() { currencies.Start(ctx) })
+	}
+
+	if config.SchedulerInterval > 0 {
+		startScheduler(ctx, &wg, config, store)
+	}
+
+	if config.HoldExpiryInterval > 0 {
+		startHoldExpirer(ctx, &wg, config, store)
+	}
+
+	if len(config.EventPublisher) > 0 {
+		startRelay(ctx, &wg, config, store)
+	}
+
+	if config.WebhookInterval > 0 {
+		startWebhookDispatcher(ctx, &wg, config, store)
+	}
+
+	var grpcErrs <-chan error
+	if len(config.GRPCServerAddress) > 0 {
+		grpcErrs = runGrpcServer(ctx, stop, &wg, config, store, revocations, rates, currencies)
+	}
+
+	server, err := api.NewServer(config, store, revocations, rates, currencies)
 	if err != nil {
 		log.Fatal("connot create server:", err)
 	}
 
-	err = server.Start(config.ServerAddress)
+	log.Printf("start HTTP server at %s", config.ServerAddress)
+	err = server.Start(ctx, config.ServerAddress)
+	if err != nil {
+		log.Println("HTTP server stopped with error:", err)
+	}
+
+	// HTTP 服务退出后通知其他服务和后台任务停止，全部退出后再关闭数据库连接
+	stop()
+	wg.Wait()
+	if err := conn.Close(); err != nil {
+		log.Println("cannot close db:", err)
+	}
+	log.Println("server stopped")
+
+	// 任一服务异常退出时以非零状态退出
+	if err == nil {
+		select {
+		case err = <-grpcErrs:
+		default:
+		}
+	}
 	if err != nil {
-		log.Fatal("connot start server:", err)
+		os.Exit(1)
+	}
+}
+
+// runInBackground 在后台执行 task，wg 用于等待它退出
+func runInBackground(wg *sync.WaitGroup, task func()) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		task()
+	}()
+}
+
+// newRevocationStore 多实例部署时使用数据库共享作废列表，未配置时保存在内存中
+func newRevocationStore(config util.Config, store db.Store) token.RevocationStore {
+	if config.RevocationStore == "postgres" {
+		return token.NewPostgresRevocationStore(store)
+	}
+	return token.NewMemoryRevocationStore()
+}
+
+// newRateProvider 配置了汇率文件时从文件加载汇率，否则不支持跨币种转账
+func newRateProvider(config util.Config) (fx.RateProvider, error) {
+	if len(config.FXRatesFile) == 0 {
+		return fx.NewStaticRateProvider(nil)
+	}
+	return fx.NewFileRateProvider(config.FXRatesFile)
+}
+
+// runGrpcServer 在 GRPC_SERVER_ADDRESS 上提供 gRPC 服务，与 HTTP 服务共用数据库、作废列表、汇率和货币表。
+// 服务异常退出时调用 stop 让 HTTP 服务和后台任务一起停止，错误从返回的 channel 取得
+func runGrpcServer(
+	ctx context.Context,
+	stop context.CancelFunc,
+	wg *sync.WaitGroup,
+	config util.Config,
+	store db.Store,
+	revocations token.RevocationStore,
+	rates fx.RateProvider,
+	currencies *currency.Registry,
+) <-chan error {
+	server, err := gapi.NewServer(config, store, revocations, rates, currencies)
+	if err != nil {
+		log.Fatal("cannot create gRPC server:", err)
+	}
+
+	errs := make(chan error, 1)
+	runInBackground(wg, func() {
+		log.Printf("start gRPC server at %s", config.GRPCServerAddress)
+		if err := server.Start(ctx, config.GRPCServerAddress); err != nil {
+			log.Println("gRPC server stopped with error:", err)
+			errs <- err
+			stop()
+		}
+	})
+	return errs
+}
+
+// startScheduler 在后台执行到期的定时转账
+func startScheduler(ctx context.Context, wg *sync.WaitGroup, config util.Config, store db.Store) {
+	fees, err := fee.LoadSchedule(config.TransferFeesFile)
+	if err != nil {
+		log.Fatal("cannot load fee schedule:", err)
+	}
+
+	transferScheduler := scheduler.NewScheduler(
+		store,
+		fees,
+		config.SchedulerInterval,
+		config.SchedulerRetryDelay,
+		config.SchedulerMaxAttempts,
+	)
+	runInBackground(wg, func() { transferScheduler.Start(ctx) })
+}
+
+// startHoldExpirer 在后台定期把过期的预授权标记为 expired
+func startHoldExpirer(ctx context.Context, wg *sync.WaitGroup, config util.Config, store db.Store) {
+	expirer := scheduler.NewHoldExpirer(store, config.HoldExpiryInterval)
+	runInBackground(wg, func() { expirer.Start(ctx) })
+}
+
+// startRelay 在后台把账户和转账事件投递给 EVENT_PUBLISHER 指定的下游
+func startRelay(ctx context.Context, wg *sync.WaitGroup, config util.Config, store db.Store) {
+	publisher, err := outbox.NewPublisher(config.EventPublisher, config.EventPublisherTarget)
+	if err != nil {
+		log.Fatal("cannot create event publisher:", err)
+	}
+
+	relay := outbox.NewRelay(store, publisher, config.EventRelayInterval)
+	runInBackground(wg, func() { relay.Start(ctx) })
+}
+
+// startWebhookDispatcher 在后台把事件投递给用户注册的 webhook
+func startWebhookDispatcher(ctx context.Context, wg *sync.WaitGroup, config util.Config, store db.Store) {
+	dispatcher := webhook.NewDispatcher(store, config.WebhookInterval, config.WebhookMaxAttempts)
+	runInBackground(wg, func() { dispatcher.Start(ctx) })
+}
+
+// runReconcile 核对一次账本并输出报告，发现偏差时以非零状态退出
+func runReconcile(store db.Store) {
+	report, err := reconcile.NewReconciler(store).Run(context.Background())
+	if err != nil {
+		log.Fatal("cannot reconcile ledger:", err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		log.Fatal("cannot write report:", err)
+	}
+
+	if !report.OK() {
+		os.Exit(1)
+	}
+}
+
+// runAccrue 计提 --date 当天的利息，默认为昨天（UTC）；给出 --from 时从该日起逐日补跑到 --date。
+// 每个计息日输出一行 JSON 结果
+func runAccrue(config util.Config, store db.Store, args []string) {
+	const layout = "2006-01-02"
+
+	flags := flag.NewFlagSet("accrue", flag.ExitOnError)
+	dateFlag := flags.String("date", time.Now().UTC().AddDate(0, 0, -1).Format(layout), "accrual date, YYYY-MM-DD")
+	fromFlag := flags.String("from", "", "first accrual date to backfill, YYYY-MM-DD")
+	flags.Parse(args)
+
+	date, err := time.Parse(layout, *dateFlag)
+	if err != nil {
+		log.Fatal("invalid --date:", err)
+	}
+	from := date
+	if len(*fromFlag) > 0 {
+		from, err = time.Parse(layout, *fromFlag)
+		if err != nil {
+			log.Fatal("invalid --from:", err)
+		}
+		if from.After(date) {
+			log.Fatal("--from is after --date")
+		}
+	}
+
+	rates, err := interest.LoadRateTable(config.InterestRatesFile)
+	if err != nil {
+		log.Fatal("cannot load interest rates:", err)
+	}
+
+	accruer := interest.NewAccruer(store, rates)
+	encoder := json.NewEncoder(os.Stdout)
+	for day := from; !day.After(date); day = day.AddDate(0, 0, 1) {
+		report, err := accruer.Run(context.Background(), day)
+		if err != nil {
+			log.Fatalf("cannot accrue interest for %s: %v", day.Format(layout), err)
+		}
+		if err := encoder.Encode(report); err != nil {
+			log.Fatal("cannot write report:", err)
+		}
 	}
 }
@@ -0,0 +1,76 @@
+package money
+
+import (
+	"strings"
+	"sync"
+)
+
+// Currency ISO 4217 货币信息，Exponent 是最小货币单位的小数位数，
+// 例如 USD 为 2（1 美元 = 100 美分），JPY 为 0，KWD 为 3
+type Currency struct {
+	Code     string `json:"code"`
+	Exponent int    `json:"exponent"`
+}
+
+var (
+	mu sync.RWMutex
+	// currencies 常用的 ISO 4217 货币，运行时可以通过 Register 补充
+	currencies = map[string]Currency{
+		"AED": {Code: "AED", Exponent: 2},
+		"AUD": {Code: "AUD", Exponent: 2},
+		"BHD": {Code: "BHD", Exponent: 3},
+		"BRL": {Code: "BRL", Exponent: 2},
+		"CAD": {Code: "CAD", Exponent: 2},
+		"CHF": {Code: "CHF", Exponent: 2},
+		"CLP": {Code: "CLP", Exponent: 0},
+		"CNY": {Code: "CNY", Exponent: 2},
+		"CZK": {Code: "CZK", Exponent: 2},
+		"DKK": {Code: "DKK", Exponent: 2},
+		"EUR": {Code: "EUR", Exponent: 2},
+		"GBP": {Code: "GBP", Exponent: 2},
+		"HKD": {Code: "HKD", Exponent: 2},
+		"HUF": {Code: "HUF", Exponent: 2},
+		"IDR": {Code: "IDR", Exponent: 2},
+		"ILS": {Code: "ILS", Exponent: 2},
+		"INR": {Code: "INR", Exponent: 2},
+		"ISK": {Code: "ISK", Exponent: 0},
+		"JOD": {Code: "JOD", Exponent: 3},
+		"JPY": {Code: "JPY", Exponent: 0},
+		"KRW": {Code: "KRW", Exponent: 0},
+		"KWD": {Code: "KWD", Exponent: 3},
+		"MXN": {Code: "MXN", Exponent: 2},
+		"MYR": {Code: "MYR", Exponent: 2},
+		"NOK": {Code: "NOK", Exponent: 2},
+		"NZD": {Code: "NZD", Exponent: 2},
+		"OMR": {Code: "OMR", Exponent: 3},
+		"PHP": {Code: "PHP", Exponent: 2},
+		"PLN": {Code: "PLN", Exponent: 2},
+		"SAR": {Code: "SAR", Exponent: 2},
+		"SEK": {Code: "SEK", Exponent: 2},
+		"SGD": {Code: "SGD", Exponent: 2},
+		"THB": {Code: "THB", Exponent: 2},
+		"TND": {Code: "TND", Exponent: 3},
+		"TRY": {Code: "TRY", Exponent: 2},
+		"TWD": {Code: "TWD", Exponent: 2},
+		"USD": {Code: "USD", Exponent: 2},
+		"VND": {Code: "VND", Exponent: 0},
+		"ZAR": {Code: "ZAR", Exponent: 2},
+	}
+)
+
+// LookupCurrency 按货币代码查找货币信息，代码不区分大小写
+func LookupCurrency(code string) (Currency, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	currency, ok := currencies[strings.ToUpper(code)]
+	return currency, ok
+}
+
+// Register 登记或更新一种货币的最小货币单位
+func Register(currency Currency) {
+	currency.Code = strings.ToUpper(currency.Code)
+
+	mu.Lock()
+	defer mu.Unlock()
+	currencies[currency.Code] = currency
+}
@@ -0,0 +1,196 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrUnknownCurrency 不是支持的 ISO 4217 货币
+	ErrUnknownCurrency = errors.New("unknown currency")
+	// ErrCurrencyMismatch 不同货币的金额不能直接运算
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+	// ErrOverflow 运算结果超出 int64 范围
+	ErrOverflow = errors.New("amount overflow")
+	// ErrInvalidAmount 金额格式错误，或小数位数超过货币的最小单位
+	ErrInvalidAmount = errors.New("invalid amount")
+)
+
+// Money 一笔金额，Amount 以货币的最小单位计，例如 USD 的美分
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+// New 用最小货币单位的整数金额创建 Money
+func New(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// Parse 解析十进制字符串金额，例如 Parse("12.34", "USD") 为 1234 美分，
+// 小数位数不能超过货币的最小单位
+func Parse(value string, currency string) (Money, error) {
+	info, ok := LookupCurrency(currency)
+	if !ok {
+		return Money{}, fmt.Errorf("%s: %w", currency, ErrUnknownCurrency)
+	}
+
+	digits := strings.TrimPrefix(value, "-")
+	integer, fraction := digits, ""
+	if i := strings.IndexByte(digits, '.'); i >= 0 {
+		integer, fraction = digits[:i], digits[i+1:]
+		if len(fraction) == 0 {
+			return Money{}, fmt.Errorf("%q: %w", value, ErrInvalidAmount)
+		}
+	}
+	if len(integer) == 0 || !isDigits(integer) || !isDigits(fraction) {
+		return Money{}, fmt.Errorf("%q: %w", value, ErrInvalidAmount)
+	}
+	if len(fraction) > info.Exponent {
+		return Money{}, fmt.Errorf("%q has more than %d decimal places for %s: %w", value, info.Exponent, info.Code, ErrInvalidAmount)
+	}
+
+	minor := value[:len(value)-len(digits)] + integer + fraction + strings.Repeat("0", info.Exponent-len(fraction))
+	amount, err := strconv.ParseInt(minor, 10, 64)
+	if err != nil {
+		if errors.Is(err, strconv.ErrRange) {
+			return Money{}, fmt.Errorf("%q: %w", value, ErrOverflow)
+		}
+		return Money{}, fmt.Errorf("%q: %w", value, ErrInvalidAmount)
+	}
+	return New(amount, info.Code), nil
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Decimal 按货币的最小单位格式化为十进制字符串，例如 1234 美分为 "12.34"
+func (m Money) Decimal() string {
+	exponent := 0
+	if info, ok := LookupCurrency(m.Currency); ok {
+		exponent = info.Exponent
+	}
+
+	// 用 uint64 取绝对值，math.MinInt64 也不会溢出
+	abs := uint64(m.Amount)
+	sign := ""
+	if m.Amount < 0 {
+		abs = -abs
+		sign = "-"
+	}
+
+	digits := strconv.FormatUint(abs, 10)
+	if exponent == 0 {
+		return sign + digits
+	}
+	if len(digits) <= exponent {
+		digits = strings.Repeat("0", exponent-len(digits)+1) + digits
+	}
+	point := len(digits) - exponent
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+func (m Money) String() string {
+	return m.Decimal() + " " + m.Currency
+}
+
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+func (m Money) IsPositive() bool {
+	return m.Amount > 0
+}
+
+func (m Money) IsNegative() bool {
+	return m.Amount < 0
+}
+
+// Add 相加，货币不同时返回 ErrCurrencyMismatch，溢出时返回 ErrOverflow
+func (m Money) Add(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, fmt.Errorf("%s vs %s: %w", m.Currency, other.Currency, ErrCurrencyMismatch)
+	}
+	if (other.Amount > 0 && m.Amount > math.MaxInt64-other.Amount) ||
+		(other.Amount < 0 && m.Amount < math.MinInt64-other.Amount) {
+		return Money{}, fmt.Errorf("%s + %s: %w", m, other, ErrOverflow)
+	}
+	return New(m.Amount+other.Amount, m.Currency), nil
+}
+
+// Sub 相减，货币不同时返回 ErrCurrencyMismatch，溢出时返回 ErrOverflow
+func (m Money) Sub(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, fmt.Errorf("%s vs %s: %w", m.Currency, other.Currency, ErrCurrencyMismatch)
+	}
+	if (other.Amount < 0 && m.Amount > math.MaxInt64+other.Amount) ||
+		(other.Amount > 0 && m.Amount < math.MinInt64+other.Amount) {
+		return Money{}, fmt.Errorf("%s - %s: %w", m, other, ErrOverflow)
+	}
+	return New(m.Amount-other.Amount, m.Currency), nil
+}
+
+// Mul 乘以整数倍，溢出时返回 ErrOverflow
+func (m Money) Mul(n int64) (Money, error) {
+	if m.Amount == 0 || n == 0 {
+		return New(0, m.Currency), nil
+	}
+	product := m.Amount * n
+	// math.MinInt64 / -1 在 Go 中仍等于 math.MinInt64，需要单独判断
+	if product/n != m.Amount || (n == -1 && m.Amount == math.MinInt64) {
+		return Money{}, fmt.Errorf("%s * %d: %w", m, n, ErrOverflow)
+	}
+	return New(product, m.Currency), nil
+}
+
+// Neg 取相反数，math.MinInt64 没有对应的正数，返回 ErrOverflow
+func (m Money) Neg() (Money, error) {
+	if m.Amount == math.MinInt64 {
+		return Money{}, fmt.Errorf("-(%s): %w", m, ErrOverflow)
+	}
+	return New(-m.Amount, m.Currency), nil
+}
+
+// moneyJSON 金额以十进制字符串编码，避免 JSON 数字的精度问题
+type moneyJSON struct {
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// MarshalJSON 编码为 {"amount": "12.34", "currency": "USD"}
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(moneyJSON{
+		Amount:   m.Decimal(),
+		Currency: m.Currency,
+	})
+}
+
+// UnmarshalJSON 解析 {"amount": "12.34", "currency": "USD"}，
+// 货币未知或金额格式错误时返回错误
+func (m *Money) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var raw moneyJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	parsed, err := Parse(raw.Amount, raw.Currency)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
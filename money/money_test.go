@@ -0,0 +1,129 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    string
+		currency string
+		expected Money
+		err      error
+	}{
+		{name: "Cents", value: "12.34", currency: "USD", expected: New(1234, "USD")},
+		{name: "ShortFraction", value: "12.3", currency: "USD", expected: New(1230, "USD")},
+		{name: "Integer", value: "12", currency: "USD", expected: New(1200, "USD")},
+		{name: "Negative", value: "-0.05", currency: "EUR", expected: New(-5, "EUR")},
+		{name: "NoMinorUnit", value: "500", currency: "JPY", expected: New(500, "JPY")},
+		{name: "ThreeDecimals", value: "1.005", currency: "KWD", expected: New(1005, "KWD")},
+		{name: "LowerCaseCurrency", value: "1", currency: "usd", expected: New(100, "USD")},
+		{name: "TooManyDecimals", value: "1.005", currency: "USD", err: ErrInvalidAmount},
+		{name: "DecimalsForJPY", value: "1.5", currency: "JPY", err: ErrInvalidAmount},
+		{name: "Empty", value: "", currency: "USD", err: ErrInvalidAmount},
+		{name: "TrailingPoint", value: "1.", currency: "USD", err: ErrInvalidAmount},
+		{name: "LeadingPoint", value: ".5", currency: "USD", err: ErrInvalidAmount},
+		{name: "Exponent", value: "1e3", currency: "USD", err: ErrInvalidAmount},
+		{name: "Plus", value: "+1", currency: "USD", err: ErrInvalidAmount},
+		{name: "Overflow", value: "92233720368547758.08", currency: "USD", err: ErrOverflow},
+		{name: "UnknownCurrency", value: "1", currency: "XYZ", err: ErrUnknownCurrency},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			m, err := Parse(tc.value, tc.currency)
+			if tc.err != nil {
+				require.True(t, errors.Is(err, tc.err), err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, m)
+		})
+	}
+}
+
+func TestDecimal(t *testing.T) {
+	require.Equal(t, "12.34", New(1234, "USD").Decimal())
+	require.Equal(t, "0.05", New(5, "USD").Decimal())
+	require.Equal(t, "-0.05", New(-5, "USD").Decimal())
+	require.Equal(t, "0.00", New(0, "USD").Decimal())
+	require.Equal(t, "500", New(500, "JPY").Decimal())
+	require.Equal(t, "1.005", New(1005, "KWD").Decimal())
+	require.Equal(t, "-92233720368547758.08", New(math.MinInt64, "USD").Decimal())
+	require.Equal(t, "12.34 USD", New(1234, "USD").String())
+}
+
+func TestArithmetic(t *testing.T) {
+	sum, err := New(150, "USD").Add(New(-50, "USD"))
+	require.NoError(t, err)
+	require.Equal(t, New(100, "USD"), sum)
+
+	diff, err := New(100, "USD").Sub(New(150, "USD"))
+	require.NoError(t, err)
+	require.Equal(t, New(-50, "USD"), diff)
+	require.True(t, diff.IsNegative())
+
+	product, err := New(-25, "USD").Mul(4)
+	require.NoError(t, err)
+	require.Equal(t, New(-100, "USD"), product)
+
+	neg, err := New(100, "USD").Neg()
+	require.NoError(t, err)
+	require.Equal(t, New(-100, "USD"), neg)
+
+	_, err = New(1, "USD").Add(New(1, "EUR"))
+	require.True(t, errors.Is(err, ErrCurrencyMismatch))
+	_, err = New(1, "USD").Sub(New(1, "EUR"))
+	require.True(t, errors.Is(err, ErrCurrencyMismatch))
+}
+
+func TestOverflow(t *testing.T) {
+	max := New(math.MaxInt64, "USD")
+	min := New(math.MinInt64, "USD")
+
+	_, err := max.Add(New(1, "USD"))
+	require.True(t, errors.Is(err, ErrOverflow))
+	_, err = min.Add(New(-1, "USD"))
+	require.True(t, errors.Is(err, ErrOverflow))
+	_, err = min.Sub(New(1, "USD"))
+	require.True(t, errors.Is(err, ErrOverflow))
+	_, err = max.Sub(New(-1, "USD"))
+	require.True(t, errors.Is(err, ErrOverflow))
+	_, err = max.Mul(2)
+	require.True(t, errors.Is(err, ErrOverflow))
+	_, err = min.Mul(-1)
+	require.True(t, errors.Is(err, ErrOverflow))
+	_, err = min.Neg()
+	require.True(t, errors.Is(err, ErrOverflow))
+
+	sum, err := max.Add(min)
+	require.NoError(t, err)
+	require.Equal(t, New(-1, "USD"), sum)
+}
+
+func TestJSON(t *testing.T) {
+	data, err := json.Marshal(New(1005, "KWD"))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"amount": "1.005", "currency": "KWD"}`, string(data))
+
+	var m Money
+	err = json.Unmarshal([]byte(`{"amount": "12.30", "currency": "USD"}`), &m)
+	require.NoError(t, err)
+	require.Equal(t, New(1230, "USD"), m)
+
+	err = json.Unmarshal([]byte(`{"amount": 12.30, "currency": "USD"}`), &m)
+	require.Error(t, err)
+
+	err = json.Unmarshal([]byte(`{"amount": "1.234", "currency": "USD"}`), &m)
+	require.True(t, errors.Is(err, ErrInvalidAmount))
+
+	err = json.Unmarshal([]byte(`{"amount": "1", "currency": "XYZ"}`), &m)
+	require.True(t, errors.Is(err, ErrUnknownCurrency))
+}
@@ -0,0 +1,161 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	db "github.com/xiusl/bank/db/sqlc"
+)
+
+// 发布方式
+const (
+	PublisherStdout  = "stdout"
+	PublisherFile    = "file"
+	PublisherWebhook = "webhook"
+)
+
+// ErrUnknownPublisher 不支持的发布方式
+var ErrUnknownPublisher = errors.New("unknown event publisher")
+
+// webhookTimeout 单次 webhook 请求的超时时间
+const webhookTimeout = 10 * time.Second
+
+// Message 对外发布的事件，ID 在重复投递时保持不变，接收方可据此去重
+type Message struct {
+	ID        int64           `json:"id"`
+	AccountID int64           `json:"account_id"`
+	Type      string          `json:"type"`
+	Payload   json.RawMessage `json:"payload"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// NewMessage 把事件转换为对外发布的格式
+func NewMessage(event db.Event) Message {
+	return Message{
+		ID:        event.ID,
+		AccountID: event.AccountID,
+		Type:      event.Type,
+		Payload:   event.Payload,
+		CreatedAt: event.CreatedAt,
+	}
+}
+
+// Publisher 把事件投递给下游，返回 nil 表示下游已经收到
+type Publisher interface {
+	Publish(ctx context.Context, message Message) error
+}
+
+// NewPublisher 按配置创建发布方式：stdout 不需要 target，file 的 target 是文件路径，
+// webhook 的 target 是接收事件的 URL
+func NewPublisher(kind string, target string) (Publisher, error) {
+	switch kind {
+	case PublisherStdout:
+		return NewWriterPublisher(os.Stdout), nil
+	case PublisherFile:
+		return NewFilePublisher(target)
+	case PublisherWebhook:
+		return NewWebhookPublisher(target), nil
+	}
+	return nil, fmt.Errorf("%q: %w", kind, ErrUnknownPublisher)
+}
+
+// WriterPublisher 每个事件写一行 JSON
+type WriterPublisher struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func NewWriterPublisher(w io.Writer) *WriterPublisher {
+	return &WriterPublisher{
+		w: w,
+	}
+}
+
+func (publisher *WriterPublisher) Publish(ctx context.Context, message Message) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	publisher.mu.Lock()
+	defer publisher.mu.Unlock()
+	_, err = publisher.w.Write(append(data, '\n'))
+	return err
+}
+
+// FilePublisher 把事件追加到文件，每次写入后落盘
+type FilePublisher struct {
+	*WriterPublisher
+	file *os.File
+}
+
+func NewFilePublisher(path string) (*FilePublisher, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &FilePublisher{
+		WriterPublisher: NewWriterPublisher(file),
+		file:            file,
+	}, nil
+}
+
+func (publisher *FilePublisher) Publish(ctx context.Context, message Message) error {
+	if err := publisher.WriterPublisher.Publish(ctx, message); err != nil {
+		return err
+	}
+	return publisher.file.Sync()
+}
+
+func (publisher *FilePublisher) Close() error {
+	return publisher.file.Close()
+}
+
+// WebhookPublisher 以 POST 请求把事件发送到 url，非 2xx 响应视为投递失败
+type WebhookPublisher struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookPublisher(url string) *WebhookPublisher {
+	return &WebhookPublisher{
+		url:    url,
+		client: &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+func (publisher *WebhookPublisher) Publish(ctx context.Context, message Message) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, publisher.url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("X-Event-Id", strconv.FormatInt(message.ID, 10))
+	request.Header.Set("X-Event-Type", message.Type)
+
+	response, err := publisher.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	io.Copy(io.Discard, response.Body)
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("webhook %s responded %s", publisher.url, response.Status)
+	}
+	return nil
+}
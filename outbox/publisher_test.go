@@ -0,0 +1,116 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	db "github.com/xiusl/bank/db/sqlc"
+)
+
+func testMessage() Message {
+	return Message{
+		ID:        42,
+		AccountID: 3,
+		Type:      db.EventBalanceChanged,
+		Payload:   json.RawMessage(`{"amount":-30}`),
+		CreatedAt: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestWriterPublisher(t *testing.T) {
+	var buf bytes.Buffer
+	publisher := NewWriterPublisher(&buf)
+
+	err := publisher.Publish(context.Background(), testMessage())
+	require.NoError(t, err)
+	err = publisher.Publish(context.Background(), testMessage())
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+
+	var got Message
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &got))
+	require.Equal(t, testMessage(), got)
+}
+
+func TestFilePublisher(t *testing.T) {
+	dir, err := ioutil.TempDir("", "outbox")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "events.jsonl")
+
+	// 重新打开时追加而不是覆盖
+	for i := 0; i < 2; i++ {
+		publisher, err := NewFilePublisher(path)
+		require.NoError(t, err)
+		require.NoError(t, publisher.Publish(context.Background(), testMessage()))
+		require.NoError(t, publisher.Close())
+	}
+
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.Len(t, strings.Split(strings.TrimSpace(string(data)), "\n"), 2)
+}
+
+func TestWebhookPublisher(t *testing.T) {
+	testCases := []struct {
+		name   string
+		status int
+		ok     bool
+	}{
+		{name: "OK", status: http.StatusOK, ok: true},
+		{name: "Accepted", status: http.StatusAccepted, ok: true},
+		{name: "ServerError", status: http.StatusInternalServerError, ok: false},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			var got Message
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, http.MethodPost, r.Method)
+				require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				require.Equal(t, "42", r.Header.Get("X-Event-Id"))
+				require.Equal(t, db.EventBalanceChanged, r.Header.Get("X-Event-Type"))
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+				w.WriteHeader(tc.status)
+			}))
+			defer server.Close()
+
+			err := NewWebhookPublisher(server.URL).Publish(context.Background(), testMessage())
+			if tc.ok {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+			require.Equal(t, testMessage(), got)
+		})
+	}
+}
+
+func TestNewPublisher(t *testing.T) {
+	publisher, err := NewPublisher(PublisherStdout, "")
+	require.NoError(t, err)
+	require.IsType(t, &WriterPublisher{}, publisher)
+
+	publisher, err = NewPublisher(PublisherWebhook, "http://localhost/events")
+	require.NoError(t, err)
+	require.IsType(t, &WebhookPublisher{}, publisher)
+
+	publisher, err = NewPublisher("kafka", "")
+	require.True(t, errors.Is(err, ErrUnknownPublisher))
+	require.Nil(t, publisher)
+}
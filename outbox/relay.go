@@ -0,0 +1,75 @@
+package outbox
+
+import (
+	"context"
+	"log"
+	"time"
+
+	db "github.com/xiusl/bank/db/sqlc"
+)
+
+const (
+	// batchSize 每轮最多投递的事件数量
+	batchSize = 100
+	// leaseDuration 领取后在这段时间内不会被其他实例重复领取
+	leaseDuration = 5 * time.Minute
+)
+
+// Relay 在后台把 events 表中待投递的事件交给 publisher。
+// 事件与账户变更在同一事务中写入，至少投递一次，同一账户的事件按写入顺序投递
+type Relay struct {
+	store     db.Store
+	publisher Publisher
+	interval  time.Duration
+}
+
+func NewRelay(store db.Store, publisher Publisher, interval time.Duration) *Relay {
+	return &Relay{
+		store:     store,
+		publisher: publisher,
+		interval:  interval,
+	}
+}
+
+// Start 立即投递一轮，之后每隔 interval 投递一轮，直到 ctx 结束。
+// 一轮投递满一批时不等待，继续投递下一批
+func (relay *Relay) Start(ctx context.Context) {
+	ticker := time.NewTicker(relay.interval)
+	defer ticker.Stop()
+
+	for {
+		result, err := relay.RunOnce(ctx)
+		if err != nil {
+			log.Println("relay events failed:", err)
+		}
+		if err == nil && result.Failed == 0 && result.Published == batchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce 投递一批事件，单个事件投递失败不返回错误，留待下一轮重试
+func (relay *Relay) RunOnce(ctx context.Context) (db.RelayEventsTxResult, error) {
+	now := time.Now()
+	result, err := relay.store.RelayEventsTx(ctx, db.RelayEventsTxParams{
+		BatchSize:  batchSize,
+		Now:        now,
+		LeaseUntil: now.Add(leaseDuration),
+		Publish: func(event db.Event) error {
+			return relay.publisher.Publish(ctx, NewMessage(event))
+		},
+	})
+	if err != nil {
+		return result, err
+	}
+	if result.Failed > 0 {
+		log.Printf("relay events: %d published, %d failed", result.Published, result.Failed)
+	}
+	return result, nil
+}
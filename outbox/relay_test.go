@@ -0,0 +1,112 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/xiusl/bank/db/mock"
+	db "github.com/xiusl/bank/db/sqlc"
+)
+
+// recordingPublisher 记录收到的消息，err 不为空时投递失败
+type recordingPublisher struct {
+	messages []Message
+	err      error
+}
+
+func (publisher *recordingPublisher) Publish(ctx context.Context, message Message) error {
+	if publisher.err != nil {
+		return publisher.err
+	}
+	publisher.messages = append(publisher.messages, message)
+	return nil
+}
+
+func TestRunOnce(t *testing.T) {
+	event := db.Event{
+		ID:        7,
+		AccountID: 3,
+		Type:      db.EventTransferCreated,
+		Payload:   json.RawMessage(`{"id":1}`),
+		CreatedAt: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	testCases := []struct {
+		name      string
+		publisher *recordingPublisher
+		check     func(t *testing.T, publisher *recordingPublisher, publishErr error)
+	}{
+		{
+			name:      "OK",
+			publisher: &recordingPublisher{},
+			check: func(t *testing.T, publisher *recordingPublisher, publishErr error) {
+				require.NoError(t, publishErr)
+				require.Equal(t, []Message{{
+					ID:        event.ID,
+					AccountID: event.AccountID,
+					Type:      event.Type,
+					Payload:   event.Payload,
+					CreatedAt: event.CreatedAt,
+				}}, publisher.messages)
+			},
+		},
+		{
+			name:      "PublishFailed",
+			publisher: &recordingPublisher{err: errors.New("unavailable")},
+			check: func(t *testing.T, publisher *recordingPublisher, publishErr error) {
+				require.EqualError(t, publishErr, "unavailable")
+				require.Empty(t, publisher.messages)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			var publishErr error
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				RelayEventsTx(gomock.Any(), gomock.Any()).
+				Times(1).
+				DoAndReturn(func(ctx context.Context, arg db.RelayEventsTxParams) (db.RelayEventsTxResult, error) {
+					require.Equal(t, int32(batchSize), arg.BatchSize)
+					require.Equal(t, leaseDuration, arg.LeaseUntil.Sub(arg.Now))
+					publishErr = arg.Publish(event)
+					if publishErr != nil {
+						return db.RelayEventsTxResult{Failed: 1}, nil
+					}
+					return db.RelayEventsTxResult{Published: 1}, nil
+				})
+
+			relay := NewRelay(store, tc.publisher, time.Minute)
+			_, err := relay.RunOnce(context.Background())
+			require.NoError(t, err)
+			tc.check(t, tc.publisher, publishErr)
+		})
+	}
+}
+
+func TestRunOnceStoreError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		RelayEventsTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(db.RelayEventsTxResult{}, sql.ErrConnDone)
+
+	relay := NewRelay(store, &recordingPublisher{}, time.Minute)
+	_, err := relay.RunOnce(context.Background())
+	require.Equal(t, sql.ErrConnDone, err)
+}
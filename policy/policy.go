@@ -0,0 +1,58 @@
+package policy
+
+import (
+	db "github.com/xiusl/bank/db/sqlc"
+	"github.com/xiusl/bank/token"
+	"github.com/xiusl/bank/util"
+)
+
+// Action 对账户的操作类型，HTTP 和 gRPC 服务按同一套规则授权
+type Action int
+
+const (
+	// ViewAccount 查看账户、流水和转账记录
+	ViewAccount Action = iota
+	// OperateAccount 动用账户资金：转出、存款、取款
+	OperateAccount
+	// FreezeAccount 冻结、解冻账户
+	FreezeAccount
+	// CloseAccount 销户、重新开户
+	CloseAccount
+)
+
+// rule 满足任一条件即可执行：是账户本人，或拥有列出的角色
+type rule struct {
+	owner bool
+	roles []string
+}
+
+var accountPolicy = map[Action]rule{
+	ViewAccount:    {owner: true, roles: []string{util.BankerRole, util.AdminRole}},
+	OperateAccount: {owner: true},
+	FreezeAccount:  {roles: []string{util.AdminRole}},
+	CloseAccount:   {owner: true, roles: []string{util.AdminRole}},
+}
+
+// Allows 当前用户能否对账户执行 action
+func Allows(payload *token.Payload, account db.Account, action Action) bool {
+	rule := accountPolicy[action]
+	if rule.owner && payload.Username == account.Owner {
+		return true
+	}
+	return HasRole(payload, rule.roles...)
+}
+
+// OwnerAllowed 账户本人能否执行 action，不能时只有列出的角色可以执行
+func OwnerAllowed(action Action) bool {
+	return accountPolicy[action].owner
+}
+
+// HasRole 当前用户拥有 roles 中的任一角色
+func HasRole(payload *token.Payload, roles ...string) bool {
+	for _, role := range roles {
+		if payload.Role == role {
+			return true
+		}
+	}
+	return false
+}
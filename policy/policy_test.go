@@ -0,0 +1,59 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	db "github.com/xiusl/bank/db/sqlc"
+	"github.com/xiusl/bank/token"
+	"github.com/xiusl/bank/util"
+)
+
+func TestAllows(t *testing.T) {
+	owner := util.RandomOwner()
+	account := db.Account{Owner: owner}
+
+	testCases := []struct {
+		name    string
+		payload *token.Payload
+		allowed map[Action]bool
+	}{
+		{
+			name:    "Owner",
+			payload: &token.Payload{Username: owner, Role: util.DepositorRole},
+			allowed: map[Action]bool{ViewAccount: true, OperateAccount: true, FreezeAccount: false, CloseAccount: true},
+		},
+		{
+			name:    "OtherDepositor",
+			payload: &token.Payload{Username: util.RandomOwner(), Role: util.DepositorRole},
+			allowed: map[Action]bool{ViewAccount: false, OperateAccount: false, FreezeAccount: false, CloseAccount: false},
+		},
+		{
+			name:    "Banker",
+			payload: &token.Payload{Username: util.RandomOwner(), Role: util.BankerRole},
+			allowed: map[Action]bool{ViewAccount: true, OperateAccount: false, FreezeAccount: false, CloseAccount: false},
+		},
+		{
+			name:    "Admin",
+			payload: &token.Payload{Username: util.RandomOwner(), Role: util.AdminRole},
+			allowed: map[Action]bool{ViewAccount: true, OperateAccount: false, FreezeAccount: true, CloseAccount: true},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			for action, allowed := range tc.allowed {
+				require.Equal(t, allowed, Allows(tc.payload, account, action), "action %d", action)
+			}
+		})
+	}
+}
+
+func TestOwnerAllowed(t *testing.T) {
+	require.True(t, OwnerAllowed(ViewAccount))
+	require.True(t, OwnerAllowed(OperateAccount))
+	require.False(t, OwnerAllowed(FreezeAccount))
+	require.True(t, OwnerAllowed(CloseAccount))
+}
@@ -0,0 +1,68 @@
+package reconcile
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// Monitor 在后台定期核对账本，保留最近一次的报告
+type Monitor struct {
+	reconciler *Reconciler
+	interval   time.Duration
+
+	mu     sync.RWMutex
+	last   Report
+	hasRun bool
+}
+
+func NewMonitor(reconciler *Reconciler, interval time.Duration) *Monitor {
+	return &Monitor{
+		reconciler: reconciler,
+		interval:   interval,
+	}
+}
+
+// Start 立即核对一次，之后每隔 interval 核对一次，直到 ctx 结束
+func (monitor *Monitor) Start(ctx context.Context) {
+	ticker := time.NewTicker(monitor.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := monitor.RunOnce(ctx); err != nil {
+			log.Println("reconcile failed:", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce 核对一次并保存报告
+func (monitor *Monitor) RunOnce(ctx context.Context) (Report, error) {
+	report, err := monitor.reconciler.Run(ctx)
+	if err != nil {
+		return report, err
+	}
+	if !report.OK() {
+		log.Printf("reconcile found drift: %d accounts, %d transfers", len(report.Accounts), len(report.Transfers))
+	}
+
+	monitor.mu.Lock()
+	monitor.last = report
+	monitor.hasRun = true
+	monitor.mu.Unlock()
+
+	return report, nil
+}
+
+// Last 返回最近一次报告，还没有核对过时 ok 为 false
+func (monitor *Monitor) Last() (report Report, ok bool) {
+	monitor.mu.RLock()
+	defer monitor.mu.RUnlock()
+	return monitor.last, monitor.hasRun
+}
@@ -0,0 +1,85 @@
+// Package reconcile 核对复式记账的不变量：
+// 账户余额等于其分录之和，每笔转账的分录借贷相抵
+package reconcile
+
+import (
+	"context"
+	"time"
+
+	db "github.com/xiusl/bank/db/sqlc"
+)
+
+// AccountDrift 余额与分录之和不一致的账户
+type AccountDrift struct {
+	AccountID    int64 `json:"account_id"`
+	Balance      int64 `json:"balance"`
+	EntriesTotal int64 `json:"entries_total"`
+	Drift        int64 `json:"drift"`
+}
+
+// TransferDrift 分录缺失或不能相抵的转账
+type TransferDrift struct {
+	TransferID   int64 `json:"transfer_id"`
+	Amount       int64 `json:"amount"`
+	EntriesTotal int64 `json:"entries_total"`
+	EntriesCount int64 `json:"entries_count"`
+}
+
+// Report 一次核对的结果
+type Report struct {
+	CheckedAt time.Time       `json:"checked_at"`
+	Accounts  []AccountDrift  `json:"accounts"`
+	Transfers []TransferDrift `json:"transfers"`
+}
+
+// OK 账本没有发现偏差
+func (report Report) OK() bool {
+	return len(report.Accounts) == 0 && len(report.Transfers) == 0
+}
+
+// Reconciler 扫描账本并生成核对报告
+type Reconciler struct {
+	querier db.Querier
+}
+
+func NewReconciler(querier db.Querier) *Reconciler {
+	return &Reconciler{querier: querier}
+}
+
+// Run 扫描全部账户和转账
+func (reconciler *Reconciler) Run(ctx context.Context) (Report, error) {
+	report := Report{
+		CheckedAt: time.Now(),
+		Accounts:  []AccountDrift{},
+		Transfers: []TransferDrift{},
+	}
+
+	accounts, err := reconciler.querier.ListAccountBalanceDrift(ctx)
+	if err != nil {
+		return report, err
+	}
+	for _, row := range accounts {
+		report.Accounts = append(report.Accounts, AccountDrift{
+			AccountID:    row.ID,
+			Balance:      row.Balance,
+			EntriesTotal: row.EntriesTotal,
+			Drift:        row.Balance - row.EntriesTotal,
+		})
+	}
+
+	// 分录通过 transfer_id 归到转账上，早期的分录已由迁移回填 transfer_id
+	transfers, err := reconciler.querier.ListTransferDrift(ctx)
+	if err != nil {
+		return report, err
+	}
+	for _, row := range transfers {
+		report.Transfers = append(report.Transfers, TransferDrift{
+			TransferID:   row.ID,
+			Amount:       row.Amount,
+			EntriesTotal: row.EntriesTotal,
+			EntriesCount: row.EntriesCount,
+		})
+	}
+
+	return report, nil
+}
@@ -0,0 +1,82 @@
+package reconcile
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/xiusl/bank/db/mock"
+	db "github.com/xiusl/bank/db/sqlc"
+)
+
+func TestReconcilerRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ListAccountBalanceDrift(gomock.Any()).
+		Times(1).
+		Return([]db.ListAccountBalanceDriftRow{{ID: 1, Balance: 100, EntriesTotal: 80}}, nil)
+	store.EXPECT().
+		ListTransferDrift(gomock.Any()).
+		Times(1).
+		Return([]db.ListTransferDriftRow{{ID: 7, Amount: 10, EntriesTotal: 10, EntriesCount: 1}}, nil)
+
+	report, err := NewReconciler(store).Run(context.Background())
+	require.NoError(t, err)
+	require.False(t, report.OK())
+	require.WithinDuration(t, time.Now(), report.CheckedAt, time.Second)
+
+	require.Len(t, report.Accounts, 1)
+	require.Equal(t, int64(1), report.Accounts[0].AccountID)
+	require.Equal(t, int64(20), report.Accounts[0].Drift)
+
+	require.Len(t, report.Transfers, 1)
+	require.Equal(t, int64(7), report.Transfers[0].TransferID)
+	require.Equal(t, int64(1), report.Transfers[0].EntriesCount)
+}
+
+func TestReconcilerRunClean(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().ListAccountBalanceDrift(gomock.Any()).Times(1).Return([]db.ListAccountBalanceDriftRow{}, nil)
+	store.EXPECT().ListTransferDrift(gomock.Any()).Times(1).Return([]db.ListTransferDriftRow{}, nil)
+
+	report, err := NewReconciler(store).Run(context.Background())
+	require.NoError(t, err)
+	require.True(t, report.OK())
+}
+
+func TestMonitorRunOnce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	monitor := NewMonitor(NewReconciler(store), time.Minute)
+
+	_, ok := monitor.Last()
+	require.False(t, ok)
+
+	// 失败时不覆盖上一次的报告
+	store.EXPECT().ListAccountBalanceDrift(gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
+	_, err := monitor.RunOnce(context.Background())
+	require.Error(t, err)
+
+	_, ok = monitor.Last()
+	require.False(t, ok)
+
+	store.EXPECT().ListAccountBalanceDrift(gomock.Any()).Times(1).Return([]db.ListAccountBalanceDriftRow{}, nil)
+	store.EXPECT().ListTransferDrift(gomock.Any()).Times(1).Return([]db.ListTransferDriftRow{}, nil)
+	report, err := monitor.RunOnce(context.Background())
+	require.NoError(t, err)
+
+	last, ok := monitor.Last()
+	require.True(t, ok)
+	require.Equal(t, report.CheckedAt, last.CheckedAt)
+}
@@ -0,0 +1,46 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	db "github.com/xiusl/bank/db/sqlc"
+)
+
+// HoldExpirer 在后台定期把过期的预授权标记为 expired。
+// 过期预授权在可用余额中已不计入，这里只更新状态，与定时转账分开配置
+type HoldExpirer struct {
+	store    db.Store
+	interval time.Duration
+}
+
+func NewHoldExpirer(store db.Store, interval time.Duration) *HoldExpirer {
+	return &HoldExpirer{
+		store:    store,
+		interval: interval,
+	}
+}
+
+// Start 立即执行一轮，之后每隔 interval 执行一轮，直到 ctx 结束
+func (expirer *HoldExpirer) Start(ctx context.Context) {
+	ticker := time.NewTicker(expirer.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := expirer.RunOnce(ctx); err != nil {
+			log.Println("expire holds failed:", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce 把已过期仍为 held 的预授权标记为 expired
+func (expirer *HoldExpirer) RunOnce(ctx context.Context) error {
+	return expirer.store.ExpireHolds(ctx)
+}
@@ -0,0 +1,56 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/xiusl/bank/db/mock"
+)
+
+func TestHoldExpirerRunOnce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	gomock.InOrder(
+		store.EXPECT().ExpireHolds(gomock.Any()).Times(1).Return(nil),
+		store.EXPECT().ExpireHolds(gomock.Any()).Times(1).Return(errors.New("connection reset")),
+	)
+
+	expirer := NewHoldExpirer(store, time.Minute)
+	require.NoError(t, expirer.RunOnce(context.Background()))
+	require.Error(t, expirer.RunOnce(context.Background()))
+}
+
+func TestHoldExpirerStart(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// 启动后立即执行一轮，ctx 结束后退出
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ExpireHolds(gomock.Any()).
+		Times(1).
+		DoAndReturn(func(context.Context) error {
+			cancel()
+			return nil
+		})
+
+	done := make(chan struct{})
+	go func() {
+		NewHoldExpirer(store, time.Hour).Start(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("hold expirer did not stop")
+	}
+}
@@ -0,0 +1,213 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+
+	db "github.com/xiusl/bank/db/sqlc"
+	"github.com/xiusl/bank/fee"
+)
+
+const (
+	// batchSize 每轮最多领取的定时转账数量
+	batchSize = 100
+	// leaseDuration 领取后在这段时间内不会被其他实例重复领取
+	leaseDuration = 5 * time.Minute
+)
+
+// Scheduler 在后台定期执行到期的定时转账。
+// 余额不足时跳过本期；其他错误按 retryDelay 重试，失败 maxAttempts 次后跳过本期。
+type Scheduler struct {
+	store       db.Store
+	fees        *fee.Schedule
+	interval    time.Duration
+	retryDelay  time.Duration
+	maxAttempts int32
+}
+
+func NewScheduler(store db.Store, fees *fee.Schedule, interval time.Duration, retryDelay time.Duration, maxAttempts int32) *Scheduler {
+	return &Scheduler{
+		store:       store,
+		fees:        fees,
+		interval:    interval,
+		retryDelay:  retryDelay,
+		maxAttempts: maxAttempts,
+	}
+}
+
+// Start 立即执行一轮，之后每隔 interval 执行一轮，直到 ctx 结束
+func (scheduler *Scheduler) Start(ctx context.Context) {
+	ticker := time.NewTicker(scheduler.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := scheduler.RunOnce(ctx, time.Now()); err != nil {
+			log.Println("run scheduled transfers failed:", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce 领取 now 时已到期的定时转账并逐个执行，返回执行的数量。
+// 单个定时转账出错时记录日志并继续执行其余的，它在领取租约到期后会被重新领取
+func (scheduler *Scheduler) RunOnce(ctx context.Context, now time.Time) (int, error) {
+	due, err := scheduler.store.ClaimDueScheduledTransfers(ctx, db.ClaimDueScheduledTransfersParams{
+		LeaseUntil: now.Add(leaseDuration),
+		Now:        now,
+		BatchSize:  batchSize,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	executed := 0
+	for _, scheduled := range due {
+		ok, err := scheduler.execute(ctx, scheduled, now)
+		if err != nil {
+			log.Printf("execute scheduled transfer %d failed: %v", scheduled.ID, err)
+			continue
+		}
+		if ok {
+			executed++
+		}
+	}
+	return executed, nil
+}
+
+// execute 在一个事务里执行一次定时转账并记录结果，只有事务失败时返回错误；
+// 领取后被用户修改或暂停的定时转账本轮跳过，返回 false
+func (scheduler *Scheduler) execute(ctx context.Context, scheduled db.ScheduledTransfer, now time.Time) (bool, error) {
+	arg, err := scheduler.transferParams(ctx, scheduled)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = scheduler.store.ExecuteScheduledTransferTx(ctx, db.ExecuteScheduledTransferTxParams{
+		ScheduledTransfer: scheduled,
+		Transfer:          arg,
+		Record: func(result db.TransferTxResult, err error) db.ScheduledTransferRunRecord {
+			return scheduler.record(scheduled, result, err, now)
+		},
+	})
+	if errors.Is(err, db.ErrScheduledTransferChanged) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// record 根据转账结果决定执行记录和下一次执行：
+// 成功或余额不足时安排下一期，其他错误按 retryDelay 重试，失败 maxAttempts 次后跳过本期
+func (scheduler *Scheduler) record(scheduled db.ScheduledTransfer, result db.TransferTxResult, err error, now time.Time) db.ScheduledTransferRunRecord {
+	var record db.ScheduledTransferRunRecord
+
+	switch {
+	case err == nil:
+		record.Run.Status = db.ScheduledRunSucceeded
+		record.Run.TransferID = sql.NullInt64{Int64: result.Transfer.ID, Valid: true}
+		advance(&record, scheduled, now)
+	case errors.Is(err, db.ErrInsufficientFunds):
+		record.Run.Status = db.ScheduledRunSkipped
+		record.Run.Error = err.Error()
+		advance(&record, scheduled, now)
+	default:
+		record.Run.Status = db.ScheduledRunFailed
+		record.Run.Error = err.Error()
+		if scheduled.Attempts+1 >= scheduler.maxAttempts {
+			advance(&record, scheduled, now)
+			break
+		}
+		record.Reschedule = db.RescheduleScheduledTransferParams{
+			NextRunAt: scheduled.NextRunAt,
+			RunAfter:  now.Add(scheduler.retryDelay),
+			Attempts:  scheduled.Attempts + 1,
+		}
+	}
+	return record
+}
+
+// transferParams 按转出账户的货币计算手续费
+func (scheduler *Scheduler) transferParams(ctx context.Context, scheduled db.ScheduledTransfer) (db.TransferTxParams, error) {
+	fromAccount, err := scheduler.store.GetAccount(ctx, scheduled.FromAccountID)
+	if err != nil {
+		return db.TransferTxParams{}, err
+	}
+
+	breakdown := scheduler.fees.Calculate(fromAccount.Currency, scheduled.Amount)
+	return db.TransferTxParams{
+		FromAccountID: scheduled.FromAccountID,
+		ToAccountID:   scheduled.ToAccountID,
+		Amount:        scheduled.Amount,
+		Fee: db.TransferFee{
+			Flat:       breakdown.Flat,
+			Percentage: breakdown.Percentage,
+			Amount:     breakdown.Amount,
+		},
+	}, nil
+}
+
+// advance 安排下一期，一次性的定时转账执行后即完成
+func advance(record *db.ScheduledTransferRunRecord, scheduled db.ScheduledTransfer, now time.Time) {
+	next, ok := NextRunAt(scheduled.Frequency, scheduled.StartAt, scheduled.NextRunAt, now)
+	if !ok {
+		record.Completed = true
+		return
+	}
+	record.Reschedule = db.RescheduleScheduledTransferParams{
+		NextRunAt: next,
+		RunAfter:  next,
+	}
+}
+
+// NextRunAt 返回 last 之后第一个晚于 now 的执行时间，调度器停机期间错过的期次不再补执行。
+// 每月执行的按 start 的日期计算，当月没有这一天时取当月最后一天，避免执行日逐月漂移。
+// 一次性的定时转账没有下一期，ok 为 false
+func NextRunAt(frequency string, start time.Time, last time.Time, now time.Time) (next time.Time, ok bool) {
+	after := last
+	if now.After(after) {
+		after = now
+	}
+
+	switch frequency {
+	case db.FrequencyDaily:
+		next = last
+		for !next.After(after) {
+			next = next.AddDate(0, 0, 1)
+		}
+	case db.FrequencyWeekly:
+		next = last
+		for !next.After(after) {
+			next = next.AddDate(0, 0, 7)
+		}
+	case db.FrequencyMonthly:
+		months := (last.Year()-start.Year())*12 + int(last.Month()-start.Month())
+		if months < 0 {
+			months = 0
+		}
+		next = addMonths(start, months)
+		for !next.After(after) {
+			months++
+			next = addMonths(start, months)
+		}
+	default:
+		return last, false
+	}
+	return next, true
+}
+
+// addMonths 返回 t 之后第 months 个月的同一天，超出当月天数时取当月最后一天
+func addMonths(t time.Time, months int) time.Time {
+	year, month, day := t.Date()
+	first := time.Date(year, month+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	if lastDay := first.AddDate(0, 1, -1).Day(); day > lastDay {
+		day = lastDay
+	}
+	return first.AddDate(0, 0, day-1)
+}
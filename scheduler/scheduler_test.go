@@ -0,0 +1,377 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/xiusl/bank/db/mock"
+	db "github.com/xiusl/bank/db/sqlc"
+	"github.com/xiusl/bank/fee"
+	"github.com/xiusl/bank/util"
+)
+
+func TestNextRunAt(t *testing.T) {
+	last := time.Date(2021, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name      string
+		frequency string
+		start     time.Time
+		last      time.Time
+		now       time.Time
+		next      time.Time
+		ok        bool
+	}{
+		{
+			name:      "Once",
+			frequency: db.FrequencyOnce,
+			start:     last,
+			last:      last,
+			now:       last,
+			next:      last,
+			ok:        false,
+		},
+		{
+			name:      "Daily",
+			frequency: db.FrequencyDaily,
+			start:     last,
+			last:      last,
+			now:       last,
+			next:      last.AddDate(0, 0, 1),
+			ok:        true,
+		},
+		{
+			name:      "Weekly",
+			frequency: db.FrequencyWeekly,
+			start:     last,
+			last:      last,
+			now:       last,
+			next:      last.AddDate(0, 0, 7),
+			ok:        true,
+		},
+		{
+			name:      "Monthly",
+			frequency: db.FrequencyMonthly,
+			start:     last,
+			last:      last,
+			now:       last,
+			next:      time.Date(2021, 2, 1, 9, 0, 0, 0, time.UTC),
+			ok:        true,
+		},
+		{
+			name:      "SkipMissed",
+			frequency: db.FrequencyDaily,
+			start:     last,
+			last:      last,
+			now:       last.AddDate(0, 0, 3).Add(time.Hour),
+			next:      last.AddDate(0, 0, 4),
+			ok:        true,
+		},
+		{
+			name:      "MonthlyEndOfMonth",
+			frequency: db.FrequencyMonthly,
+			start:     time.Date(2021, 1, 31, 9, 0, 0, 0, time.UTC),
+			last:      time.Date(2021, 1, 31, 9, 0, 0, 0, time.UTC),
+			now:       time.Date(2021, 1, 31, 9, 0, 0, 0, time.UTC),
+			next:      time.Date(2021, 2, 28, 9, 0, 0, 0, time.UTC),
+			ok:        true,
+		},
+		{
+			name:      "MonthlyBackToStartDay",
+			frequency: db.FrequencyMonthly,
+			start:     time.Date(2021, 1, 31, 9, 0, 0, 0, time.UTC),
+			last:      time.Date(2021, 2, 28, 9, 0, 0, 0, time.UTC),
+			now:       time.Date(2021, 2, 28, 9, 0, 0, 0, time.UTC),
+			next:      time.Date(2021, 3, 31, 9, 0, 0, 0, time.UTC),
+			ok:        true,
+		},
+		{
+			name:      "MonthlyLeapYear",
+			frequency: db.FrequencyMonthly,
+			start:     time.Date(2024, 1, 30, 9, 0, 0, 0, time.UTC),
+			last:      time.Date(2024, 1, 30, 9, 0, 0, 0, time.UTC),
+			now:       time.Date(2024, 1, 30, 9, 0, 0, 0, time.UTC),
+			next:      time.Date(2024, 2, 29, 9, 0, 0, 0, time.UTC),
+			ok:        true,
+		},
+		{
+			name:      "MonthlySkipMissed",
+			frequency: db.FrequencyMonthly,
+			start:     time.Date(2021, 1, 31, 9, 0, 0, 0, time.UTC),
+			last:      time.Date(2021, 1, 31, 9, 0, 0, 0, time.UTC),
+			now:       time.Date(2021, 4, 15, 9, 0, 0, 0, time.UTC),
+			next:      time.Date(2021, 4, 30, 9, 0, 0, 0, time.UTC),
+			ok:        true,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			next, ok := NextRunAt(tc.frequency, tc.start, tc.last, tc.now)
+			require.Equal(t, tc.ok, ok)
+			require.Equal(t, tc.next, next)
+		})
+	}
+}
+
+func TestRunOnce(t *testing.T) {
+	now := time.Date(2021, 1, 1, 9, 0, 0, 0, time.UTC)
+	account := db.Account{ID: 1, Currency: util.USD}
+
+	scheduled := db.ScheduledTransfer{
+		ID:            7,
+		FromAccountID: account.ID,
+		ToAccountID:   2,
+		Amount:        100,
+		Frequency:     db.FrequencyMonthly,
+		Status:        db.ScheduledTransferActive,
+		NextRunAt:     now,
+		RunAfter:      now,
+		StartAt:       now,
+	}
+	nextMonth := now.AddDate(0, 1, 0)
+
+	testCases := []struct {
+		name       string
+		scheduled  func() db.ScheduledTransfer
+		transferTx func() (db.TransferTxResult, error)
+		record     db.ScheduledTransferRunRecord
+	}{
+		{
+			name:      "Succeeded",
+			scheduled: func() db.ScheduledTransfer { return scheduled },
+			transferTx: func() (db.TransferTxResult, error) {
+				return db.TransferTxResult{Transfer: db.Transfer{ID: 42}}, nil
+			},
+			record: db.ScheduledTransferRunRecord{
+				Run: db.CreateScheduledTransferRunParams{
+					TransferID: sql.NullInt64{Int64: 42, Valid: true},
+					Status:     db.ScheduledRunSucceeded,
+				},
+				Reschedule: db.RescheduleScheduledTransferParams{
+					NextRunAt: nextMonth,
+					RunAfter:  nextMonth,
+				},
+			},
+		},
+		{
+			name: "OnceCompleted",
+			scheduled: func() db.ScheduledTransfer {
+				once := scheduled
+				once.Frequency = db.FrequencyOnce
+				return once
+			},
+			transferTx: func() (db.TransferTxResult, error) {
+				return db.TransferTxResult{Transfer: db.Transfer{ID: 42}}, nil
+			},
+			record: db.ScheduledTransferRunRecord{
+				Run: db.CreateScheduledTransferRunParams{
+					TransferID: sql.NullInt64{Int64: 42, Valid: true},
+					Status:     db.ScheduledRunSucceeded,
+				},
+				Completed: true,
+			},
+		},
+		{
+			name:      "SkippedOnInsufficientFunds",
+			scheduled: func() db.ScheduledTransfer { return scheduled },
+			transferTx: func() (db.TransferTxResult, error) {
+				return db.TransferTxResult{}, fmt.Errorf("account [1] balance 0: %w", db.ErrInsufficientFunds)
+			},
+			record: db.ScheduledTransferRunRecord{
+				Run: db.CreateScheduledTransferRunParams{
+					Status: db.ScheduledRunSkipped,
+					Error:  "account [1] balance 0: insufficient funds",
+				},
+				Reschedule: db.RescheduleScheduledTransferParams{
+					NextRunAt: nextMonth,
+					RunAfter:  nextMonth,
+				},
+			},
+		},
+		{
+			name:      "Retry",
+			scheduled: func() db.ScheduledTransfer { return scheduled },
+			transferTx: func() (db.TransferTxResult, error) {
+				return db.TransferTxResult{}, sql.ErrConnDone
+			},
+			record: db.ScheduledTransferRunRecord{
+				Run: db.CreateScheduledTransferRunParams{
+					Status: db.ScheduledRunFailed,
+					Error:  sql.ErrConnDone.Error(),
+				},
+				Reschedule: db.RescheduleScheduledTransferParams{
+					NextRunAt: now,
+					RunAfter:  now.Add(time.Minute),
+					Attempts:  1,
+				},
+			},
+		},
+		{
+			name: "GiveUpAfterMaxAttempts",
+			scheduled: func() db.ScheduledTransfer {
+				retried := scheduled
+				retried.Attempts = 2
+				return retried
+			},
+			transferTx: func() (db.TransferTxResult, error) {
+				return db.TransferTxResult{}, sql.ErrConnDone
+			},
+			record: db.ScheduledTransferRunRecord{
+				Run: db.CreateScheduledTransferRunParams{
+					Status: db.ScheduledRunFailed,
+					Error:  sql.ErrConnDone.Error(),
+				},
+				Reschedule: db.RescheduleScheduledTransferParams{
+					NextRunAt: nextMonth,
+					RunAfter:  nextMonth,
+				},
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				ClaimDueScheduledTransfers(gomock.Any(), gomock.Eq(db.ClaimDueScheduledTransfersParams{
+					LeaseUntil: now.Add(leaseDuration),
+					Now:        now,
+					BatchSize:  batchSize,
+				})).
+				Times(1).
+				Return([]db.ScheduledTransfer{tc.scheduled()}, nil)
+			store.EXPECT().
+				GetAccount(gomock.Any(), gomock.Eq(account.ID)).
+				Times(1).
+				Return(account, nil)
+
+			arg := db.TransferTxParams{
+				FromAccountID: scheduled.FromAccountID,
+				ToAccountID:   scheduled.ToAccountID,
+				Amount:        scheduled.Amount,
+				Fee:           db.TransferFee{Flat: 1, Amount: 1},
+			}
+			store.EXPECT().
+				ExecuteScheduledTransferTx(gomock.Any(), gomock.Any()).
+				Times(1).
+				DoAndReturn(func(ctx context.Context, txArg db.ExecuteScheduledTransferTxParams) (db.ExecuteScheduledTransferTxResult, error) {
+					require.Equal(t, tc.scheduled(), txArg.ScheduledTransfer)
+					require.Equal(t, arg, txArg.Transfer)
+					require.Equal(t, tc.record, txArg.Record(tc.transferTx()))
+					return db.ExecuteScheduledTransferTxResult{}, nil
+				})
+
+			fees, err := fee.NewSchedule(map[string]fee.Rule{util.USD: {Flat: 1}})
+			require.NoError(t, err)
+
+			n, err := NewScheduler(store, fees, time.Minute, time.Minute, 3).RunOnce(context.Background(), now)
+			require.NoError(t, err)
+			require.Equal(t, 1, n)
+		})
+	}
+}
+
+func TestRunOnceChangedSinceClaimed(t *testing.T) {
+	now := time.Date(2021, 1, 1, 9, 0, 0, 0, time.UTC)
+	account := db.Account{ID: 1, Currency: util.USD}
+
+	scheduled := db.ScheduledTransfer{
+		ID:            7,
+		FromAccountID: account.ID,
+		ToAccountID:   2,
+		Amount:        100,
+		Frequency:     db.FrequencyMonthly,
+		Status:        db.ScheduledTransferActive,
+		NextRunAt:     now,
+		RunAfter:      now,
+		StartAt:       now,
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ClaimDueScheduledTransfers(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return([]db.ScheduledTransfer{scheduled}, nil)
+	store.EXPECT().
+		GetAccount(gomock.Any(), gomock.Eq(account.ID)).
+		Times(1).
+		Return(account, nil)
+	store.EXPECT().
+		ExecuteScheduledTransferTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(db.ExecuteScheduledTransferTxResult{}, db.ErrScheduledTransferChanged)
+
+	fees, err := fee.NewSchedule(nil)
+	require.NoError(t, err)
+
+	// 领取后被修改的定时转账跳过，不计入执行数量
+	n, err := NewScheduler(store, fees, time.Minute, time.Minute, 3).RunOnce(context.Background(), now)
+	require.NoError(t, err)
+	require.Zero(t, n)
+}
+
+func TestRunOnceContinuesAfterError(t *testing.T) {
+	now := time.Date(2021, 1, 1, 9, 0, 0, 0, time.UTC)
+	account := db.Account{ID: 1, Currency: util.USD}
+
+	failing := db.ScheduledTransfer{
+		ID:            7,
+		FromAccountID: 3,
+		ToAccountID:   2,
+		Amount:        100,
+		Frequency:     db.FrequencyMonthly,
+		Status:        db.ScheduledTransferActive,
+		NextRunAt:     now,
+		RunAfter:      now,
+		StartAt:       now,
+	}
+	succeeding := failing
+	succeeding.ID = 8
+	succeeding.FromAccountID = account.ID
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ClaimDueScheduledTransfers(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return([]db.ScheduledTransfer{failing, succeeding}, nil)
+	store.EXPECT().
+		GetAccount(gomock.Any(), gomock.Eq(failing.FromAccountID)).
+		Times(1).
+		Return(db.Account{}, sql.ErrConnDone)
+	store.EXPECT().
+		GetAccount(gomock.Any(), gomock.Eq(account.ID)).
+		Times(1).
+		Return(account, nil)
+	store.EXPECT().
+		ExecuteScheduledTransferTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(ctx context.Context, arg db.ExecuteScheduledTransferTxParams) (db.ExecuteScheduledTransferTxResult, error) {
+			require.Equal(t, succeeding, arg.ScheduledTransfer)
+			return db.ExecuteScheduledTransferTxResult{}, nil
+		})
+
+	fees, err := fee.NewSchedule(nil)
+	require.NoError(t, err)
+
+	// 一个定时转账出错不影响本轮其他定时转账，只统计实际执行的数量
+	n, err := NewScheduler(store, fees, time.Minute, time.Minute, 3).RunOnce(context.Background(), now)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+}
@@ -0,0 +1,71 @@
+package statement
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	db "github.com/xiusl/bank/db/sqlc"
+	"github.com/xiusl/bank/money"
+)
+
+// csvWriter 每行一条记录，type 列区分 opening、entry 和 closing，金额为账户货币的小数形式
+type csvWriter struct {
+	out      *output
+	csv      *csv.Writer
+	currency string
+	to       time.Time
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	out := newOutput(w)
+	return &csvWriter{
+		out: out,
+		csv: csv.NewWriter(out),
+	}
+}
+
+func (writer *csvWriter) Begin(header Header) error {
+	writer.currency = header.Currency
+	writer.to = header.To
+
+	if err := writer.csv.Write([]string{"type", "entry_id", "time", "description", "transfer_id", "amount", "balance"}); err != nil {
+		return err
+	}
+	return writer.csv.Write([]string{
+		"opening", "", header.From.UTC().Format(time.RFC3339), "", "", "",
+		money.New(header.OpeningBalance, writer.currency).Decimal(),
+	})
+}
+
+func (writer *csvWriter) Line(entry db.ListStatementEntriesRow, balance int64) error {
+	transferID := ""
+	if entry.TransferID.Valid {
+		transferID = strconv.FormatInt(entry.TransferID.Int64, 10)
+	}
+	return writer.csv.Write([]string{
+		"entry",
+		strconv.FormatInt(entry.ID, 10),
+		entry.CreatedAt.UTC().Format(time.RFC3339),
+		entry.Description,
+		transferID,
+		money.New(entry.Amount, writer.currency).Decimal(),
+		money.New(balance, writer.currency).Decimal(),
+	})
+}
+
+func (writer *csvWriter) End(closingBalance int64) error {
+	return writer.csv.Write([]string{
+		"closing", "", writer.to.UTC().Format(time.RFC3339), "", "", "",
+		money.New(closingBalance, writer.currency).Decimal(),
+	})
+}
+
+func (writer *csvWriter) Flush() error {
+	writer.csv.Flush()
+	if err := writer.csv.Error(); err != nil {
+		return err
+	}
+	return writer.out.Flush()
+}
@@ -0,0 +1,104 @@
+package statement
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	db "github.com/xiusl/bank/db/sqlc"
+	"github.com/xiusl/bank/money"
+)
+
+type jsonHeader struct {
+	AccountID      int64       `json:"account_id"`
+	Owner          string      `json:"owner"`
+	Currency       string      `json:"currency"`
+	AccountType    string      `json:"account_type"`
+	From           time.Time   `json:"from"`
+	To             time.Time   `json:"to"`
+	GeneratedAt    time.Time   `json:"generated_at"`
+	OpeningBalance money.Money `json:"opening_balance"`
+}
+
+type jsonLine struct {
+	ID          int64       `json:"id"`
+	CreatedAt   time.Time   `json:"created_at"`
+	Description string      `json:"description"`
+	TransferID  int64       `json:"transfer_id,omitempty"`
+	Amount      money.Money `json:"amount"`
+	Balance     money.Money `json:"balance"`
+}
+
+// jsonWriter 逐条写出一个 JSON 对象：{"header": {...}, "entries": [...], "closing_balance": {...}}
+type jsonWriter struct {
+	out      *output
+	currency string
+	lines    int
+}
+
+func newJSONWriter(w io.Writer) *jsonWriter {
+	return &jsonWriter{
+		out: newOutput(w),
+	}
+}
+
+func (writer *jsonWriter) Begin(header Header) error {
+	writer.currency = header.Currency
+
+	data, err := json.Marshal(jsonHeader{
+		AccountID:      header.AccountID,
+		Owner:          header.Owner,
+		Currency:       header.Currency,
+		AccountType:    header.AccountType,
+		From:           header.From,
+		To:             header.To,
+		GeneratedAt:    header.GeneratedAt,
+		OpeningBalance: money.New(header.OpeningBalance, header.Currency),
+	})
+	if err != nil {
+		return err
+	}
+	return writer.write(`{"header":`, string(data), `,"entries":[`)
+}
+
+func (writer *jsonWriter) Line(entry db.ListStatementEntriesRow, balance int64) error {
+	data, err := json.Marshal(jsonLine{
+		ID:          entry.ID,
+		CreatedAt:   entry.CreatedAt,
+		Description: entry.Description,
+		TransferID:  entry.TransferID.Int64,
+		Amount:      money.New(entry.Amount, writer.currency),
+		Balance:     money.New(balance, writer.currency),
+	})
+	if err != nil {
+		return err
+	}
+
+	separator := ","
+	if writer.lines == 0 {
+		separator = ""
+	}
+	writer.lines++
+	return writer.write(separator, string(data))
+}
+
+func (writer *jsonWriter) End(closingBalance int64) error {
+	data, err := json.Marshal(money.New(closingBalance, writer.currency))
+	if err != nil {
+		return err
+	}
+	return writer.write(`],"closing_balance":`, string(data), "}\n")
+}
+
+func (writer *jsonWriter) Flush() error {
+	return writer.out.Flush()
+}
+
+func (writer *jsonWriter) write(parts ...string) error {
+	for _, part := range parts {
+		if _, err := writer.out.WriteString(part); err != nil {
+			return err
+		}
+	}
+	return nil
+}
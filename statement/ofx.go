@@ -0,0 +1,124 @@
+package statement
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	db "github.com/xiusl/bank/db/sqlc"
+	"github.com/xiusl/bank/money"
+)
+
+// ofxTime OFX 的日期时间格式，统一使用 UTC
+const ofxTime = "20060102150405"
+
+// ofxWriter 按 OFX 2.2（XML）写出银行对账单，期末余额写在 LEDGERBAL 中。
+// OFX 没有期初余额字段，期初余额只体现在各笔交易之前的余额里
+type ofxWriter struct {
+	out    *output
+	header Header
+}
+
+func newOFXWriter(w io.Writer) *ofxWriter {
+	return &ofxWriter{
+		out: newOutput(w),
+	}
+}
+
+func (writer *ofxWriter) Begin(header Header) error {
+	writer.header = header
+
+	accountType := "CHECKING"
+	if header.AccountType == db.AccountTypeSavings {
+		accountType = "SAVINGS"
+	}
+
+	_, err := fmt.Fprintf(writer.out, `<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>
+<OFX>
+<SIGNONMSGSRSV1>
+<SONRS>
+<STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>
+<DTSERVER>%s</DTSERVER>
+<LANGUAGE>ENG</LANGUAGE>
+</SONRS>
+</SIGNONMSGSRSV1>
+<BANKMSGSRSV1>
+<STMTTRNRS>
+<TRNUID>0</TRNUID>
+<STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>
+<STMTRS>
+<CURDEF>%s</CURDEF>
+<BANKACCTFROM>
+<BANKID>bank</BANKID>
+<ACCTID>%d</ACCTID>
+<ACCTTYPE>%s</ACCTTYPE>
+</BANKACCTFROM>
+<BANKTRANLIST>
+<DTSTART>%s</DTSTART>
+<DTEND>%s</DTEND>
+`,
+		formatOFXTime(header.GeneratedAt),
+		header.Currency,
+		header.AccountID,
+		accountType,
+		formatOFXTime(header.From),
+		formatOFXTime(header.To),
+	)
+	return err
+}
+
+func (writer *ofxWriter) Line(entry db.ListStatementEntriesRow, balance int64) error {
+	transactionType := "CREDIT"
+	if entry.Amount < 0 {
+		transactionType = "DEBIT"
+	}
+
+	var name strings.Builder
+	if err := xml.EscapeText(&name, []byte(entry.Description)); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintf(writer.out, `<STMTTRN>
+<TRNTYPE>%s</TRNTYPE>
+<DTPOSTED>%s</DTPOSTED>
+<TRNAMT>%s</TRNAMT>
+<FITID>%d</FITID>
+<NAME>%s</NAME>
+</STMTTRN>
+`,
+		transactionType,
+		formatOFXTime(entry.CreatedAt),
+		money.New(entry.Amount, writer.header.Currency).Decimal(),
+		entry.ID,
+		name.String(),
+	)
+	return err
+}
+
+func (writer *ofxWriter) End(closingBalance int64) error {
+	_, err := fmt.Fprintf(writer.out, `</BANKTRANLIST>
+<LEDGERBAL>
+<BALAMT>%s</BALAMT>
+<DTASOF>%s</DTASOF>
+</LEDGERBAL>
+</STMTRS>
+</STMTTRNRS>
+</BANKMSGSRSV1>
+</OFX>
+`,
+		money.New(closingBalance, writer.header.Currency).Decimal(),
+		formatOFXTime(writer.header.To),
+	)
+	return err
+}
+
+func (writer *ofxWriter) Flush() error {
+	return writer.out.Flush()
+}
+
+func formatOFXTime(t time.Time) string {
+	return t.UTC().Format(ofxTime)
+}
@@ -0,0 +1,151 @@
+// Package statement 生成账户对账单：期初余额、每条分录及其后的余额、期末余额。
+// 分录分页读取并逐页写出，不会一次把整个期间的分录载入内存
+package statement
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	db "github.com/xiusl/bank/db/sqlc"
+)
+
+// pageSize 每次读取的分录数量，每页写完后刷新一次输出
+const pageSize = 500
+
+// 对账单格式
+const (
+	FormatCSV  = "csv"
+	FormatJSON = "json"
+	FormatOFX  = "ofx"
+)
+
+// ErrUnknownFormat 不支持的对账单格式
+var ErrUnknownFormat = errors.New("unknown statement format")
+
+// Header 对账单的账户和期间，期间为 [From, To)
+type Header struct {
+	AccountID   int64
+	Owner       string
+	Currency    string
+	AccountType string
+	From        time.Time
+	To          time.Time
+	GeneratedAt time.Time
+	// From 时刻的余额，由 Generate 填写
+	OpeningBalance int64
+}
+
+// Writer 按某种格式写出对账单，依次调用 Begin、Line（每条分录一次）和 End
+type Writer interface {
+	Begin(header Header) error
+	// Line 写出一条分录，balance 为记这条分录后的余额
+	Line(entry db.ListStatementEntriesRow, balance int64) error
+	End(closingBalance int64) error
+	// Flush 把已写出的内容发送给客户端
+	Flush() error
+}
+
+// NewWriter 返回 format 格式的 Writer
+func NewWriter(format string, w io.Writer) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w), nil
+	case FormatJSON:
+		return newJSONWriter(w), nil
+	case FormatOFX:
+		return newOFXWriter(w), nil
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+// ContentType 返回格式对应的 HTTP Content-Type
+func ContentType(format string) string {
+	switch format {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatOFX:
+		return "application/x-ofx"
+	default:
+		return "application/json; charset=utf-8"
+	}
+}
+
+// Generate 计算期初余额后逐页读取期间内的分录并写出，返回期末余额。
+// 调用 Begin 之前出错时 w 没有写出任何内容
+func Generate(ctx context.Context, querier db.Querier, header Header, w Writer) (int64, error) {
+	opening, err := querier.GetAccountBalanceAt(ctx, db.GetAccountBalanceAtParams{
+		At:        header.From,
+		AccountID: header.AccountID,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	header.OpeningBalance = opening
+	if err := w.Begin(header); err != nil {
+		return 0, err
+	}
+
+	balance := opening
+	var afterID int64
+	for {
+		entries, err := querier.ListStatementEntries(ctx, db.ListStatementEntriesParams{
+			AccountID: header.AccountID,
+			AfterID:   afterID,
+			FromTime:  header.From,
+			ToTime:    header.To,
+			PageSize:  pageSize,
+		})
+		if err != nil {
+			return balance, err
+		}
+
+		for _, entry := range entries {
+			balance += entry.Amount
+			if err := w.Line(entry, balance); err != nil {
+				return balance, err
+			}
+		}
+		if err := w.Flush(); err != nil {
+			return balance, err
+		}
+
+		if len(entries) < pageSize {
+			break
+		}
+		afterID = entries[len(entries)-1].ID
+	}
+
+	if err := w.End(balance); err != nil {
+		return balance, err
+	}
+	return balance, w.Flush()
+}
+
+// output 带缓冲的输出，Flush 时如果下层是 HTTP 响应一并推送给客户端
+type output struct {
+	*bufio.Writer
+	w io.Writer
+}
+
+func newOutput(w io.Writer) *output {
+	return &output{
+		Writer: bufio.NewWriter(w),
+		w:      w,
+	}
+}
+
+func (out *output) Flush() error {
+	if err := out.Writer.Flush(); err != nil {
+		return err
+	}
+	if flusher, ok := out.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return nil
+}
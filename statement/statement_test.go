@@ -0,0 +1,236 @@
+package statement
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/xiusl/bank/db/mock"
+	db "github.com/xiusl/bank/db/sqlc"
+	"github.com/xiusl/bank/util"
+)
+
+var (
+	testFrom = time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	testTo   = time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func testHeader() Header {
+	return Header{
+		AccountID:   7,
+		Owner:       "alice",
+		Currency:    util.USD,
+		AccountType: db.AccountTypeSavings,
+		From:        testFrom,
+		To:          testTo,
+		GeneratedAt: testTo,
+	}
+}
+
+func testEntries() []db.ListStatementEntriesRow {
+	return []db.ListStatementEntriesRow{
+		{
+			ID:          11,
+			AccountID:   7,
+			Amount:      500,
+			CreatedAt:   time.Date(2021, 3, 2, 9, 0, 0, 0, time.UTC),
+			Description: "deposit",
+		},
+		{
+			ID:          12,
+			AccountID:   7,
+			Amount:      -200,
+			CreatedAt:   time.Date(2021, 3, 3, 9, 0, 0, 0, time.UTC),
+			TransferID:  sql.NullInt64{Int64: 3, Valid: true},
+			Description: "transfer",
+		},
+	}
+}
+
+// generate 期初余额 10.00，两条分录后期末余额为 13.00
+func generate(t *testing.T, format string) string {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetAccountBalanceAt(gomock.Any(), gomock.Eq(db.GetAccountBalanceAtParams{At: testFrom, AccountID: 7})).
+		Times(1).
+		Return(int64(1000), nil)
+	store.EXPECT().
+		ListStatementEntries(gomock.Any(), gomock.Eq(db.ListStatementEntriesParams{
+			AccountID: 7,
+			FromTime:  testFrom,
+			ToTime:    testTo,
+			PageSize:  pageSize,
+		})).
+		Times(1).
+		Return(testEntries(), nil)
+
+	var buf bytes.Buffer
+	writer, err := NewWriter(format, &buf)
+	require.NoError(t, err)
+
+	closing, err := Generate(context.Background(), store, testHeader(), writer)
+	require.NoError(t, err)
+	require.Equal(t, int64(1300), closing)
+	return buf.String()
+}
+
+func TestGenerateCSV(t *testing.T) {
+	expected := "type,entry_id,time,description,transfer_id,amount,balance\n" +
+		"opening,,2021-03-01T00:00:00Z,,,,10.00\n" +
+		"entry,11,2021-03-02T09:00:00Z,deposit,,5.00,15.00\n" +
+		"entry,12,2021-03-03T09:00:00Z,transfer,3,-2.00,13.00\n" +
+		"closing,,2021-04-01T00:00:00Z,,,,13.00\n"
+	require.Equal(t, expected, generate(t, FormatCSV))
+}
+
+func TestGenerateJSON(t *testing.T) {
+	var got struct {
+		Header struct {
+			AccountID      int64             `json:"account_id"`
+			AccountType    string            `json:"account_type"`
+			OpeningBalance map[string]string `json:"opening_balance"`
+		} `json:"header"`
+		Entries []struct {
+			ID         int64             `json:"id"`
+			TransferID int64             `json:"transfer_id"`
+			Amount     map[string]string `json:"amount"`
+			Balance    map[string]string `json:"balance"`
+		} `json:"entries"`
+		ClosingBalance map[string]string `json:"closing_balance"`
+	}
+	err := json.Unmarshal([]byte(generate(t, FormatJSON)), &got)
+	require.NoError(t, err)
+
+	require.Equal(t, int64(7), got.Header.AccountID)
+	require.Equal(t, db.AccountTypeSavings, got.Header.AccountType)
+	require.Equal(t, map[string]string{"amount": "10.00", "currency": util.USD}, got.Header.OpeningBalance)
+
+	require.Len(t, got.Entries, 2)
+	require.Equal(t, int64(11), got.Entries[0].ID)
+	require.Zero(t, got.Entries[0].TransferID)
+	require.Equal(t, "15.00", got.Entries[0].Balance["amount"])
+	require.Equal(t, int64(3), got.Entries[1].TransferID)
+	require.Equal(t, "-2.00", got.Entries[1].Amount["amount"])
+	require.Equal(t, "13.00", got.Entries[1].Balance["amount"])
+
+	require.Equal(t, map[string]string{"amount": "13.00", "currency": util.USD}, got.ClosingBalance)
+}
+
+func TestGenerateOFX(t *testing.T) {
+	var got struct {
+		Statement struct {
+			Currency string `xml:"CURDEF"`
+			Account  struct {
+				ID   int64  `xml:"ACCTID"`
+				Type string `xml:"ACCTTYPE"`
+			} `xml:"BANKACCTFROM"`
+			Transactions []struct {
+				Type   string `xml:"TRNTYPE"`
+				Posted string `xml:"DTPOSTED"`
+				Amount string `xml:"TRNAMT"`
+				FITID  int64  `xml:"FITID"`
+				Name   string `xml:"NAME"`
+			} `xml:"BANKTRANLIST>STMTTRN"`
+			LedgerBalance string `xml:"LEDGERBAL>BALAMT"`
+		} `xml:"BANKMSGSRSV1>STMTTRNRS>STMTRS"`
+	}
+	err := xml.Unmarshal([]byte(generate(t, FormatOFX)), &got)
+	require.NoError(t, err)
+
+	require.Equal(t, util.USD, got.Statement.Currency)
+	require.Equal(t, int64(7), got.Statement.Account.ID)
+	require.Equal(t, "SAVINGS", got.Statement.Account.Type)
+
+	require.Len(t, got.Statement.Transactions, 2)
+	require.Equal(t, "CREDIT", got.Statement.Transactions[0].Type)
+	require.Equal(t, "20210302090000", got.Statement.Transactions[0].Posted)
+	require.Equal(t, "5.00", got.Statement.Transactions[0].Amount)
+	require.Equal(t, int64(11), got.Statement.Transactions[0].FITID)
+	require.Equal(t, "DEBIT", got.Statement.Transactions[1].Type)
+	require.Equal(t, "-2.00", got.Statement.Transactions[1].Amount)
+	require.Equal(t, "transfer", got.Statement.Transactions[1].Name)
+
+	require.Equal(t, "13.00", got.Statement.LedgerBalance)
+}
+
+func TestGeneratePagination(t *testing.T) {
+	entries := make([]db.ListStatementEntriesRow, pageSize)
+	for i := range entries {
+		entries[i] = db.ListStatementEntriesRow{ID: int64(i + 1), AccountID: 7, Amount: 1}
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetAccountBalanceAt(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(int64(0), nil)
+	gomock.InOrder(
+		store.EXPECT().
+			ListStatementEntries(gomock.Any(), gomock.Eq(db.ListStatementEntriesParams{
+				AccountID: 7,
+				FromTime:  testFrom,
+				ToTime:    testTo,
+				PageSize:  pageSize,
+			})).
+			Times(1).
+			Return(entries, nil),
+		store.EXPECT().
+			ListStatementEntries(gomock.Any(), gomock.Eq(db.ListStatementEntriesParams{
+				AccountID: 7,
+				AfterID:   pageSize,
+				FromTime:  testFrom,
+				ToTime:    testTo,
+				PageSize:  pageSize,
+			})).
+			Times(1).
+			Return([]db.ListStatementEntriesRow{{ID: pageSize + 1, AccountID: 7, Amount: 1}}, nil),
+	)
+
+	var buf bytes.Buffer
+	writer, err := NewWriter(FormatCSV, &buf)
+	require.NoError(t, err)
+
+	closing, err := Generate(context.Background(), store, testHeader(), writer)
+	require.NoError(t, err)
+	require.Equal(t, int64(pageSize+1), closing)
+}
+
+func TestGenerateError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetAccountBalanceAt(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(int64(0), sql.ErrConnDone)
+	store.EXPECT().
+		ListStatementEntries(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	var buf bytes.Buffer
+	writer, err := NewWriter(FormatJSON, &buf)
+	require.NoError(t, err)
+
+	_, err = Generate(context.Background(), store, testHeader(), writer)
+	require.Equal(t, sql.ErrConnDone, err)
+	require.Zero(t, buf.Len())
+}
+
+func TestUnknownFormat(t *testing.T) {
+	writer, err := NewWriter("pdf", &bytes.Buffer{})
+	require.Equal(t, ErrUnknownFormat, err)
+	require.Nil(t, writer)
+}
@@ -3,6 +3,7 @@ package token
 import "time"
 
 type Maker interface {
-	CreateToken(username string, duration time.Duration) (string, error)
-	VerifyToken(tokenString string) (*Payload, error)
+	CreateToken(username string, role string, tokenType TokenType, duration time.Duration) (string, *Payload, error)
+	// VerifyToken 校验 token 并要求它是 tokenType 类型，类型不符时返回 ErrInvalidToken
+	VerifyToken(tokenString string, tokenType TokenType) (*Payload, error)
 }
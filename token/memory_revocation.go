@@ -0,0 +1,62 @@
+package token
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MemoryRevocationStore 进程内的作废列表，适用于单实例部署和测试
+type MemoryRevocationStore struct {
+	mu     sync.RWMutex
+	tokens map[uuid.UUID]time.Time
+	users  map[string]time.Time
+}
+
+func NewMemoryRevocationStore() *MemoryRevocationStore {
+	return &MemoryRevocationStore{
+		tokens: make(map[uuid.UUID]time.Time),
+		users:  make(map[string]time.Time),
+	}
+}
+
+func (store *MemoryRevocationStore) Revoke(ctx context.Context, id uuid.UUID, username string, expiresAt time.Time) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	// 顺便清理已经过期的记录，过期的 token 本身就无法通过校验
+	now := time.Now()
+	for tokenID, expiredAt := range store.tokens {
+		if now.After(expiredAt) {
+			delete(store.tokens, tokenID)
+		}
+	}
+
+	store.tokens[id] = expiresAt
+	return nil
+}
+
+func (store *MemoryRevocationStore) RevokeUser(ctx context.Context, username string, before time.Time) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	if before.After(store.users[username]) {
+		store.users[username] = before
+	}
+	return nil
+}
+
+func (store *MemoryRevocationStore) IsRevoked(ctx context.Context, payload *Payload) (bool, error) {
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+
+	if _, ok := store.tokens[payload.ID]; ok {
+		return true, nil
+	}
+	if before, ok := store.users[payload.Username]; ok && !payload.IssuedAt.After(before) {
+		return true, nil
+	}
+	return false, nil
+}
@@ -0,0 +1,50 @@
+package token
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	db "github.com/xiusl/bank/db/sqlc"
+)
+
+// PostgresRevocationStore 把作废记录保存在数据库中，多实例之间共享
+type PostgresRevocationStore struct {
+	querier db.Querier
+}
+
+func NewPostgresRevocationStore(querier db.Querier) *PostgresRevocationStore {
+	return &PostgresRevocationStore{querier: querier}
+}
+
+func (store *PostgresRevocationStore) Revoke(ctx context.Context, id uuid.UUID, username string, expiresAt time.Time) error {
+	return store.querier.CreateRevokedToken(ctx, db.CreateRevokedTokenParams{
+		ID:        id,
+		Username:  username,
+		ExpiresAt: expiresAt,
+	})
+}
+
+func (store *PostgresRevocationStore) RevokeUser(ctx context.Context, username string, before time.Time) error {
+	return store.querier.UpsertUserTokenRevocation(ctx, db.UpsertUserTokenRevocationParams{
+		Username:      username,
+		RevokedBefore: before,
+	})
+}
+
+func (store *PostgresRevocationStore) IsRevoked(ctx context.Context, payload *Payload) (bool, error) {
+	revoked, err := store.querier.IsTokenRevoked(ctx, payload.ID)
+	if err != nil || revoked {
+		return revoked, err
+	}
+
+	revocation, err := store.querier.GetUserTokenRevocation(ctx, payload.Username)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, err
+	}
+	return !payload.IssuedAt.After(revocation.RevokedBefore), nil
+}
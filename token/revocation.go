@@ -0,0 +1,19 @@
+package token
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RevocationStore 记录被提前作废的 token，VerifyToken 只校验签名和过期时间，
+// 需要再查一次这里才能拒绝已登出或被管理员踢下线的 token
+type RevocationStore interface {
+	// Revoke 作废单个 token，expiresAt 之后记录可以清理
+	Revoke(ctx context.Context, id uuid.UUID, username string, expiresAt time.Time) error
+	// RevokeUser 作废用户在 before 及之前签发的所有 token
+	RevokeUser(ctx context.Context, username string, before time.Time) error
+	// IsRevoked 判断 token 是否已被作废
+	IsRevoked(ctx context.Context, payload *Payload) (bool, error)
+}
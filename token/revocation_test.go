@@ -0,0 +1,65 @@
+package token
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xiusl/bank/util"
+)
+
+func TestMemoryRevocationStore(t *testing.T) {
+	store := NewMemoryRevocationStore()
+	ctx := context.Background()
+
+	payload1, err := NewPayload(util.RandomOwner(), util.DepositorRole, AccessToken, time.Minute)
+	require.NoError(t, err)
+	payload2, err := NewPayload(payload1.Username, util.DepositorRole, AccessToken, time.Minute)
+	require.NoError(t, err)
+
+	revoked, err := store.IsRevoked(ctx, payload1)
+	require.NoError(t, err)
+	require.False(t, revoked)
+
+	err = store.Revoke(ctx, payload1.ID, payload1.Username, payload1.ExpiredAt)
+	require.NoError(t, err)
+
+	revoked, err = store.IsRevoked(ctx, payload1)
+	require.NoError(t, err)
+	require.True(t, revoked)
+
+	revoked, err = store.IsRevoked(ctx, payload2)
+	require.NoError(t, err)
+	require.False(t, revoked)
+}
+
+func TestMemoryRevocationStoreRevokeUser(t *testing.T) {
+	store := NewMemoryRevocationStore()
+	ctx := context.Background()
+
+	username := util.RandomOwner()
+	oldPayload, err := NewPayload(username, util.DepositorRole, AccessToken, time.Minute)
+	require.NoError(t, err)
+	otherPayload, err := NewPayload(util.RandomOwner(), util.DepositorRole, AccessToken, time.Minute)
+	require.NoError(t, err)
+
+	err = store.RevokeUser(ctx, username, time.Now())
+	require.NoError(t, err)
+
+	revoked, err := store.IsRevoked(ctx, oldPayload)
+	require.NoError(t, err)
+	require.True(t, revoked)
+
+	revoked, err = store.IsRevoked(ctx, otherPayload)
+	require.NoError(t, err)
+	require.False(t, revoked)
+
+	newPayload, err := NewPayload(username, util.DepositorRole, AccessToken, time.Minute)
+	require.NoError(t, err)
+	newPayload.IssuedAt = time.Now().Add(time.Second)
+
+	revoked, err = store.IsRevoked(ctx, newPayload)
+	require.NoError(t, err)
+	require.False(t, revoked)
+}
@@ -7,11 +7,36 @@ import (
 )
 
 type Config struct {
-	DBDriver            string        `mapstructure:"DB_DRIVER"`
-	DBSource            string        `mapstructure:"DB_SOURCE"`
-	ServerAddress       string        `mapstructure:"SERVER_ADDRESS"`
-	TokenSymmertricKey  string        `mapstructure:"TOKEN_SYSMMERTRIC_KEY"`
-	AccessTokenDuration time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
+	DBDriver                string        `mapstructure:"DB_DRIVER"`
+	DBSource                string        `mapstructure:"DB_SOURCE"`
+	ServerAddress           string        `mapstructure:"SERVER_ADDRESS"`
+	GRPCServerAddress       string        `mapstructure:"GRPC_SERVER_ADDRESS"`
+	HTTPReadTimeout         time.Duration `mapstructure:"HTTP_READ_TIMEOUT"`
+	HTTPWriteTimeout        time.Duration `mapstructure:"HTTP_WRITE_TIMEOUT"`
+	HTTPStreamWriteTimeout  time.Duration `mapstructure:"HTTP_STREAM_WRITE_TIMEOUT"`
+	HTTPIdleTimeout         time.Duration `mapstructure:"HTTP_IDLE_TIMEOUT"`
+	ShutdownTimeout         time.Duration `mapstructure:"SHUTDOWN_TIMEOUT"`
+	TokenSymmertricKey      string        `mapstructure:"TOKEN_SYSMMERTRIC_KEY"`
+	AccessTokenDuration     time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
+	RefreshTokenDuration    time.Duration `mapstructure:"REFRESH_TOKEN_DURATION"`
+	RevocationStore         string        `mapstructure:"REVOCATION_STORE"`
+	IdempotencyKeyTTL       time.Duration `mapstructure:"IDEMPOTENCY_KEY_TTL"`
+	ReconcileInterval       time.Duration `mapstructure:"RECONCILE_INTERVAL"`
+	FXRatesFile             string        `mapstructure:"FX_RATES_FILE"`
+	FXSpread                float64       `mapstructure:"FX_SPREAD"`
+	TransferFeesFile        string        `mapstructure:"TRANSFER_FEES_FILE"`
+	SchedulerInterval       time.Duration `mapstructure:"SCHEDULER_INTERVAL"`
+	SchedulerRetryDelay     time.Duration `mapstructure:"SCHEDULER_RETRY_DELAY"`
+	SchedulerMaxAttempts    int32         `mapstructure:"SCHEDULER_MAX_ATTEMPTS"`
+	HoldDuration            time.Duration `mapstructure:"HOLD_DURATION"`
+	HoldExpiryInterval      time.Duration `mapstructure:"HOLD_EXPIRY_INTERVAL"`
+	CurrencyRefreshInterval time.Duration `mapstructure:"CURRENCY_REFRESH_INTERVAL"`
+	InterestRatesFile       string        `mapstructure:"INTEREST_RATES_FILE"`
+	EventPublisher          string        `mapstructure:"EVENT_PUBLISHER"`
+	EventPublisherTarget    string        `mapstructure:"EVENT_PUBLISHER_TARGET"`
+	EventRelayInterval      time.Duration `mapstructure:"EVENT_RELAY_INTERVAL"`
+	WebhookInterval         time.Duration `mapstructure:"WEBHOOK_INTERVAL"`
+	WebhookMaxAttempts      int32         `mapstructure:"WEBHOOK_MAX_ATTEMPTS"`
 }
 
 func LoadConfig(path string) (config Config, err error) {
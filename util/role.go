@@ -0,0 +1,8 @@
+package util
+
+// 用户角色
+const (
+	DepositorRole = "depositor"
+	BankerRole    = "banker"
+	AdminRole     = "admin"
+)
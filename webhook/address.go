@@ -0,0 +1,105 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+)
+
+// ErrForbiddenURL webhook 地址不是 https，或指向回环、内网、链路本地等不允许访问的地址
+var ErrForbiddenURL = errors.New("forbidden webhook url")
+
+// forbiddenNetworks 不允许投递的网段，回环、链路本地（含云厂商的元数据地址 169.254.169.254）
+// 和多播地址由 net.IP 的方法判断
+var forbiddenNetworks = mustParseCIDRs(
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"100.64.0.0/10",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"198.18.0.0/15",
+	"fc00::/7",
+)
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks[i] = network
+	}
+	return networks
+}
+
+// ValidateURL 检查注册的 webhook 地址：必须是 https，主机是 IP 时不能是不允许访问的地址。
+// 域名要到投递时解析后才能检查，见 NewClient
+func ValidateURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("%q: %w", rawURL, ErrForbiddenURL)
+	}
+	if u.Scheme != "https" || len(u.Hostname()) == 0 {
+		return fmt.Errorf("%q must be an https url: %w", rawURL, ErrForbiddenURL)
+	}
+	if ip := net.ParseIP(u.Hostname()); ip != nil {
+		return checkIP(ip)
+	}
+	return nil
+}
+
+// checkIP 拒绝回环、内网、链路本地、多播和未指定地址
+func checkIP(ip net.IP) error {
+	if ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return fmt.Errorf("%s: %w", ip, ErrForbiddenURL)
+	}
+	for _, network := range forbiddenNetworks {
+		if network.Contains(ip) {
+			return fmt.Errorf("%s: %w", ip, ErrForbiddenURL)
+		}
+	}
+	return nil
+}
+
+// dialControl 在 DNS 解析之后、建立连接之前检查目标地址，
+// 防止域名解析到内网地址或解析结果在注册后被修改
+func dialControl(network string, address string, conn syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return fmt.Errorf("%s: %w", address, ErrForbiddenURL)
+	}
+	return checkIP(ip)
+}
+
+// NewClient 返回投递用的 http.Client：每次建立连接前检查解析后的地址，
+// 不走环境变量中的代理，重定向也只允许到 https 地址
+func NewClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: requestTimeout,
+		Control: dialControl,
+	}
+	transport := &http.Transport{
+		DialContext:         dialer.DialContext,
+		TLSHandshakeTimeout: requestTimeout,
+		MaxIdleConnsPerHost: 2,
+	}
+	return &http.Client{
+		Transport: transport,
+		Timeout:   requestTimeout,
+		CheckRedirect: func(request *http.Request, via []*http.Request) error {
+			if len(via) >= 5 {
+				return errors.New("stopped after 5 redirects")
+			}
+			return ValidateURL(request.URL.String())
+		},
+	}
+}
@@ -0,0 +1,47 @@
+package webhook
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateURL(t *testing.T) {
+	testCases := []struct {
+		name  string
+		url   string
+		valid bool
+	}{
+		{name: "OK", url: "https://example.com/hooks", valid: true},
+		{name: "PublicIP", url: "https://93.184.216.34/hooks", valid: true},
+		{name: "PlainHTTP", url: "http://example.com/hooks"},
+		{name: "NoHost", url: "https:///hooks"},
+		{name: "Loopback", url: "https://127.0.0.1/hooks"},
+		{name: "Private", url: "https://10.0.0.8/hooks"},
+		{name: "Metadata", url: "https://169.254.169.254/latest/meta-data"},
+		{name: "IPv6Loopback", url: "https://[::1]/hooks"},
+		{name: "IPv4MappedIPv6", url: "https://[::ffff:192.168.1.1]/hooks"},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateURL(tc.url)
+			if tc.valid {
+				require.NoError(t, err)
+				return
+			}
+			require.True(t, errors.Is(err, ErrForbiddenURL))
+		})
+	}
+}
+
+func TestDialControl(t *testing.T) {
+	require.NoError(t, dialControl("tcp4", "93.184.216.34:443", nil))
+	require.True(t, errors.Is(dialControl("tcp4", "127.0.0.1:443", nil), ErrForbiddenURL))
+	require.True(t, errors.Is(dialControl("tcp4", "172.20.1.1:443", nil), ErrForbiddenURL))
+	require.True(t, errors.Is(dialControl("tcp6", "[fd00::1]:443", nil), ErrForbiddenURL))
+	require.True(t, errors.Is(checkIP(net.ParseIP("100.64.0.1")), ErrForbiddenURL))
+}
@@ -0,0 +1,204 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	db "github.com/xiusl/bank/db/sqlc"
+	"github.com/xiusl/bank/outbox"
+)
+
+const (
+	// batchSize 每轮最多领取的投递数量
+	batchSize = 100
+	// leaseDuration 领取后在这段时间内不会被其他实例重复领取
+	leaseDuration = 5 * time.Minute
+	// requestTimeout 单次投递请求的超时时间
+	requestTimeout = 10 * time.Second
+	// maxErrorLength 记录的错误信息和响应内容的最大长度
+	maxErrorLength = 512
+
+	// 第一次重试前等待 baseDelay，之后每次翻倍，最长 maxDelay
+	baseDelay = 30 * time.Second
+	maxDelay  = 6 * time.Hour
+)
+
+// 投递请求中除签名外的请求头
+const (
+	DeliveryHeader  = "X-Webhook-Delivery"
+	EventIDHeader   = "X-Event-Id"
+	EventTypeHeader = "X-Event-Type"
+)
+
+// Dispatcher 在后台把待投递的事件 POST 到用户注册的 endpoint，请求体用 endpoint 的密钥签名。
+// 非 2xx 响应或请求失败时按指数退避重试，失败 maxAttempts 次后放弃，用户可以通过 API 重新投递
+type Dispatcher struct {
+	store       db.Store
+	client      *http.Client
+	interval    time.Duration
+	maxAttempts int32
+}
+
+func NewDispatcher(store db.Store, interval time.Duration, maxAttempts int32) *Dispatcher {
+	return &Dispatcher{
+		store:       store,
+		client:      NewClient(),
+		interval:    interval,
+		maxAttempts: maxAttempts,
+	}
+}
+
+// Start 立即投递一轮，之后每隔 interval 投递一轮，直到 ctx 结束
+func (dispatcher *Dispatcher) Start(ctx context.Context) {
+	ticker := time.NewTicker(dispatcher.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := dispatcher.RunOnce(ctx, time.Now()); err != nil {
+			log.Println("dispatch webhooks failed:", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce 领取 now 时已到期的投递并逐个发送，返回处理完成的数量。
+// 单个投递出错时记录日志并继续处理其余的，它在领取租约到期后会被重新领取
+func (dispatcher *Dispatcher) RunOnce(ctx context.Context, now time.Time) (int, error) {
+	due, err := dispatcher.store.ClaimDueWebhookDeliveries(ctx, db.ClaimDueWebhookDeliveriesParams{
+		LeaseUntil: now.Add(leaseDuration),
+		Now:        now,
+		BatchSize:  batchSize,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for _, delivery := range due {
+		if err := dispatcher.deliver(ctx, delivery, now); err != nil {
+			log.Printf("deliver webhook %d failed: %v", delivery.ID, err)
+			continue
+		}
+		delivered++
+	}
+	return delivered, nil
+}
+
+// deliver 发送一次投递并记录结果，只有查询或记录失败时返回错误
+func (dispatcher *Dispatcher) deliver(ctx context.Context, delivery db.WebhookDelivery, now time.Time) error {
+	arg := db.RecordWebhookDeliveryAttemptParams{
+		ID:            delivery.ID,
+		NextAttemptAt: now,
+	}
+
+	endpoint, err := dispatcher.store.GetWebhookEndpoint(ctx, delivery.EndpointID)
+	if err == sql.ErrNoRows {
+		// endpoint 已被删除，不再重试
+		arg.Status = db.WebhookDeliveryFailed
+		arg.LastError = "endpoint not found"
+		_, err = dispatcher.store.RecordWebhookDeliveryAttempt(ctx, arg)
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	event, err := dispatcher.store.GetEvent(ctx, delivery.EventID)
+	if err != nil {
+		return err
+	}
+
+	if !endpoint.Active {
+		arg.Status = db.WebhookDeliveryFailed
+		arg.LastError = "endpoint is inactive"
+		_, err = dispatcher.store.RecordWebhookDeliveryAttempt(ctx, arg)
+		return err
+	}
+
+	arg.ResponseCode, err = dispatcher.send(ctx, endpoint, delivery, event)
+	switch {
+	case err == nil:
+		arg.Status = db.WebhookDeliverySucceeded
+		arg.DeliveredAt = sql.NullTime{Time: now, Valid: true}
+	case delivery.Attempts+1 >= dispatcher.maxAttempts:
+		arg.Status = db.WebhookDeliveryFailed
+		arg.LastError = truncate(err.Error())
+	default:
+		arg.Status = db.WebhookDeliveryPending
+		arg.LastError = truncate(err.Error())
+		arg.NextAttemptAt = now.Add(Backoff(delivery.Attempts + 1))
+	}
+
+	_, err = dispatcher.store.RecordWebhookDeliveryAttempt(ctx, arg)
+	return err
+}
+
+// send 发送签名后的请求，返回响应码；没有收到响应时响应码为 0。
+// 签名使用发送时的时间，一轮中排在后面的投递不会因为时间戳过旧被接收方拒绝
+func (dispatcher *Dispatcher) send(ctx context.Context, endpoint db.WebhookEndpoint, delivery db.WebhookDelivery, event db.Event) (int32, error) {
+	body, err := json.Marshal(outbox.NewMessage(event))
+	if err != nil {
+		return 0, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.Url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	// 只投递到 https 地址，目标 IP 在建立连接时由 client 检查
+	if request.URL.Scheme != "https" {
+		return 0, fmt.Errorf("%q must be an https url: %w", endpoint.Url, ErrForbiddenURL)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(SignatureHeader, Sign(endpoint.Secret, time.Now(), body))
+	request.Header.Set(DeliveryHeader, strconv.FormatInt(delivery.ID, 10))
+	request.Header.Set(EventIDHeader, strconv.FormatInt(event.ID, 10))
+	request.Header.Set(EventTypeHeader, event.Type)
+
+	response, err := dispatcher.client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+
+	code := int32(response.StatusCode)
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		content, _ := io.ReadAll(io.LimitReader(response.Body, maxErrorLength))
+		return code, fmt.Errorf("endpoint responded %s: %s", response.Status, content)
+	}
+	io.Copy(io.Discard, response.Body)
+	return code, nil
+}
+
+// Backoff 返回第 attempts 次失败后到下一次重试的等待时间
+func Backoff(attempts int32) time.Duration {
+	delay := baseDelay
+	for i := int32(1); i < attempts; i++ {
+		delay *= 2
+		if delay >= maxDelay {
+			return maxDelay
+		}
+	}
+	return delay
+}
+
+// truncate 截断过长的错误信息，响应内容可能不是合法的 UTF-8，需要去掉无效字节才能写入数据库
+func truncate(s string) string {
+	if len(s) > maxErrorLength {
+		s = s[:maxErrorLength]
+	}
+	return strings.ToValidUTF8(s, "")
+}
@@ -0,0 +1,284 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/xiusl/bank/db/mock"
+	db "github.com/xiusl/bank/db/sqlc"
+	"github.com/xiusl/bank/outbox"
+)
+
+func TestBackoff(t *testing.T) {
+	require.Equal(t, 30*time.Second, Backoff(1))
+	require.Equal(t, time.Minute, Backoff(2))
+	require.Equal(t, 4*time.Minute, Backoff(4))
+	require.Equal(t, maxDelay, Backoff(20))
+}
+
+func TestRunOnce(t *testing.T) {
+	// 一轮开始的时间早于实际发送的时间，签名应使用发送时的时间
+	now := time.Now().Add(-time.Hour).Truncate(time.Second)
+	secret := "whsec_test"
+
+	event := db.Event{
+		ID:        9,
+		AccountID: 3,
+		Type:      db.EventTransferCreated,
+		Payload:   json.RawMessage(`{"id":1}`),
+		CreatedAt: now.Add(-time.Minute).UTC(),
+	}
+
+	testCases := []struct {
+		name      string
+		status    int
+		attempts  int32
+		active    bool
+		checkArgs func(t *testing.T, arg db.RecordWebhookDeliveryAttemptParams)
+	}{
+		{
+			name:   "OK",
+			status: http.StatusOK,
+			active: true,
+			checkArgs: func(t *testing.T, arg db.RecordWebhookDeliveryAttemptParams) {
+				require.Equal(t, db.WebhookDeliverySucceeded, arg.Status)
+				require.Equal(t, int32(http.StatusOK), arg.ResponseCode)
+				require.Empty(t, arg.LastError)
+				require.Equal(t, sql.NullTime{Time: now, Valid: true}, arg.DeliveredAt)
+			},
+		},
+		{
+			name:     "Retry",
+			status:   http.StatusServiceUnavailable,
+			attempts: 2,
+			active:   true,
+			checkArgs: func(t *testing.T, arg db.RecordWebhookDeliveryAttemptParams) {
+				require.Equal(t, db.WebhookDeliveryPending, arg.Status)
+				require.Equal(t, int32(http.StatusServiceUnavailable), arg.ResponseCode)
+				require.Contains(t, arg.LastError, "503")
+				require.Equal(t, now.Add(2*time.Minute), arg.NextAttemptAt)
+				require.False(t, arg.DeliveredAt.Valid)
+			},
+		},
+		{
+			name:     "Exhausted",
+			status:   http.StatusInternalServerError,
+			attempts: 4,
+			active:   true,
+			checkArgs: func(t *testing.T, arg db.RecordWebhookDeliveryAttemptParams) {
+				require.Equal(t, db.WebhookDeliveryFailed, arg.Status)
+				require.Equal(t, int32(http.StatusInternalServerError), arg.ResponseCode)
+			},
+		},
+		{
+			name:   "InactiveEndpoint",
+			status: http.StatusOK,
+			active: false,
+			checkArgs: func(t *testing.T, arg db.RecordWebhookDeliveryAttemptParams) {
+				require.Equal(t, db.WebhookDeliveryFailed, arg.Status)
+				require.Zero(t, arg.ResponseCode)
+				require.Equal(t, "endpoint is inactive", arg.LastError)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			received := 0
+			receiver := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received++
+				body, err := ioutil.ReadAll(r.Body)
+				require.NoError(t, err)
+
+				require.NoError(t, Verify(secret, r.Header.Get(SignatureHeader), body, time.Now(), time.Minute))
+				require.Equal(t, "5", r.Header.Get(DeliveryHeader))
+				require.Equal(t, strconv.FormatInt(event.ID, 10), r.Header.Get(EventIDHeader))
+				require.Equal(t, event.Type, r.Header.Get(EventTypeHeader))
+
+				var message outbox.Message
+				require.NoError(t, json.Unmarshal(body, &message))
+				require.Equal(t, outbox.NewMessage(event), message)
+
+				w.WriteHeader(tc.status)
+			}))
+			defer receiver.Close()
+
+			endpoint := db.WebhookEndpoint{ID: 2, Url: receiver.URL, Secret: secret, Active: tc.active}
+			delivery := db.WebhookDelivery{ID: 5, EndpointID: endpoint.ID, EventID: event.ID, Attempts: tc.attempts}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				ClaimDueWebhookDeliveries(gomock.Any(), gomock.Eq(db.ClaimDueWebhookDeliveriesParams{
+					LeaseUntil: now.Add(leaseDuration),
+					Now:        now,
+					BatchSize:  batchSize,
+				})).
+				Times(1).
+				Return([]db.WebhookDelivery{delivery}, nil)
+			store.EXPECT().GetWebhookEndpoint(gomock.Any(), gomock.Eq(endpoint.ID)).Times(1).Return(endpoint, nil)
+			store.EXPECT().GetEvent(gomock.Any(), gomock.Eq(event.ID)).Times(1).Return(event, nil)
+			store.EXPECT().
+				RecordWebhookDeliveryAttempt(gomock.Any(), gomock.Any()).
+				Times(1).
+				DoAndReturn(func(ctx context.Context, arg db.RecordWebhookDeliveryAttemptParams) (db.WebhookDelivery, error) {
+					require.Equal(t, delivery.ID, arg.ID)
+					tc.checkArgs(t, arg)
+					return delivery, nil
+				})
+
+			dispatcher := NewDispatcher(store, time.Minute, 5)
+			// 测试服务器监听在回环地址上，使用它自带的 client 绕过地址检查
+			dispatcher.client = receiver.Client()
+			n, err := dispatcher.RunOnce(context.Background(), now)
+			require.NoError(t, err)
+			require.Equal(t, 1, n)
+
+			if tc.active {
+				require.Equal(t, 1, received)
+			} else {
+				require.Zero(t, received)
+			}
+		})
+	}
+}
+
+func TestRunOnceUnreachable(t *testing.T) {
+	now := time.Now()
+
+	receiver := httptest.NewTLSServer(http.NotFoundHandler())
+	url := receiver.URL
+	client := receiver.Client()
+	receiver.Close()
+
+	endpoint := db.WebhookEndpoint{ID: 2, Url: url, Secret: "whsec_test", Active: true}
+	delivery := db.WebhookDelivery{ID: 5, EndpointID: endpoint.ID, EventID: 9}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().ClaimDueWebhookDeliveries(gomock.Any(), gomock.Any()).Times(1).Return([]db.WebhookDelivery{delivery}, nil)
+	store.EXPECT().GetWebhookEndpoint(gomock.Any(), gomock.Eq(endpoint.ID)).Times(1).Return(endpoint, nil)
+	store.EXPECT().GetEvent(gomock.Any(), gomock.Eq(delivery.EventID)).Times(1).Return(db.Event{ID: 9}, nil)
+	store.EXPECT().
+		RecordWebhookDeliveryAttempt(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(ctx context.Context, arg db.RecordWebhookDeliveryAttemptParams) (db.WebhookDelivery, error) {
+			require.Equal(t, db.WebhookDeliveryPending, arg.Status)
+			require.Zero(t, arg.ResponseCode)
+			require.NotEmpty(t, arg.LastError)
+			require.Equal(t, now.Add(baseDelay), arg.NextAttemptAt)
+			return delivery, nil
+		})
+
+	dispatcher := NewDispatcher(store, time.Minute, 5)
+	dispatcher.client = client
+	_, err := dispatcher.RunOnce(context.Background(), now)
+	require.NoError(t, err)
+}
+
+func TestRunOnceForbiddenURL(t *testing.T) {
+	now := time.Now()
+
+	received := 0
+	receiver := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received++
+	}))
+	defer receiver.Close()
+
+	plain := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received++
+	}))
+	defer plain.Close()
+
+	testCases := []struct {
+		name string
+		url  string
+	}{
+		// 默认的 client 在建立连接前拒绝回环地址
+		{name: "Loopback", url: receiver.URL},
+		{name: "LocalhostName", url: strings.Replace(receiver.URL, "127.0.0.1", "localhost", 1)},
+		{name: "PlainHTTP", url: plain.URL},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			endpoint := db.WebhookEndpoint{ID: 2, Url: tc.url, Secret: "whsec_test", Active: true}
+			delivery := db.WebhookDelivery{ID: 5, EndpointID: endpoint.ID, EventID: 9}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().ClaimDueWebhookDeliveries(gomock.Any(), gomock.Any()).Times(1).Return([]db.WebhookDelivery{delivery}, nil)
+			store.EXPECT().GetWebhookEndpoint(gomock.Any(), gomock.Eq(endpoint.ID)).Times(1).Return(endpoint, nil)
+			store.EXPECT().GetEvent(gomock.Any(), gomock.Eq(delivery.EventID)).Times(1).Return(db.Event{ID: 9}, nil)
+			store.EXPECT().
+				RecordWebhookDeliveryAttempt(gomock.Any(), gomock.Any()).
+				Times(1).
+				DoAndReturn(func(ctx context.Context, arg db.RecordWebhookDeliveryAttemptParams) (db.WebhookDelivery, error) {
+					require.Equal(t, db.WebhookDeliveryPending, arg.Status)
+					require.Zero(t, arg.ResponseCode)
+					require.Contains(t, arg.LastError, ErrForbiddenURL.Error())
+					return delivery, nil
+				})
+
+			dispatcher := NewDispatcher(store, time.Minute, 5)
+			_, err := dispatcher.RunOnce(context.Background(), now)
+			require.NoError(t, err)
+		})
+	}
+	require.Zero(t, received)
+}
+
+func TestRunOnceContinuesAfterError(t *testing.T) {
+	now := time.Now()
+
+	deleted := db.WebhookDelivery{ID: 5, EndpointID: 2, EventID: 9}
+	failing := db.WebhookDelivery{ID: 6, EndpointID: 3, EventID: 9}
+	inactive := db.WebhookDelivery{ID: 7, EndpointID: 4, EventID: 9}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ClaimDueWebhookDeliveries(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return([]db.WebhookDelivery{deleted, failing, inactive}, nil)
+	store.EXPECT().GetWebhookEndpoint(gomock.Any(), gomock.Eq(deleted.EndpointID)).Times(1).Return(db.WebhookEndpoint{}, sql.ErrNoRows)
+	store.EXPECT().GetWebhookEndpoint(gomock.Any(), gomock.Eq(failing.EndpointID)).Times(1).Return(db.WebhookEndpoint{}, sql.ErrConnDone)
+	store.EXPECT().GetWebhookEndpoint(gomock.Any(), gomock.Eq(inactive.EndpointID)).Times(1).Return(db.WebhookEndpoint{ID: 4}, nil)
+	store.EXPECT().GetEvent(gomock.Any(), gomock.Eq(inactive.EventID)).Times(1).Return(db.Event{ID: 9}, nil)
+
+	// 已删除的 endpoint 不再重试，查询失败的投递留待租约到期后重试，不影响同一批的其他投递
+	store.EXPECT().
+		RecordWebhookDeliveryAttempt(gomock.Any(), gomock.Any()).
+		Times(2).
+		DoAndReturn(func(ctx context.Context, arg db.RecordWebhookDeliveryAttemptParams) (db.WebhookDelivery, error) {
+			require.NotEqual(t, failing.ID, arg.ID)
+			require.Equal(t, db.WebhookDeliveryFailed, arg.Status)
+			return db.WebhookDelivery{ID: arg.ID}, nil
+		})
+
+	n, err := NewDispatcher(store, time.Minute, 5).RunOnce(context.Background(), now)
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+}
@@ -0,0 +1,79 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SignatureHeader 投递请求中携带签名的请求头，格式为 t=<unix 时间戳>,v1=<hex 签名>
+const SignatureHeader = "X-Webhook-Signature"
+
+// secretPrefix 签名密钥的前缀，便于用户识别
+const secretPrefix = "whsec_"
+
+// ErrInvalidSignature 签名头格式错误、签名不匹配或时间戳超出容忍范围
+var ErrInvalidSignature = errors.New("invalid webhook signature")
+
+// NewSecret 生成一个随机的签名密钥
+func NewSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return secretPrefix + hex.EncodeToString(b), nil
+}
+
+// Sign 用 secret 对 "<时间戳>.<请求体>" 做 HMAC-SHA256，返回签名头的值。
+// 时间戳参与签名，接收方可以据此拒绝重放的旧请求
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	t := timestamp.Unix()
+	return fmt.Sprintf("t=%d,v1=%s", t, hex.EncodeToString(mac(secret, t, body)))
+}
+
+// Verify 检查签名头是否由 secret 对 body 签出，且时间戳与 now 相差不超过 tolerance
+func Verify(secret string, header string, body []byte, now time.Time, tolerance time.Duration) error {
+	var t int64
+	var signature []byte
+	for _, part := range strings.Split(header, ",") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return ErrInvalidSignature
+		}
+		var err error
+		switch kv[0] {
+		case "t":
+			t, err = strconv.ParseInt(kv[1], 10, 64)
+		case "v1":
+			signature, err = hex.DecodeString(kv[1])
+		}
+		if err != nil {
+			return ErrInvalidSignature
+		}
+	}
+	if t == 0 || signature == nil {
+		return ErrInvalidSignature
+	}
+
+	age := now.Sub(time.Unix(t, 0))
+	if age > tolerance || age < -tolerance {
+		return fmt.Errorf("timestamp %d is too old: %w", t, ErrInvalidSignature)
+	}
+	if !hmac.Equal(signature, mac(secret, t, body)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func mac(secret string, timestamp int64, body []byte) []byte {
+	h := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(h, "%d.", timestamp)
+	h.Write(body)
+	return h.Sum(nil)
+}
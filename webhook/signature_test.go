@@ -0,0 +1,55 @@
+package webhook
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignature(t *testing.T) {
+	secret, err := NewSecret()
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(secret, secretPrefix))
+
+	other, err := NewSecret()
+	require.NoError(t, err)
+	require.NotEqual(t, secret, other)
+
+	now := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	body := []byte(`{"id":1}`)
+	header := Sign(secret, now, body)
+	require.True(t, strings.HasPrefix(header, "t=1614556800,v1="))
+
+	testCases := []struct {
+		name   string
+		secret string
+		header string
+		body   []byte
+		now    time.Time
+		ok     bool
+	}{
+		{name: "OK", secret: secret, header: header, body: body, now: now, ok: true},
+		{name: "WithinTolerance", secret: secret, header: header, body: body, now: now.Add(4 * time.Minute), ok: true},
+		{name: "WrongSecret", secret: other, header: header, body: body, now: now},
+		{name: "TamperedBody", secret: secret, header: header, body: []byte(`{"id":2}`), now: now},
+		{name: "Expired", secret: secret, header: header, body: body, now: now.Add(6 * time.Minute)},
+		{name: "Malformed", secret: secret, header: "v1", body: body, now: now},
+		{name: "MissingTimestamp", secret: secret, header: strings.SplitN(header, ",", 2)[1], body: body, now: now},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			err := Verify(tc.secret, tc.header, tc.body, tc.now, 5*time.Minute)
+			if tc.ok {
+				require.NoError(t, err)
+			} else {
+				require.True(t, errors.Is(err, ErrInvalidSignature))
+			}
+		})
+	}
+}